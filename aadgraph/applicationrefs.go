@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
 )
 
 // ApplicationRefsClient performs operations on Applications.
@@ -20,6 +22,17 @@ func NewApplicationRefsClient(tenantId string) *ApplicationRefsClient {
 	}
 }
 
+// NewApplicationRefsClientForEnvironment returns a new ApplicationRefsClient for the specified national cloud environment
+func NewApplicationRefsClientForEnvironment(env environments.Environment, tenantId string) (*ApplicationRefsClient, error) {
+	client, err := NewClientForEnvironment(env, Version20, tenantId)
+	if err != nil {
+		return nil, err
+	}
+	return &ApplicationRefsClient{
+		BaseClient: client,
+	}, nil
+}
+
 // Get retrieves an Application manifest.
 func (c *ApplicationRefsClient) Get(ctx context.Context, appId string) (*ApplicationRef, int, error) {
 	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
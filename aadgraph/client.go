@@ -71,13 +71,8 @@ type Client struct {
 
 // NewClient returns a new Client configured with the specified API version and tenant ID.
 func NewClient(apiVersion ApiVersion, tenantId string) Client {
-	var endpoint string
-	if defaultEndpoint, _ := environments.AzurePublic().MicrosoftGraph.Endpoint(); defaultEndpoint != nil {
-		endpoint = *defaultEndpoint
-	}
-
 	return Client{
-		Endpoint:   endpoint,
+		Endpoint:   endpoints[environments.AzurePublicCloud],
 		ApiVersion: apiVersion,
 		TenantId:   tenantId,
 		httpClient: http.DefaultClient,
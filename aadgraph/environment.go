@@ -0,0 +1,39 @@
+package aadgraph
+
+import (
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+	"github.com/manicminer/hamilton/errors"
+)
+
+// endpoints maps national cloud environment names to their Azure Active Directory Graph endpoints.
+var endpoints = map[string]string{
+	environments.AzurePublicCloud:       "https://graph.windows.net",
+	"Canary":                            "https://graph.windows.net",
+	environments.AzureUSGovernmentCloud: "https://graph.windows.net",
+	"USGovernmentL5":                    "https://graph.windows.net",
+	environments.AzureChinaCloud:        "https://graph.chinacloudapi.cn",
+}
+
+// EndpointForEnvironment returns the Azure Active Directory Graph endpoint for the specified national cloud environment.
+func EndpointForEnvironment(env environments.Environment) (string, error) {
+	endpoint, ok := endpoints[env.Name]
+	if !ok {
+		return "", errors.UnsupportedFeatureError{
+			Feature:     "Azure Active Directory Graph",
+			Environment: env.Name,
+		}
+	}
+	return endpoint, nil
+}
+
+// NewClientForEnvironment returns a new Client configured with the Azure Active Directory Graph endpoint for the
+// specified national cloud environment.
+func NewClientForEnvironment(env environments.Environment, apiVersion ApiVersion, tenantId string) (Client, error) {
+	c := NewClient(apiVersion, tenantId)
+	endpoint, err := EndpointForEnvironment(env)
+	if err != nil {
+		return c, err
+	}
+	c.Endpoint = endpoint
+	return c, nil
+}
@@ -0,0 +1,32 @@
+package aadgraph
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+)
+
+func TestNewClientForEnvironment(t *testing.T) {
+	for _, tc := range []struct {
+		env      *environments.Environment
+		endpoint string
+	}{
+		{environments.AzurePublic(), "https://graph.windows.net"},
+		{environments.AzurePublicCanary(), "https://graph.windows.net"},
+		{environments.AzureUSGovernment(), "https://graph.windows.net"},
+		{environments.AzureUSGovernmentL5(), "https://graph.windows.net"},
+		{environments.AzureChina(), "https://graph.chinacloudapi.cn"},
+	} {
+		c, err := NewClientForEnvironment(*tc.env, Version16, "tenant")
+		if err != nil {
+			t.Fatalf("unexpected error for environment %q: %v", tc.env.Name, err)
+		}
+		if c.Endpoint != tc.endpoint {
+			t.Fatalf("got endpoint %q for environment %q, want %q", c.Endpoint, tc.env.Name, tc.endpoint)
+		}
+	}
+
+	if _, err := NewClientForEnvironment(environments.Environment{Name: "Unknown"}, Version16, "tenant"); err == nil {
+		t.Fatal("expected an error for an unknown environment, got nil")
+	}
+}
@@ -1,6 +1,9 @@
 package errors
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // AlreadyExistsError is an error returned when an entity or object being created already exists.
 type AlreadyExistsError struct {
@@ -12,3 +15,62 @@ type AlreadyExistsError struct {
 func (e AlreadyExistsError) Error() string {
 	return fmt.Sprintf("%s with ID %q already exists", e.Obj, e.Id)
 }
+
+// UnsupportedApiVersionError is returned when an operation or model field is not available in the API version configured for a client.
+type UnsupportedApiVersionError struct {
+	Operation  string
+	ApiVersion string
+	Required   string
+	Fields     []string
+}
+
+// Error returns an error string for UnsupportedApiVersionError.
+func (e UnsupportedApiVersionError) Error() string {
+	if len(e.Fields) > 0 {
+		return fmt.Sprintf("%s: field(s) %s are only available in API version %q, but the client is configured for %q", e.Operation, strings.Join(e.Fields, ", "), e.Required, e.ApiVersion)
+	}
+	return fmt.Sprintf("%s is only available in API version %q, but the client is configured for %q", e.Operation, e.Required, e.ApiVersion)
+}
+
+// UnsupportedFeatureError is returned when an operation relies on a feature that is not available in the national cloud
+// environment configured for a client.
+type UnsupportedFeatureError struct {
+	Feature     string
+	Environment string
+}
+
+// Error returns an error string for UnsupportedFeatureError.
+func (e UnsupportedFeatureError) Error() string {
+	return fmt.Sprintf("%s is not supported in the %q environment", e.Feature, e.Environment)
+}
+
+// RemovalLimitExceededError is returned when reconciling a relationship would remove a greater proportion of the
+// existing references than permitted, or would remove all of them without this being explicitly allowed.
+type RemovalLimitExceededError struct {
+	Relationship string
+	Current      int
+	Removals     int
+
+	// MaxPercentage is the maximum removal percentage that was exceeded, or zero when all references would have been
+	// removed.
+	MaxPercentage int
+}
+
+// Error returns an error string for RemovalLimitExceededError.
+func (e RemovalLimitExceededError) Error() string {
+	if e.MaxPercentage == 0 {
+		return fmt.Sprintf("refusing to remove all %d %s without explicitly allowing it", e.Current, e.Relationship)
+	}
+	return fmt.Sprintf("refusing to remove %d of %d %s, which exceeds the maximum removal percentage of %d%%", e.Removals, e.Current, e.Relationship, e.MaxPercentage)
+}
+
+// InvalidMembershipRuleError is returned when a dynamic membership rule cannot be parsed or refers to unknown properties.
+type InvalidMembershipRuleError struct {
+	Position int
+	Message  string
+}
+
+// Error returns an error string for InvalidMembershipRuleError.
+func (e InvalidMembershipRuleError) Error() string {
+	return fmt.Sprintf("invalid membership rule at position %d: %s", e.Position, e.Message)
+}
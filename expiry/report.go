@@ -0,0 +1,122 @@
+package expiry
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+// ObjectType is the type of object that a credential belongs to.
+type ObjectType = string
+
+const (
+	ObjectTypeApplication      ObjectType = "Application"
+	ObjectTypeServicePrincipal ObjectType = "ServicePrincipal"
+)
+
+// CredentialType is the kind of credential.
+type CredentialType = string
+
+const (
+	CredentialTypePassword          CredentialType = "Password"
+	CredentialTypeCertificate       CredentialType = "Certificate"
+	CredentialTypeFederatedIdentity CredentialType = "FederatedIdentity"
+)
+
+// Credential describes a single credential and the object that it belongs to. DaysRemaining is rounded down and is
+// negative for expired credentials, and both it and EndDateTime are nil for credentials that do not expire.
+type Credential struct {
+	ObjectType     ObjectType                 `json:"objectType"`
+	ObjectId       string                     `json:"objectId"`
+	AppId          string                     `json:"appId,omitempty"`
+	DisplayName    string                     `json:"displayName,omitempty"`
+	CredentialType CredentialType             `json:"credentialType"`
+	KeyId          string                     `json:"keyId"`
+	CredentialName string                     `json:"credentialName,omitempty"`
+	Usage          msgraph.KeyCredentialUsage `json:"usage,omitempty"`
+	StartDateTime  *time.Time                 `json:"startDateTime,omitempty"`
+	EndDateTime    *time.Time                 `json:"endDateTime,omitempty"`
+	DaysRemaining  *int                       `json:"daysRemaining,omitempty"`
+	Expired        bool                       `json:"expired"`
+	OwnerEmails    []string                   `json:"ownerEmails,omitempty"`
+}
+
+// csvHeader lists the columns written by WriteCSV.
+var csvHeader = []string{
+	"objectType",
+	"objectId",
+	"appId",
+	"displayName",
+	"credentialType",
+	"keyId",
+	"credentialName",
+	"usage",
+	"startDateTime",
+	"endDateTime",
+	"daysRemaining",
+	"expired",
+	"ownerEmails",
+}
+
+// WriteJSON writes credentials as an indented JSON array.
+func WriteJSON(w io.Writer, credentials []Credential) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(credentials); err != nil {
+		return fmt.Errorf("writing credentials: %v", err)
+	}
+	return nil
+}
+
+// WriteCSV writes credentials as CSV with a header row. Dates are formatted as RFC 3339, and multiple owner emails are
+// separated with semicolons.
+func WriteCSV(w io.Writer, credentials []Credential) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return fmt.Errorf("writing header: %v", err)
+	}
+
+	formatTime := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.UTC().Format(time.RFC3339)
+	}
+
+	for _, c := range credentials {
+		daysRemaining := ""
+		if c.DaysRemaining != nil {
+			daysRemaining = strconv.Itoa(*c.DaysRemaining)
+		}
+		record := []string{
+			c.ObjectType,
+			c.ObjectId,
+			c.AppId,
+			c.DisplayName,
+			c.CredentialType,
+			c.KeyId,
+			c.CredentialName,
+			c.Usage,
+			formatTime(c.StartDateTime),
+			formatTime(c.EndDateTime),
+			daysRemaining,
+			strconv.FormatBool(c.Expired),
+			strings.Join(c.OwnerEmails, ";"),
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("writing credential %q for %s %q: %v", c.KeyId, c.ObjectType, c.ObjectId, err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("writing credentials: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,269 @@
+// Package expiry scans the applications and service principals in a tenant for credentials that have expired or are
+// about to expire, reporting each credential along with the object it belongs to and the contact emails of its owners.
+package expiry
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+// getByIdsLimit is the maximum number of IDs accepted in a single getByIds request.
+const getByIdsLimit = 1000
+
+// Options configures a Scanner.
+type Options struct {
+	// Threshold limits the report to credentials that expire within this duration, including those that have already
+	// expired. Federated identity credentials do not expire and are only reported when Threshold is zero, which reports
+	// all credentials.
+	Threshold time.Duration
+
+	// ExcludeExpired omits credentials that have already expired.
+	ExcludeExpired bool
+
+	// SkipApplications disables scanning of applications.
+	SkipApplications bool
+
+	// SkipServicePrincipals disables scanning of service principals.
+	SkipServicePrincipals bool
+
+	// ResolveOwners looks up the owners of each object with a reported credential, and populates the OwnerEmails for
+	// those credentials. This requires additional requests for every such object.
+	ResolveOwners bool
+
+	// now is overridden in tests
+	now func() time.Time
+}
+
+// Scanner finds expiring credentials using the clients from a ServiceClient.
+type Scanner struct {
+	applications      *msgraph.ApplicationsClient
+	servicePrincipals *msgraph.ServicePrincipalsClient
+	directoryObjects  *msgraph.DirectoryObjectsClient
+	options           Options
+}
+
+// New returns a Scanner which uses the clients from the specified ServiceClient.
+func New(svc *msgraph.ServiceClient, options Options) *Scanner {
+	if options.now == nil {
+		options.now = time.Now
+	}
+	return &Scanner{
+		applications:      svc.Applications(),
+		servicePrincipals: svc.ServicePrincipals(),
+		directoryObjects:  svc.DirectoryObjects(),
+		options:           options,
+	}
+}
+
+// Scan lists all applications and service principals, selecting only their credentials, and returns the credentials
+// that match the configured threshold. Credentials are ordered by expiry, soonest first, with credentials that do not
+// expire at the end.
+func (s *Scanner) Scan(ctx context.Context) ([]Credential, error) {
+	now := s.options.now().UTC()
+	credentials := make([]Credential, 0)
+
+	if !s.options.SkipApplications {
+		applications, _, err := s.applications.List(ctx, odata.Query{
+			Select: []string{"id", "appId", "displayName", "keyCredentials", "passwordCredentials"},
+			Expand: odata.Expand{Relationship: "federatedIdentityCredentials"},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("listing applications: %v", err)
+		}
+		for _, app := range *applications {
+			object := Credential{ObjectType: ObjectTypeApplication}
+			if app.ID() != nil {
+				object.ObjectId = *app.ID()
+			}
+			if app.AppId != nil {
+				object.AppId = *app.AppId
+			}
+			if app.DisplayName != nil {
+				object.DisplayName = *app.DisplayName
+			}
+			credentials = append(credentials, s.collect(object, now, app.PasswordCredentials, app.KeyCredentials, app.FederatedIdentityCredentials)...)
+		}
+	}
+
+	if !s.options.SkipServicePrincipals {
+		servicePrincipals, _, err := s.servicePrincipals.List(ctx, odata.Query{
+			Select: []string{"id", "appId", "displayName", "keyCredentials", "passwordCredentials"},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("listing service principals: %v", err)
+		}
+		for _, sp := range *servicePrincipals {
+			object := Credential{ObjectType: ObjectTypeServicePrincipal}
+			if sp.ID() != nil {
+				object.ObjectId = *sp.ID()
+			}
+			if sp.AppId != nil {
+				object.AppId = *sp.AppId
+			}
+			if sp.DisplayName != nil {
+				object.DisplayName = *sp.DisplayName
+			}
+			credentials = append(credentials, s.collect(object, now, sp.PasswordCredentials, sp.KeyCredentials, nil)...)
+		}
+	}
+
+	sort.SliceStable(credentials, func(i, j int) bool {
+		a, b := credentials[i].EndDateTime, credentials[j].EndDateTime
+		if a == nil || b == nil {
+			return a != nil
+		}
+		return a.Before(*b)
+	})
+
+	if s.options.ResolveOwners {
+		if err := s.resolveOwners(ctx, credentials); err != nil {
+			return nil, err
+		}
+	}
+
+	return credentials, nil
+}
+
+// collect builds a Credential for each of the credentials of an object which match the threshold.
+func (s *Scanner) collect(object Credential, now time.Time, passwords *[]msgraph.PasswordCredential, keys *[]msgraph.KeyCredential, federated *[]msgraph.FederatedIdentityCredential) []Credential {
+	var credentials []Credential
+
+	add := func(credential Credential) {
+		if credential.EndDateTime != nil {
+			remaining := credential.EndDateTime.Sub(now)
+			days := int(math.Floor(remaining.Hours() / 24))
+			credential.DaysRemaining = &days
+			credential.Expired = remaining <= 0
+			if credential.Expired && s.options.ExcludeExpired {
+				return
+			}
+			if s.options.Threshold > 0 && remaining > s.options.Threshold {
+				return
+			}
+		} else if s.options.Threshold > 0 {
+			return
+		}
+		credentials = append(credentials, credential)
+	}
+
+	if passwords != nil {
+		for _, password := range *passwords {
+			credential := object
+			credential.CredentialType = CredentialTypePassword
+			credential.KeyId = stringValue(password.KeyId)
+			credential.CredentialName = stringValue(password.DisplayName)
+			credential.StartDateTime = password.StartDateTime
+			credential.EndDateTime = password.EndDateTime
+			add(credential)
+		}
+	}
+
+	if keys != nil {
+		for _, key := range *keys {
+			credential := object
+			credential.CredentialType = CredentialTypeCertificate
+			credential.KeyId = stringValue(key.KeyId)
+			credential.CredentialName = stringValue(key.DisplayName)
+			credential.Usage = key.Usage
+			credential.StartDateTime = key.StartDateTime
+			credential.EndDateTime = key.EndDateTime
+			add(credential)
+		}
+	}
+
+	if federated != nil {
+		for _, fic := range *federated {
+			credential := object
+			credential.CredentialType = CredentialTypeFederatedIdentity
+			credential.KeyId = stringValue(fic.ID)
+			credential.CredentialName = stringValue(fic.Name)
+			add(credential)
+		}
+	}
+
+	return credentials
+}
+
+// resolveOwners populates the OwnerEmails for each credential, using the mail address of each owner that is a user,
+// or their user principal name when they have no mail address.
+func (s *Scanner) resolveOwners(ctx context.Context, credentials []Credential) error {
+	ownerIds := make(map[string][]string)
+	userIds := make([]string, 0)
+	seen := make(map[string]bool)
+
+	for _, credential := range credentials {
+		if _, ok := ownerIds[credential.ObjectId]; ok {
+			continue
+		}
+
+		var owners *[]string
+		var err error
+		switch credential.ObjectType {
+		case ObjectTypeApplication:
+			owners, _, err = s.applications.ListOwners(ctx, credential.ObjectId)
+		case ObjectTypeServicePrincipal:
+			owners, _, err = s.servicePrincipals.ListOwners(ctx, credential.ObjectId)
+		}
+		if err != nil {
+			return fmt.Errorf("listing owners for %s %q: %v", credential.ObjectType, credential.ObjectId, err)
+		}
+
+		ownerIds[credential.ObjectId] = make([]string, 0)
+		if owners != nil {
+			ownerIds[credential.ObjectId] = *owners
+			for _, id := range *owners {
+				if !seen[id] {
+					seen[id] = true
+					userIds = append(userIds, id)
+				}
+			}
+		}
+	}
+
+	emails := make(map[string]string)
+	for start := 0; start < len(userIds); start += getByIdsLimit {
+		end := start + getByIdsLimit
+		if end > len(userIds) {
+			end = len(userIds)
+		}
+		users, _, err := s.directoryObjects.GetByIds(ctx, userIds[start:end], []odata.ShortType{odata.ShortTypeUser})
+		if err != nil {
+			return fmt.Errorf("retrieving owners: %v", err)
+		}
+		for _, user := range *users {
+			if user.ID() == nil {
+				continue
+			}
+			for _, property := range []string{"mail", "userPrincipalName"} {
+				if v, ok := user.AdditionalData[property].(string); ok && v != "" {
+					emails[*user.ID()] = v
+					break
+				}
+			}
+		}
+	}
+
+	for i, credential := range credentials {
+		for _, id := range ownerIds[credential.ObjectId] {
+			if email, ok := emails[id]; ok {
+				credentials[i].OwnerEmails = append(credentials[i].OwnerEmails, email)
+			}
+		}
+		sort.Strings(credentials[i].OwnerEmails)
+	}
+
+	return nil
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
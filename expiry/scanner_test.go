@@ -0,0 +1,143 @@
+package expiry
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		respond := func(body string) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(body))
+		}
+
+		switch r.URL.Path {
+		case "/beta/applications":
+			if expand := r.URL.Query().Get("$expand"); expand != "federatedIdentityCredentials" {
+				t.Errorf("unexpected $expand for applications: %q", expand)
+			}
+			respond(`{"value":[{
+				"id": "app-1",
+				"appId": "client-1",
+				"displayName": "Payroll",
+				"passwordCredentials": [
+					{"keyId": "pwd-expired", "displayName": "old", "endDateTime": "2023-12-25T00:00:00Z"},
+					{"keyId": "pwd-soon", "displayName": "current", "endDateTime": "2024-01-11T12:00:00Z"}
+				],
+				"keyCredentials": [
+					{"keyId": "cert-later", "type": "AsymmetricX509Cert", "usage": "Verify", "endDateTime": "2025-01-01T00:00:00Z"}
+				],
+				"federatedIdentityCredentials": [
+					{"id": "fic-1", "name": "github-actions", "issuer": "https://token.actions.githubusercontent.com"}
+				]
+			}]}`)
+
+		case "/beta/servicePrincipals":
+			respond(`{"value":[{
+				"id": "sp-1",
+				"appId": "client-2",
+				"displayName": "SAML App",
+				"keyCredentials": [
+					{"keyId": "saml-sign", "type": "AsymmetricX509Cert", "usage": "Sign", "endDateTime": "2024-01-20T00:00:00Z"}
+				]
+			}]}`)
+
+		case "/beta/applications/app-1/owners":
+			respond(`{"value":[{"id":"user-1"},{"id":"sp-owner"}]}`)
+
+		case "/beta/servicePrincipals/sp-1/owners":
+			respond(`{"value":[{"id":"user-1"},{"id":"user-2"}]}`)
+
+		case "/v1.0/directoryObjects/getByIds":
+			respond(`{"value":[
+				{"@odata.type":"#microsoft.graph.user","id":"user-1","mail":"alice@example.com"},
+				{"@odata.type":"#microsoft.graph.user","id":"user-2","userPrincipalName":"bob@example.com"}
+			]}`)
+
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestScanner_Scan(t *testing.T) {
+	ts := newTestServer(t)
+	defer ts.Close()
+	svc := msgraph.NewServiceClient(msgraph.WithEndpoint(ts.URL), msgraph.WithRetryMax(0))
+	now := func() time.Time { return time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+	// all credentials, in order of expiry
+	credentials, err := New(svc, Options{now: now}).Scan(context.Background())
+	if err != nil {
+		t.Fatalf("Scan(): %v", err)
+	}
+	var keyIds []string
+	for _, c := range credentials {
+		keyIds = append(keyIds, c.KeyId)
+	}
+	if expected := "pwd-expired,pwd-soon,saml-sign,cert-later,fic-1"; strings.Join(keyIds, ",") != expected {
+		t.Fatalf("expected credentials %s, got %s", expected, strings.Join(keyIds, ","))
+	}
+	if c := credentials[0]; !c.Expired || c.DaysRemaining == nil || *c.DaysRemaining != -7 {
+		t.Errorf("unexpected expired credential: %+v", c)
+	}
+	if c := credentials[1]; c.Expired || *c.DaysRemaining != 10 || c.CredentialType != CredentialTypePassword || c.DisplayName != "Payroll" {
+		t.Errorf("unexpected password credential: %+v", c)
+	}
+	if c := credentials[4]; c.CredentialType != CredentialTypeFederatedIdentity || c.EndDateTime != nil || c.DaysRemaining != nil {
+		t.Errorf("unexpected federated credential: %+v", c)
+	}
+
+	// within 30 days, excluding expired credentials, with owners
+	credentials, err = New(svc, Options{Threshold: 30 * 24 * time.Hour, ExcludeExpired: true, ResolveOwners: true, now: now}).Scan(context.Background())
+	if err != nil {
+		t.Fatalf("Scan(): %v", err)
+	}
+	if len(credentials) != 2 || credentials[0].KeyId != "pwd-soon" || credentials[1].KeyId != "saml-sign" {
+		t.Fatalf("unexpected credentials: %+v", credentials)
+	}
+	if owners := strings.Join(credentials[0].OwnerEmails, ","); owners != "alice@example.com" {
+		t.Errorf("unexpected owners for application: %s", owners)
+	}
+	if owners := strings.Join(credentials[1].OwnerEmails, ","); owners != "alice@example.com,bob@example.com" {
+		t.Errorf("unexpected owners for service principal: %s", owners)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, credentials); err != nil {
+		t.Fatalf("WriteCSV(): %v", err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("reading CSV: %v", err)
+	}
+	if len(records) != 3 || len(records[1]) != len(csvHeader) {
+		t.Fatalf("unexpected CSV: %v", records)
+	}
+	if row := records[2]; row[0] != ObjectTypeServicePrincipal || row[9] != "2024-01-20T00:00:00Z" || row[10] != "19" || row[12] != "alice@example.com;bob@example.com" {
+		t.Errorf("unexpected CSV row: %v", row)
+	}
+
+	buf.Reset()
+	if err := WriteJSON(&buf, credentials); err != nil {
+		t.Fatalf("WriteJSON(): %v", err)
+	}
+	var decoded []Credential
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("json.Unmarshal(): %v", err)
+	}
+	if len(decoded) != 2 || *decoded[0].DaysRemaining != 10 {
+		t.Errorf("unexpected JSON output: %s", buf.String())
+	}
+}
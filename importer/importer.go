@@ -0,0 +1,297 @@
+// Package importer provisions users in bulk from CSV or JSON Lines input, validating each row before it is created and
+// reporting the outcome for each row so that an import can be resumed.
+package importer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+// Options configures an Importer.
+type Options struct {
+	// Concurrency is the maximum number of rows processed at once. Defaults to 4.
+	Concurrency int
+
+	// RequestsPerSecond limits the rate at which users are created. When zero, the rate is not limited, although
+	// throttled requests are still retried by the client.
+	RequestsPerSecond float64
+
+	// RequiredFields lists the fields that must be present in every row. Defaults to DefaultRequiredFields.
+	RequiredFields []Field
+
+	// PasswordPolicy is used to validate passwords before users are created.
+	PasswordPolicy PasswordPolicy
+
+	// SkipDomainValidation disables checking that user principal names use a verified domain of the tenant.
+	SkipDomainValidation bool
+
+	// Resume contains the results of a previous run. Rows that succeeded are skipped, and users that were created
+	// without all of their assignments are not created again, but their remaining assignments are retried.
+	Resume []Result
+
+	// Progress, when set, is called as each row is completed.
+	Progress func(Result)
+}
+
+// Importer creates users from rows read with ReadCSV or ReadJSONL.
+type Importer struct {
+	users   *msgraph.UsersClient
+	groups  *msgraph.GroupsClient
+	domains *msgraph.DomainsClient
+	options Options
+}
+
+// New returns an Importer which uses the clients from the specified ServiceClient.
+func New(svc *msgraph.ServiceClient, options Options) *Importer {
+	if options.Concurrency <= 0 {
+		options.Concurrency = 4
+	}
+	if options.RequiredFields == nil {
+		options.RequiredFields = DefaultRequiredFields
+	}
+	return &Importer{
+		users:   svc.Users(),
+		groups:  svc.Groups(),
+		domains: svc.Domains(),
+		options: options,
+	}
+}
+
+// Validate checks each row without creating any users, returning a result for every row with a status of either
+// ResultStatusValid or ResultStatusInvalid.
+func (i *Importer) Validate(ctx context.Context, rows []Row) ([]Result, error) {
+	var domains map[string]bool
+	if !i.options.SkipDomainValidation {
+		verified, _, err := i.domains.List(ctx, odata.Query{})
+		if err != nil {
+			return nil, fmt.Errorf("listing domains: %v", err)
+		}
+		domains = make(map[string]bool)
+		for _, d := range *verified {
+			if d.ID != nil && d.IsVerified != nil && *d.IsVerified {
+				domains[strings.ToLower(*d.ID)] = true
+			}
+		}
+	}
+
+	results := make([]Result, len(rows))
+	seen := make(map[string]int)
+	for n, row := range rows {
+		result := Result{
+			Line:              row.Line,
+			UserPrincipalName: row.UserPrincipalName(),
+			Status:            ResultStatusValid,
+			Errors:            validateRow(row, i.options.RequiredFields, domains, i.options.PasswordPolicy),
+		}
+
+		if upn := strings.ToLower(result.UserPrincipalName); upn != "" {
+			if line, ok := seen[upn]; ok {
+				result.Errors = append(result.Errors, fmt.Sprintf("duplicate user principal name, first seen on line %d", line))
+			} else {
+				seen[upn] = row.Line
+			}
+		}
+
+		if len(result.Errors) > 0 {
+			result.Status = ResultStatusInvalid
+		} else {
+			result.Errors = nil
+		}
+		results[n] = result
+	}
+
+	return results, nil
+}
+
+// Run validates and imports the rows. Users are created first, after which managers and group memberships are
+// assigned, so that a manager can be a user created in the same import. A result is returned for every row, and an
+// error is only returned when the import could not be started.
+func (i *Importer) Run(ctx context.Context, rows []Row) ([]Result, error) {
+	results, err := i.Validate(ctx, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := make(map[string]Result)
+	for _, r := range i.options.Resume {
+		if r.UserId != "" {
+			previous[strings.ToLower(r.UserPrincipalName)] = r
+		}
+	}
+
+	var limiter <-chan time.Time
+	if i.options.RequestsPerSecond > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / i.options.RequestsPerSecond))
+		defer ticker.Stop()
+		limiter = ticker.C
+	}
+
+	// create users
+	i.forEach(ctx, results, func(ctx context.Context, n int, result *Result) bool {
+		if result.Status == ResultStatusInvalid {
+			return true
+		}
+
+		if prev, ok := previous[strings.ToLower(result.UserPrincipalName)]; ok {
+			result.UserId = prev.UserId
+			switch prev.Status {
+			case ResultStatusSucceeded, ResultStatusSkipped:
+				result.Status = ResultStatusSkipped
+				result.ManagerAssigned = prev.ManagerAssigned
+				result.GroupsAdded = prev.GroupsAdded
+				return true
+			case ResultStatusPartial:
+				result.ManagerAssigned = prev.ManagerAssigned
+				result.GroupsAdded = prev.GroupsAdded
+				return false
+			}
+		}
+
+		if limiter != nil {
+			select {
+			case <-limiter:
+			case <-ctx.Done():
+				result.Status = ResultStatusFailed
+				result.Errors = append(result.Errors, ctx.Err().Error())
+				return true
+			}
+		}
+
+		user, err := rows[n].User()
+		if err == nil {
+			user, _, err = i.users.Create(ctx, *user)
+		}
+		if err != nil {
+			result.Status = ResultStatusFailed
+			result.Errors = append(result.Errors, err.Error())
+			return true
+		}
+		if user.ID() != nil {
+			result.UserId = *user.ID()
+		}
+		return false
+	})
+
+	created := make(map[string]string)
+	for _, result := range results {
+		if result.UserId != "" {
+			created[strings.ToLower(result.UserPrincipalName)] = result.UserId
+		}
+	}
+
+	// assign managers and group memberships
+	i.forEach(ctx, results, func(ctx context.Context, n int, result *Result) bool {
+		if result.UserId == "" || result.Status == ResultStatusSkipped || result.Status == ResultStatusFailed || result.Status == ResultStatusInvalid {
+			return false
+		}
+
+		result.Status = ResultStatusSucceeded
+		row := rows[n]
+
+		if manager := strings.TrimSpace(row.Values[FieldManager]); manager != "" && !result.ManagerAssigned {
+			if err := i.assignManager(ctx, result.UserId, manager, created); err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("assigning manager %q: %v", manager, err))
+			} else {
+				result.ManagerAssigned = true
+			}
+		}
+
+		for _, groupId := range row.Groups {
+			if containsFold(result.GroupsAdded, groupId) {
+				continue
+			}
+			if err := i.addToGroup(ctx, result.UserId, groupId); err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("adding to group %q: %v", groupId, err))
+			} else {
+				result.GroupsAdded = append(result.GroupsAdded, groupId)
+			}
+		}
+
+		if len(result.Errors) > 0 {
+			result.Status = ResultStatusPartial
+		}
+		return true
+	})
+
+	return results, nil
+}
+
+// forEach calls f for each result with bounded concurrency. When f returns true the result is complete, and is reported
+// to the Progress function.
+func (i *Importer) forEach(ctx context.Context, results []Result, f func(ctx context.Context, n int, result *Result) bool) {
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	semaphore := make(chan struct{}, i.options.Concurrency)
+
+	for n := range results {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			result := results[n]
+			done := f(ctx, n, &result)
+			results[n] = result
+
+			if done && i.options.Progress != nil {
+				mutex.Lock()
+				i.options.Progress(result)
+				mutex.Unlock()
+			}
+		}(n)
+	}
+
+	wg.Wait()
+}
+
+// assignManager assigns a manager, which may be specified by user principal name or object ID. Managers created in
+// the same import are resolved without a request.
+func (i *Importer) assignManager(ctx context.Context, userId, manager string, created map[string]string) error {
+	managerId, ok := created[strings.ToLower(manager)]
+	if !ok {
+		user, _, err := i.users.Get(ctx, manager, odata.Query{Select: []string{"id"}})
+		if err != nil {
+			return err
+		}
+		if user.ID() == nil {
+			return fmt.Errorf("manager was returned with a nil ID")
+		}
+		managerId = *user.ID()
+	}
+
+	m := msgraph.DirectoryObject{Id: &managerId}
+	odataId := odata.Id(m.Uri(i.users.BaseClient.Endpoint, i.users.BaseClient.ApiVersion))
+	m.ODataId = &odataId
+
+	_, err := i.users.AssignManager(ctx, userId, msgraph.User{DirectoryObject: m})
+	return err
+}
+
+func (i *Importer) addToGroup(ctx context.Context, userId, groupId string) error {
+	member := msgraph.DirectoryObject{Id: &userId}
+	odataId := odata.Id(member.Uri(i.groups.BaseClient.Endpoint, i.groups.BaseClient.ApiVersion))
+	member.ODataId = &odataId
+
+	group := msgraph.Group{
+		DirectoryObject: msgraph.DirectoryObject{Id: &groupId},
+		Members:         &msgraph.Members{member},
+	}
+	_, _, err := i.groups.AddMembers(ctx, &group)
+	return err
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,250 @@
+package importer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+const testCSV = `upn,name,pass,boss,teams,enabled
+alice@example.com,Alice,Sup3r-secret!,,group-1,true
+bob@example.com,Bob,Sup3r-secret!,alice@example.com,group-1;group-2,
+carol@unverified.com,Carol,Sup3r-secret!,,,
+dave@example.com,Dave,short,,,
+alice@example.com,Alice Again,Sup3r-secret!,,,
+erin@example.com,Erin,Sup3r-secret!,,group-forbidden,
+`
+
+var testMapping = Mapping{
+	"upn":     FieldUserPrincipalName,
+	"name":    FieldDisplayName,
+	"pass":    FieldPassword,
+	"boss":    FieldManager,
+	"teams":   FieldGroups,
+	"enabled": FieldAccountEnabled,
+}
+
+type testTenant struct {
+	sync.Mutex
+	users    map[string]string
+	managers map[string]string
+	members  map[string][]string
+	creates  int
+}
+
+func newTestServer(t *testing.T, tenant *testTenant) *httptest.Server {
+	userPath := regexp.MustCompile(`^/[^/]+/users/([^/]+)$`)
+	managerPath := regexp.MustCompile(`^/[^/]+/users/([^/]+)/manager/\$ref$`)
+	groupPath := regexp.MustCompile(`^/[^/]+/groups/([^/]+)$`)
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenant.Lock()
+		defer tenant.Unlock()
+
+		body, _ := io.ReadAll(r.Body)
+		respond := func(status int, v interface{}) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			json.NewEncoder(w).Encode(v)
+		}
+
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/domains"):
+			respond(http.StatusOK, map[string]interface{}{"value": []map[string]interface{}{
+				{"id": "example.com", "isVerified": true},
+				{"id": "unverified.com", "isVerified": false},
+			}})
+
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/users"):
+			var user msgraph.User
+			if err := json.Unmarshal(body, &user); err != nil {
+				t.Errorf("json.Unmarshal(): %v", err)
+			}
+			tenant.creates++
+			id := fmt.Sprintf("id-%s", strings.Split(*user.UserPrincipalName, "@")[0])
+			tenant.users[*user.UserPrincipalName] = id
+			respond(http.StatusCreated, map[string]interface{}{"id": id, "userPrincipalName": *user.UserPrincipalName})
+
+		case r.Method == http.MethodGet && userPath.MatchString(r.URL.Path):
+			upn := userPath.FindStringSubmatch(r.URL.Path)[1]
+			id, ok := tenant.users[upn]
+			if !ok {
+				respond(http.StatusNotFound, map[string]interface{}{"error": map[string]string{"code": "Request_ResourceNotFound", "message": "not found"}})
+				return
+			}
+			respond(http.StatusOK, map[string]interface{}{"id": id})
+
+		case r.Method == http.MethodPut && managerPath.MatchString(r.URL.Path):
+			var ref struct {
+				ODataId string `json:"@odata.id"`
+			}
+			json.Unmarshal(body, &ref)
+			tenant.managers[managerPath.FindStringSubmatch(r.URL.Path)[1]] = ref.ODataId[strings.LastIndex(ref.ODataId, "/")+1:]
+			w.WriteHeader(http.StatusNoContent)
+
+		case r.Method == http.MethodPatch && groupPath.MatchString(r.URL.Path):
+			groupId := groupPath.FindStringSubmatch(r.URL.Path)[1]
+			if groupId == "group-forbidden" {
+				respond(http.StatusForbidden, map[string]interface{}{"error": map[string]string{"code": "Authorization_RequestDenied", "message": "Insufficient privileges to complete the operation."}})
+				return
+			}
+			var req struct {
+				Members []string `json:"members@odata.bind"`
+			}
+			json.Unmarshal(body, &req)
+			for _, m := range req.Members {
+				tenant.members[groupId] = append(tenant.members[groupId], m[strings.LastIndex(m, "/")+1:])
+			}
+			w.WriteHeader(http.StatusNoContent)
+
+		case r.Method == http.MethodPost && strings.Contains(r.URL.Path, "/groups/group-forbidden/members/$ref"):
+			respond(http.StatusForbidden, map[string]interface{}{"error": map[string]string{"code": "Authorization_RequestDenied", "message": "Insufficient privileges to complete the operation."}})
+
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestReadCSV(t *testing.T) {
+	rows, err := ReadCSV(strings.NewReader(testCSV), testMapping)
+	if err != nil {
+		t.Fatalf("ReadCSV(): %v", err)
+	}
+	if len(rows) != 6 {
+		t.Fatalf("got %d rows, want 6", len(rows))
+	}
+	if rows[1].Line != 3 || rows[1].Values[FieldManager] != "alice@example.com" || len(rows[1].Groups) != 2 {
+		t.Fatalf("unexpected row: %+v", rows[1])
+	}
+
+	user, err := rows[0].User()
+	if err != nil {
+		t.Fatalf("Row.User(): %v", err)
+	}
+	if *user.MailNickname != "alice" || !*user.AccountEnabled || *user.PasswordProfile.Password != "Sup3r-secret!" {
+		t.Fatalf("unexpected user: %+v", user)
+	}
+
+	if _, err := ReadCSV(strings.NewReader(testCSV), Mapping{"upn": "nonsense"}); err == nil {
+		t.Fatal("expected an error for an unknown field")
+	}
+}
+
+func TestReadJSONL(t *testing.T) {
+	input := `{"userPrincipalName":"alice@example.com","displayName":"Alice","accountEnabled":false,"groups":["group-1","group-2"]}
+
+{"UserPrincipalName":"bob@example.com","displayName":"Bob","groups":"group-1"}
+`
+	rows, err := ReadJSONL(strings.NewReader(input), nil)
+	if err != nil {
+		t.Fatalf("ReadJSONL(): %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(rows))
+	}
+	if rows[0].Values[FieldAccountEnabled] != "false" || len(rows[0].Groups) != 2 {
+		t.Fatalf("unexpected row: %+v", rows[0])
+	}
+	if rows[1].Line != 3 || rows[1].UserPrincipalName() != "bob@example.com" || len(rows[1].Groups) != 1 {
+		t.Fatalf("unexpected row: %+v", rows[1])
+	}
+}
+
+func TestReadJSONL_Numbers(t *testing.T) {
+	input := `{"userPrincipalName":"carol@example.com","employeeId":1234567,"postalCode":98052,"groups":[1234567890123]}`
+	rows, err := ReadJSONL(strings.NewReader(input), nil)
+	if err != nil {
+		t.Fatalf("ReadJSONL(): %v", err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("got %d rows, want 1", len(rows))
+	}
+	if got := rows[0].Values[FieldEmployeeId]; got != "1234567" {
+		t.Errorf("got employeeId %q, want %q", got, "1234567")
+	}
+	if got := rows[0].Values[FieldPostalCode]; got != "98052" {
+		t.Errorf("got postalCode %q, want %q", got, "98052")
+	}
+	if len(rows[0].Groups) != 1 || rows[0].Groups[0] != "1234567890123" {
+		t.Errorf("unexpected groups: %v", rows[0].Groups)
+	}
+}
+
+func TestImporter_Run(t *testing.T) {
+	tenant := &testTenant{
+		users:    map[string]string{},
+		managers: map[string]string{},
+		members:  map[string][]string{},
+	}
+	ts := newTestServer(t, tenant)
+	defer ts.Close()
+
+	rows, err := ReadCSV(strings.NewReader(testCSV), testMapping)
+	if err != nil {
+		t.Fatalf("ReadCSV(): %v", err)
+	}
+
+	svc := msgraph.NewServiceClient(msgraph.WithEndpoint(ts.URL), msgraph.WithRetryMax(0))
+	progress := 0
+	results, err := New(svc, Options{Progress: func(Result) { progress++ }}).Run(context.Background(), rows)
+	if err != nil {
+		t.Fatalf("Run(): %v", err)
+	}
+	if progress != len(rows) {
+		t.Errorf("got %d progress updates, want %d", progress, len(rows))
+	}
+
+	expected := []ResultStatus{
+		ResultStatusSucceeded,
+		ResultStatusSucceeded,
+		ResultStatusInvalid,
+		ResultStatusInvalid,
+		ResultStatusInvalid,
+		ResultStatusPartial,
+	}
+	for n, want := range expected {
+		if results[n].Status != want {
+			t.Errorf("line %d: got status %q, want %q (%v)", results[n].Line, results[n].Status, want, results[n].Errors)
+		}
+	}
+	if tenant.managers["id-bob"] != "id-alice" {
+		t.Errorf("expected alice to be assigned as bob's manager, got %q", tenant.managers["id-bob"])
+	}
+	if len(tenant.members["group-1"]) != 2 || len(tenant.members["group-2"]) != 1 {
+		t.Errorf("unexpected group members: %v", tenant.members)
+	}
+
+	// resuming creates no new users, and only retries the failed group membership
+	var buf bytes.Buffer
+	if err := WriteResults(&buf, results); err != nil {
+		t.Fatalf("WriteResults(): %v", err)
+	}
+	previous, err := ReadResults(&buf)
+	if err != nil {
+		t.Fatalf("ReadResults(): %v", err)
+	}
+
+	creates := tenant.creates
+	results, err = New(svc, Options{Resume: previous}).Run(context.Background(), rows)
+	if err != nil {
+		t.Fatalf("Run(): %v", err)
+	}
+	if tenant.creates != creates {
+		t.Errorf("expected no users to be created when resuming, got %d", tenant.creates-creates)
+	}
+	if results[0].Status != ResultStatusSkipped || results[5].Status != ResultStatusPartial || results[5].UserId != "id-erin" {
+		t.Errorf("unexpected results when resuming: %+v", results)
+	}
+}
@@ -0,0 +1,308 @@
+package importer
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/manicminer/hamilton/internal/utils"
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+// Field is a property of a user that can be populated from an input column.
+type Field = string
+
+const (
+	FieldAccountEnabled                Field = "accountEnabled"
+	FieldCity                          Field = "city"
+	FieldCompanyName                   Field = "companyName"
+	FieldCountry                       Field = "country"
+	FieldDepartment                    Field = "department"
+	FieldDisplayName                   Field = "displayName"
+	FieldEmployeeId                    Field = "employeeId"
+	FieldEmployeeType                  Field = "employeeType"
+	FieldForceChangePasswordNextSignIn Field = "forceChangePasswordNextSignIn"
+	FieldGivenName                     Field = "givenName"
+	FieldGroups                        Field = "groups"
+	FieldJobTitle                      Field = "jobTitle"
+	FieldMailNickname                  Field = "mailNickname"
+	FieldManager                       Field = "manager"
+	FieldMobilePhone                   Field = "mobilePhone"
+	FieldOfficeLocation                Field = "officeLocation"
+	FieldPassword                      Field = "password"
+	FieldPostalCode                    Field = "postalCode"
+	FieldState                         Field = "state"
+	FieldStreetAddress                 Field = "streetAddress"
+	FieldSurname                       Field = "surname"
+	FieldUsageLocation                 Field = "usageLocation"
+	FieldUserPrincipalName             Field = "userPrincipalName"
+)
+
+var fields = []Field{
+	FieldAccountEnabled,
+	FieldCity,
+	FieldCompanyName,
+	FieldCountry,
+	FieldDepartment,
+	FieldDisplayName,
+	FieldEmployeeId,
+	FieldEmployeeType,
+	FieldForceChangePasswordNextSignIn,
+	FieldGivenName,
+	FieldGroups,
+	FieldJobTitle,
+	FieldMailNickname,
+	FieldManager,
+	FieldMobilePhone,
+	FieldOfficeLocation,
+	FieldPassword,
+	FieldPostalCode,
+	FieldState,
+	FieldStreetAddress,
+	FieldSurname,
+	FieldUsageLocation,
+	FieldUserPrincipalName,
+}
+
+// groupsSeparator separates multiple group IDs in a single CSV column.
+const groupsSeparator = ";"
+
+// Mapping maps input column names, i.e. CSV headers or JSON keys, onto user fields. Columns that are not mapped are
+// ignored. When a Mapping is nil, columns are mapped to the field with the same name, ignoring case.
+type Mapping map[string]Field
+
+func (m Mapping) field(column string) (Field, bool) {
+	if m == nil {
+		for _, f := range fields {
+			if strings.EqualFold(f, column) {
+				return f, true
+			}
+		}
+		return "", false
+	}
+	f, ok := m[column]
+	return f, ok
+}
+
+func (m Mapping) validate() error {
+	for column, f := range m {
+		known := false
+		for _, k := range fields {
+			if f == k {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("column %q is mapped to unknown field %q", column, f)
+		}
+	}
+	return nil
+}
+
+// Row is a single user read from the input. Line is the line number of the row in the input file, and Groups contains
+// the object IDs of the groups that the user should be added to.
+type Row struct {
+	Line   int
+	Values map[Field]string
+	Groups []string
+}
+
+// UserPrincipalName returns the user principal name for the row, which identifies it in the results.
+func (r Row) UserPrincipalName() string {
+	return strings.TrimSpace(r.Values[FieldUserPrincipalName])
+}
+
+// User returns the User to be created from the row. When the mail nickname is not specified, it is derived from the user
+// principal name, and accounts are enabled unless specified otherwise.
+func (r Row) User() (*msgraph.User, error) {
+	user := msgraph.User{
+		AccountEnabled: utils.BoolPtr(true),
+	}
+
+	str := func(f Field) *string {
+		if v := strings.TrimSpace(r.Values[f]); v != "" {
+			return &v
+		}
+		return nil
+	}
+	nullable := func(f Field) *msgraph.StringNullWhenEmpty {
+		if v := str(f); v != nil {
+			s := msgraph.StringNullWhenEmpty(*v)
+			return &s
+		}
+		return nil
+	}
+
+	if v := str(FieldAccountEnabled); v != nil {
+		enabled, err := strconv.ParseBool(*v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q for %s", *v, FieldAccountEnabled)
+		}
+		user.AccountEnabled = &enabled
+	}
+
+	user.City = nullable(FieldCity)
+	user.CompanyName = nullable(FieldCompanyName)
+	user.Country = nullable(FieldCountry)
+	user.Department = nullable(FieldDepartment)
+	user.DisplayName = str(FieldDisplayName)
+	user.EmployeeId = nullable(FieldEmployeeId)
+	user.EmployeeType = nullable(FieldEmployeeType)
+	user.GivenName = nullable(FieldGivenName)
+	user.JobTitle = nullable(FieldJobTitle)
+	user.MailNickname = str(FieldMailNickname)
+	user.MobilePhone = nullable(FieldMobilePhone)
+	user.OfficeLocation = nullable(FieldOfficeLocation)
+	user.PostalCode = nullable(FieldPostalCode)
+	user.State = nullable(FieldState)
+	user.StreetAddress = nullable(FieldStreetAddress)
+	user.Surname = nullable(FieldSurname)
+	user.UsageLocation = nullable(FieldUsageLocation)
+	user.UserPrincipalName = str(FieldUserPrincipalName)
+
+	if user.MailNickname == nil && user.UserPrincipalName != nil {
+		nickname, _, _ := strings.Cut(*user.UserPrincipalName, "@")
+		user.MailNickname = &nickname
+	}
+
+	if password := str(FieldPassword); password != nil {
+		user.PasswordProfile = &msgraph.UserPasswordProfile{
+			Password: password,
+		}
+		if v := str(FieldForceChangePasswordNextSignIn); v != nil {
+			force, err := strconv.ParseBool(*v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value %q for %s", *v, FieldForceChangePasswordNextSignIn)
+			}
+			user.PasswordProfile.ForceChangePasswordNextSignIn = &force
+		}
+	}
+
+	return &user, nil
+}
+
+// ReadCSV reads rows from CSV input. The first record must contain the column headers.
+func ReadCSV(r io.Reader, mapping Mapping) ([]Row, error) {
+	if err := mapping.validate(); err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	headers, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading CSV headers: %v", err)
+	}
+
+	rows := make([]Row, 0)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading CSV: %v", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := Row{Line: line, Values: map[Field]string{}}
+		for i, value := range record {
+			if i >= len(headers) {
+				break
+			}
+			f, ok := mapping.field(strings.TrimSpace(headers[i]))
+			if !ok {
+				continue
+			}
+			if f == FieldGroups {
+				row.Groups = splitGroups(strings.Split(value, groupsSeparator))
+				continue
+			}
+			row.Values[f] = value
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// ReadJSONL reads rows from JSON Lines input, where each line is a JSON object. Values may be strings, numbers or
+// booleans, and the column mapped to FieldGroups may also be an array of group IDs.
+func ReadJSONL(r io.Reader, mapping Mapping) ([]Row, error) {
+	if err := mapping.validate(); err != nil {
+		return nil, err
+	}
+
+	rows := make([]Row, 0)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		// numbers are decoded as json.Number, so that values such as employee IDs and postal codes are not reformatted
+		var object map[string]interface{}
+		decoder := json.NewDecoder(strings.NewReader(text))
+		decoder.UseNumber()
+		if err := decoder.Decode(&object); err != nil {
+			return nil, fmt.Errorf("parsing line %d: %v", line, err)
+		}
+
+		row := Row{Line: line, Values: map[Field]string{}}
+		for column, value := range object {
+			f, ok := mapping.field(column)
+			if !ok || value == nil {
+				continue
+			}
+			if f == FieldGroups {
+				switch v := value.(type) {
+				case []interface{}:
+					groups := make([]string, 0, len(v))
+					for _, g := range v {
+						groups = append(groups, fmt.Sprint(g))
+					}
+					row.Groups = splitGroups(groups)
+				default:
+					row.Groups = splitGroups(strings.Split(fmt.Sprint(v), groupsSeparator))
+				}
+				continue
+			}
+			switch v := value.(type) {
+			case string:
+				row.Values[f] = v
+			case json.Number:
+				row.Values[f] = v.String()
+			case bool:
+				row.Values[f] = strconv.FormatBool(v)
+			default:
+				return nil, fmt.Errorf("parsing line %d: unsupported value for column %q", line, column)
+			}
+		}
+		rows = append(rows, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading JSONL: %v", err)
+	}
+
+	return rows, nil
+}
+
+func splitGroups(values []string) []string {
+	groups := make([]string, 0, len(values))
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			groups = append(groups, v)
+		}
+	}
+	return groups
+}
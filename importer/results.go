@@ -0,0 +1,79 @@
+package importer
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ResultStatus describes the outcome of importing a row.
+type ResultStatus = string
+
+const (
+	// ResultStatusValid indicates that a row passed validation. It is only reported by Validate.
+	ResultStatusValid ResultStatus = "Valid"
+
+	// ResultStatusInvalid indicates that a row failed validation, and no user was created.
+	ResultStatusInvalid ResultStatus = "Invalid"
+
+	// ResultStatusFailed indicates that the user could not be created.
+	ResultStatusFailed ResultStatus = "Failed"
+
+	// ResultStatusPartial indicates that the user was created, but their manager or group memberships could not all be
+	// assigned. These are retried when the import is resumed.
+	ResultStatusPartial ResultStatus = "Partial"
+
+	// ResultStatusSucceeded indicates that the user was created, along with their manager and group memberships.
+	ResultStatusSucceeded ResultStatus = "Succeeded"
+
+	// ResultStatusSkipped indicates that the row had already succeeded in a previous run.
+	ResultStatusSkipped ResultStatus = "Skipped"
+)
+
+// Result describes the outcome of importing a single row. Results are written as JSON Lines so that a subsequent run
+// can be resumed from them.
+type Result struct {
+	Line              int          `json:"line"`
+	UserPrincipalName string       `json:"userPrincipalName"`
+	Status            ResultStatus `json:"status"`
+	UserId            string       `json:"userId,omitempty"`
+	ManagerAssigned   bool         `json:"managerAssigned,omitempty"`
+	GroupsAdded       []string     `json:"groupsAdded,omitempty"`
+	Errors            []string     `json:"errors,omitempty"`
+}
+
+// WriteResults writes results as JSON Lines.
+func WriteResults(w io.Writer, results []Result) error {
+	encoder := json.NewEncoder(w)
+	for _, result := range results {
+		if err := encoder.Encode(result); err != nil {
+			return fmt.Errorf("writing result for line %d: %v", result.Line, err)
+		}
+	}
+	return nil
+}
+
+// ReadResults reads results written by WriteResults, so that they can be passed to Options.Resume.
+func ReadResults(r io.Reader) ([]Result, error) {
+	results := make([]Result, 0)
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var result Result
+		if err := json.Unmarshal([]byte(text), &result); err != nil {
+			return nil, fmt.Errorf("parsing result on line %d: %v", line, err)
+		}
+		results = append(results, result)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading results: %v", err)
+	}
+	return results, nil
+}
@@ -0,0 +1,114 @@
+package importer
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// DefaultRequiredFields are the fields that must be present in every row, unless otherwise specified.
+var DefaultRequiredFields = []Field{FieldUserPrincipalName, FieldDisplayName, FieldPassword}
+
+// PasswordPolicy describes the passwords that are accepted for new users. The zero value applies the default Azure
+// Active Directory policy, which requires between 8 and 256 characters from at least three of the four character
+// categories: uppercase letters, lowercase letters, numbers and symbols.
+type PasswordPolicy struct {
+	MinLength          int
+	MaxLength          int
+	RequiredCategories int
+
+	// AllowUserName permits passwords that contain the user's mail nickname.
+	AllowUserName bool
+}
+
+func (p PasswordPolicy) validate(password, nickname string) error {
+	minLength, maxLength, categories := p.MinLength, p.MaxLength, p.RequiredCategories
+	if minLength == 0 {
+		minLength = 8
+	}
+	if maxLength == 0 {
+		maxLength = 256
+	}
+	if categories == 0 {
+		categories = 3
+	}
+
+	length := len([]rune(password))
+	if length < minLength || length > maxLength {
+		return fmt.Errorf("password must be between %d and %d characters", minLength, maxLength)
+	}
+
+	var upper, lower, number, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			number = true
+		default:
+			symbol = true
+		}
+	}
+	found := 0
+	for _, b := range []bool{upper, lower, number, symbol} {
+		if b {
+			found++
+		}
+	}
+	if found < categories {
+		return fmt.Errorf("password must contain at least %d of uppercase letters, lowercase letters, numbers and symbols", categories)
+	}
+
+	if !p.AllowUserName && nickname != "" && strings.Contains(strings.ToLower(password), strings.ToLower(nickname)) {
+		return fmt.Errorf("password must not contain the user name")
+	}
+
+	return nil
+}
+
+// validateRow returns the problems found with a row. domains contains the verified domains of the tenant, in lowercase;
+// when nil, the domain of the user principal name is not checked.
+func validateRow(row Row, required []Field, domains map[string]bool, policy PasswordPolicy) []string {
+	problems := make([]string, 0)
+
+	for _, f := range required {
+		if f == FieldGroups {
+			if len(row.Groups) == 0 {
+				problems = append(problems, fmt.Sprintf("missing required field %s", f))
+			}
+			continue
+		}
+		if strings.TrimSpace(row.Values[f]) == "" {
+			problems = append(problems, fmt.Sprintf("missing required field %s", f))
+		}
+	}
+
+	user, err := row.User()
+	if err != nil {
+		return append(problems, err.Error())
+	}
+
+	if upn := row.UserPrincipalName(); upn != "" {
+		_, domain, ok := strings.Cut(upn, "@")
+		switch {
+		case !ok || domain == "" || strings.Contains(domain, "@"):
+			problems = append(problems, fmt.Sprintf("invalid user principal name %q", upn))
+		case domains != nil && !domains[strings.ToLower(domain)]:
+			problems = append(problems, fmt.Sprintf("domain %q is not a verified domain of the tenant", domain))
+		}
+	}
+
+	if user.PasswordProfile != nil && user.PasswordProfile.Password != nil {
+		nickname := ""
+		if user.MailNickname != nil {
+			nickname = *user.MailNickname
+		}
+		if err := policy.validate(*user.PasswordProfile.Password, nickname); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	return problems
+}
@@ -99,7 +99,10 @@ type Test struct {
 	AccessPackageResourceRequestClient                      *msgraph.AccessPackageResourceRequestClient
 	AccessPackageResourceRoleClient                         *msgraph.AccessPackageResourceRoleClient
 	AccessPackageResourceRoleScopeClient                    *msgraph.AccessPackageResourceRoleScopeClient
+	ActivityBasedTimeoutPolicyClient                        *msgraph.ActivityBasedTimeoutPolicyClient
 	AdministrativeUnitsClient                               *msgraph.AdministrativeUnitsClient
+	ApplicationProxyConnectorGroupsClient                   *msgraph.ApplicationProxyConnectorGroupsClient
+	ApplicationProxyConnectorsClient                        *msgraph.ApplicationProxyConnectorsClient
 	ApplicationTemplatesClient                              *msgraph.ApplicationTemplatesClient
 	ApplicationsClient                                      *msgraph.ApplicationsClient
 	AppRoleAssignedToClient                                 *msgraph.AppRoleAssignedToClient
@@ -116,11 +119,14 @@ type Test struct {
 	DirectoryObjectsClient                                  *msgraph.DirectoryObjectsClient
 	DirectoryRoleTemplatesClient                            *msgraph.DirectoryRoleTemplatesClient
 	DirectoryRolesClient                                    *msgraph.DirectoryRolesClient
+	DirectorySettingsClient                                 *msgraph.DirectorySettingsClient
 	DomainsClient                                           *msgraph.DomainsClient
 	EntitlementRoleAssignmentsClient                        *msgraph.EntitlementRoleAssignmentsClient
 	EntitlementRoleDefinitionsClient                        *msgraph.EntitlementRoleDefinitionsClient
+	GroupLifecyclePoliciesClient                            *msgraph.GroupLifecyclePoliciesClient
 	GroupsAppRoleAssignmentsClient                          *msgraph.AppRoleAssignmentsClient
 	GroupsClient                                            *msgraph.GroupsClient
+	HomeRealmDiscoveryPolicyClient                          *msgraph.HomeRealmDiscoveryPolicyClient
 	IdentityProvidersClient                                 *msgraph.IdentityProvidersClient
 	InvitationsClient                                       *msgraph.InvitationsClient
 	MeClient                                                *msgraph.MeClient
@@ -142,9 +148,11 @@ type Test struct {
 	ServicePrincipalsAppRoleAssignmentsClient               *msgraph.AppRoleAssignmentsClient
 	ServicePrincipalsClient                                 *msgraph.ServicePrincipalsClient
 	SignInReportsClient                                     *msgraph.SignInReportsClient
+	SubscribedSkusClient                                    *msgraph.SubscribedSkusClient
 	SynchronizationJobClient                                *msgraph.SynchronizationJobClient
 	TermsOfUseAgreementClient                               *msgraph.TermsOfUseAgreementClient
 	TokenIssuancePolicyClient                               *msgraph.TokenIssuancePolicyClient
+	TokenLifetimePolicyClient                               *msgraph.TokenLifetimePolicyClient
 	UserFlowAttributesClient                                *msgraph.UserFlowAttributesClient
 	UsersAppRoleAssignmentsClient                           *msgraph.AppRoleAssignmentsClient
 	UsersClient                                             *msgraph.UsersClient
@@ -196,302 +204,89 @@ func NewTest(t *testing.T) (c *Test) {
 		t.Fatalf("invalid retry count %q: %v", retryMax, err)
 	}
 
-	endpoint, ok := c.Connections["default"].AuthConfig.Environment.MicrosoftGraph.Endpoint()
-	if !ok {
-		t.Fatalf("could not configure MS Graph endpoint for environment %q", c.Connections["default"].AuthConfig.Environment.Name)
+	env := c.Connections["default"].AuthConfig.Environment
+	if _, ok := env.MicrosoftGraph.Endpoint(); !ok {
+		t.Fatalf("could not configure MS Graph endpoint for environment %q", env.Name)
 	}
 
-	c.AccessPackageAssignmentPolicyClient = msgraph.NewAccessPackageAssignmentPolicyClient()
-	c.AccessPackageAssignmentPolicyClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.AccessPackageAssignmentPolicyClient.BaseClient.Endpoint = *endpoint
-	c.AccessPackageAssignmentPolicyClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.AccessPackageAssignmentRequestClient = msgraph.NewAccessPackageAssignmentRequestClient()
-	c.AccessPackageAssignmentRequestClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.AccessPackageAssignmentRequestClient.BaseClient.Endpoint = *endpoint
-	c.AccessPackageAssignmentRequestClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.AccessPackageCatalogClient = msgraph.NewAccessPackageCatalogClient()
-	c.AccessPackageCatalogClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.AccessPackageCatalogClient.BaseClient.Endpoint = *endpoint
-	c.AccessPackageCatalogClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.AccessPackageClient = msgraph.NewAccessPackageClient()
-	c.AccessPackageClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.AccessPackageClient.BaseClient.Endpoint = *endpoint
-	c.AccessPackageClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.AccessPackageResourceClient = msgraph.NewAccessPackageResourceClient()
-	c.AccessPackageResourceClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.AccessPackageResourceClient.BaseClient.Endpoint = *endpoint
-	c.AccessPackageResourceClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.AccessPackageResourceRequestClient = msgraph.NewAccessPackageResourceRequestClient()
-	c.AccessPackageResourceRequestClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.AccessPackageResourceRequestClient.BaseClient.Endpoint = *endpoint
-	c.AccessPackageAssignmentPolicyClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.AccessPackageResourceRoleClient = msgraph.NewAccessPackageResourceRoleClient()
-	c.AccessPackageResourceRoleClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.AccessPackageResourceRoleClient.BaseClient.Endpoint = *endpoint
-	c.AccessPackageResourceRoleClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.AccessPackageResourceRoleScopeClient = msgraph.NewAccessPackageResourceRoleScopeClient()
-	c.AccessPackageResourceRoleScopeClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.AccessPackageResourceRoleScopeClient.BaseClient.Endpoint = *endpoint
-	c.AccessPackageResourceRoleScopeClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.AdministrativeUnitsClient = msgraph.NewAdministrativeUnitsClient()
-	c.AdministrativeUnitsClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.AdministrativeUnitsClient.BaseClient.Endpoint = *endpoint
-	c.AdministrativeUnitsClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.ApplicationTemplatesClient = msgraph.NewApplicationTemplatesClient()
-	c.ApplicationTemplatesClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.ApplicationTemplatesClient.BaseClient.Endpoint = *endpoint
-	c.ApplicationTemplatesClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.ApplicationsClient = msgraph.NewApplicationsClient()
-	c.ApplicationsClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.ApplicationsClient.BaseClient.Endpoint = *endpoint
-	c.ApplicationsClient.BaseClient.RetryableClient.RetryMax = retry
-	c.ApplicationsClient.BaseClient.ApiVersion = msgraph.Version10
-
-	c.AppRoleAssignedToClient = msgraph.NewAppRoleAssignedToClient()
-	c.AppRoleAssignedToClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.AppRoleAssignedToClient.BaseClient.Endpoint = *endpoint
-	c.AppRoleAssignedToClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.AttributeSetClient = msgraph.NewAttributeSetClient()
-	c.AttributeSetClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.AttributeSetClient.BaseClient.Endpoint = *endpoint
-	c.AttributeSetClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.AuthenticationMethodsClient = msgraph.NewAuthenticationMethodsClient()
-	c.AuthenticationMethodsClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.AuthenticationMethodsClient.BaseClient.Endpoint = *endpoint
-	c.AuthenticationMethodsClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.AuthenticationStrengthPoliciesClient = msgraph.NewAuthenticationStrengthPoliciesClient()
-	c.AuthenticationStrengthPoliciesClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.AuthenticationStrengthPoliciesClient.BaseClient.Endpoint = *endpoint
-	c.AuthenticationStrengthPoliciesClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.B2CUserFlowClient = msgraph.NewB2CUserFlowClient()
-	c.B2CUserFlowClient.BaseClient.Authorizer = c.Connections["b2c"].Authorizer
-	c.B2CUserFlowClient.BaseClient.Endpoint = *endpoint
-	c.B2CUserFlowClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.ClaimsMappingPolicyClient = msgraph.NewClaimsMappingPolicyClient()
-	c.ClaimsMappingPolicyClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.ClaimsMappingPolicyClient.BaseClient.Endpoint = *endpoint
-	c.ClaimsMappingPolicyClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.ConditionalAccessPoliciesClient = msgraph.NewConditionalAccessPoliciesClient()
-	c.ConditionalAccessPoliciesClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.ConditionalAccessPoliciesClient.BaseClient.Endpoint = *endpoint
-	c.ConditionalAccessPoliciesClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.ConnectedOrganizationClient = msgraph.NewConnectedOrganizationClient()
-	c.ConnectedOrganizationClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.ConnectedOrganizationClient.BaseClient.Endpoint = *endpoint
-	c.ConnectedOrganizationClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.CustomSecurityAttributeDefinitionClient = msgraph.NewCustomSecurityAttributeDefinitionClient()
-	c.CustomSecurityAttributeDefinitionClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.CustomSecurityAttributeDefinitionClient.BaseClient.Endpoint = *endpoint
-	c.CustomSecurityAttributeDefinitionClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.DelegatedPermissionGrantsClient = msgraph.NewDelegatedPermissionGrantsClient()
-	c.DelegatedPermissionGrantsClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.DelegatedPermissionGrantsClient.BaseClient.Endpoint = *endpoint
-	c.DelegatedPermissionGrantsClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.DirectoryAuditReportsClient = msgraph.NewDirectoryAuditReportsClient()
-	c.DirectoryAuditReportsClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.DirectoryAuditReportsClient.BaseClient.Endpoint = *endpoint
-	c.DirectoryAuditReportsClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.DirectoryObjectsClient = msgraph.NewDirectoryObjectsClient()
-	c.DirectoryObjectsClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.DirectoryObjectsClient.BaseClient.Endpoint = *endpoint
-	c.DirectoryObjectsClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.DirectoryRoleTemplatesClient = msgraph.NewDirectoryRoleTemplatesClient()
-	c.DirectoryRoleTemplatesClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.DirectoryRoleTemplatesClient.BaseClient.Endpoint = *endpoint
-	c.DirectoryRoleTemplatesClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.DirectoryRolesClient = msgraph.NewDirectoryRolesClient()
-	c.DirectoryRolesClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.DirectoryRolesClient.BaseClient.Endpoint = *endpoint
-	c.DirectoryRolesClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.DomainsClient = msgraph.NewDomainsClient()
-	c.DomainsClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.DomainsClient.BaseClient.Endpoint = *endpoint
-	c.DomainsClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.EntitlementRoleAssignmentsClient = msgraph.NewEntitlementRoleAssignmentsClient()
-	c.EntitlementRoleAssignmentsClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.EntitlementRoleAssignmentsClient.BaseClient.Endpoint = *endpoint
-	c.EntitlementRoleAssignmentsClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.EntitlementRoleDefinitionsClient = msgraph.NewEntitlementRoleDefinitionsClient()
-	c.EntitlementRoleDefinitionsClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.EntitlementRoleDefinitionsClient.BaseClient.Endpoint = *endpoint
-	c.EntitlementRoleDefinitionsClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.GroupsAppRoleAssignmentsClient = msgraph.NewGroupsAppRoleAssignmentsClient()
-	c.GroupsAppRoleAssignmentsClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.GroupsAppRoleAssignmentsClient.BaseClient.Endpoint = *endpoint
-	c.GroupsAppRoleAssignmentsClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.GroupsClient = msgraph.NewGroupsClient()
-	c.GroupsClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.GroupsClient.BaseClient.Endpoint = *endpoint
-	c.GroupsClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.IdentityProvidersClient = msgraph.NewIdentityProvidersClient()
-	c.IdentityProvidersClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.IdentityProvidersClient.BaseClient.Endpoint = *endpoint
-	c.IdentityProvidersClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.InvitationsClient = msgraph.NewInvitationsClient()
-	c.InvitationsClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.InvitationsClient.BaseClient.Endpoint = *endpoint
-	c.InvitationsClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.MeClient = msgraph.NewMeClient()
-	c.MeClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.MeClient.BaseClient.Endpoint = *endpoint
-	c.MeClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.NamedLocationsClient = msgraph.NewNamedLocationsClient()
-	c.NamedLocationsClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.NamedLocationsClient.BaseClient.Endpoint = *endpoint
-	c.NamedLocationsClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.PrivilegedAccessGroupAssignmentScheduleClient = msgraph.NewPrivilegedAccessGroupAssignmentScheduleClient()
-	c.PrivilegedAccessGroupAssignmentScheduleClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.PrivilegedAccessGroupAssignmentScheduleClient.BaseClient.Endpoint = *endpoint
-	c.PrivilegedAccessGroupAssignmentScheduleClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.PrivilegedAccessGroupAssignmentScheduleInstancesClient = msgraph.NewPrivilegedAccessGroupAssignmentScheduleInstancesClient()
-	c.PrivilegedAccessGroupAssignmentScheduleInstancesClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.PrivilegedAccessGroupAssignmentScheduleInstancesClient.BaseClient.Endpoint = *endpoint
-	c.PrivilegedAccessGroupAssignmentScheduleInstancesClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.PrivilegedAccessGroupAssignmentScheduleRequestsClient = msgraph.NewPrivilegedAccessGroupAssignmentScheduleRequestsClient()
-	c.PrivilegedAccessGroupAssignmentScheduleRequestsClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.PrivilegedAccessGroupAssignmentScheduleRequestsClient.BaseClient.Endpoint = *endpoint
-	c.PrivilegedAccessGroupAssignmentScheduleRequestsClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.PrivilegedAccessGroupEligibilityScheduleClient = msgraph.NewPrivilegedAccessGroupEligibilityScheduleClient()
-	c.PrivilegedAccessGroupEligibilityScheduleClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.PrivilegedAccessGroupEligibilityScheduleClient.BaseClient.Endpoint = *endpoint
-	c.PrivilegedAccessGroupEligibilityScheduleClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.PrivilegedAccessGroupEligibilityScheduleInstancesClient = msgraph.NewPrivilegedAccessGroupEligibilityScheduleInstancesClient()
-	c.PrivilegedAccessGroupEligibilityScheduleInstancesClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.PrivilegedAccessGroupEligibilityScheduleInstancesClient.BaseClient.Endpoint = *endpoint
-	c.PrivilegedAccessGroupEligibilityScheduleInstancesClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.PrivilegedAccessGroupEligibilityScheduleRequestsClient = msgraph.NewPrivilegedAccessGroupEligibilityScheduleRequestsClient()
-	c.PrivilegedAccessGroupEligibilityScheduleRequestsClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.PrivilegedAccessGroupEligibilityScheduleRequestsClient.BaseClient.Endpoint = *endpoint
-	c.PrivilegedAccessGroupEligibilityScheduleRequestsClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.ReportsClient = msgraph.NewReportsClient()
-	c.ReportsClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.ReportsClient.BaseClient.Endpoint = *endpoint
-	c.ReportsClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.RoleAssignmentsClient = msgraph.NewRoleAssignmentsClient()
-	c.RoleAssignmentsClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.RoleAssignmentsClient.BaseClient.Endpoint = *endpoint
-	c.RoleAssignmentsClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.RoleDefinitionsClient = msgraph.NewRoleDefinitionsClient()
-	c.RoleDefinitionsClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.RoleDefinitionsClient.BaseClient.Endpoint = *endpoint
-	c.RoleDefinitionsClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.RoleEligibilityScheduleRequestClient = msgraph.NewRoleEligibilityScheduleRequestClient()
-	c.RoleEligibilityScheduleRequestClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.RoleEligibilityScheduleRequestClient.BaseClient.Endpoint = *endpoint
-	c.RoleEligibilityScheduleRequestClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.RoleManagementPolicyClient = msgraph.NewRoleManagementPolicyClient()
-	c.RoleManagementPolicyClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.RoleManagementPolicyClient.BaseClient.Endpoint = *endpoint
-	c.RoleManagementPolicyClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.RoleManagementPolicyAssignmentClient = msgraph.NewRoleManagementPolicyAssignmentClient()
-	c.RoleManagementPolicyAssignmentClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.RoleManagementPolicyAssignmentClient.BaseClient.Endpoint = *endpoint
-	c.RoleManagementPolicyAssignmentClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.RoleManagementPolicyRuleClient = msgraph.NewRoleManagementPolicyRuleClient()
-	c.RoleManagementPolicyRuleClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.RoleManagementPolicyRuleClient.BaseClient.Endpoint = *endpoint
-	c.RoleManagementPolicyRuleClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.SchemaExtensionsClient = msgraph.NewSchemaExtensionsClient()
-	c.SchemaExtensionsClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.SchemaExtensionsClient.BaseClient.Endpoint = *endpoint
-	c.SchemaExtensionsClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.ServicePrincipalsAppRoleAssignmentsClient = msgraph.NewServicePrincipalsAppRoleAssignmentsClient()
-	c.ServicePrincipalsAppRoleAssignmentsClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.ServicePrincipalsAppRoleAssignmentsClient.BaseClient.Endpoint = *endpoint
-	c.ServicePrincipalsAppRoleAssignmentsClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.ServicePrincipalsClient = msgraph.NewServicePrincipalsClient()
-	c.ServicePrincipalsClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.ServicePrincipalsClient.BaseClient.Endpoint = *endpoint
-	c.ServicePrincipalsClient.BaseClient.RetryableClient.RetryMax = retry
-	c.ServicePrincipalsClient.BaseClient.ApiVersion = msgraph.Version10
-
-	c.SignInReportsClient = msgraph.NewSignInReportsClient()
-	c.SignInReportsClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.SignInReportsClient.BaseClient.Endpoint = *endpoint
-	c.SignInReportsClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.SynchronizationJobClient = msgraph.NewSynchronizationJobClient()
-	c.SynchronizationJobClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.SynchronizationJobClient.BaseClient.Endpoint = *endpoint
-	c.SynchronizationJobClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.TermsOfUseAgreementClient = msgraph.NewTermsOfUseAgreementClient()
-	c.TermsOfUseAgreementClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.TermsOfUseAgreementClient.BaseClient.Endpoint = *endpoint
-	c.TermsOfUseAgreementClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.TokenIssuancePolicyClient = msgraph.NewTokenIssuancePolicyClient()
-	c.TokenIssuancePolicyClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.TokenIssuancePolicyClient.BaseClient.Endpoint = *endpoint
-	c.TokenIssuancePolicyClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.UserFlowAttributesClient = msgraph.NewUserFlowAttributesClient()
-	c.UserFlowAttributesClient.BaseClient.Authorizer = c.Connections["b2c"].Authorizer
-	c.UserFlowAttributesClient.BaseClient.Endpoint = *endpoint
-	c.UserFlowAttributesClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.UsersAppRoleAssignmentsClient = msgraph.NewUsersAppRoleAssignmentsClient()
-	c.UsersAppRoleAssignmentsClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.UsersAppRoleAssignmentsClient.BaseClient.Endpoint = *endpoint
-	c.UsersAppRoleAssignmentsClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.UsersClient = msgraph.NewUsersClient()
-	c.UsersClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.UsersClient.BaseClient.Endpoint = *endpoint
-	c.UsersClient.BaseClient.RetryableClient.RetryMax = retry
-
-	c.WindowsAutopilotDeploymentProfilesClient = msgraph.NewWindowsAutopilotDeploymentProfilesClient()
-	c.WindowsAutopilotDeploymentProfilesClient.BaseClient.Authorizer = c.Connections["default"].Authorizer
-	c.WindowsAutopilotDeploymentProfilesClient.BaseClient.Endpoint = *endpoint
-	c.WindowsAutopilotDeploymentProfilesClient.BaseClient.RetryableClient.RetryMax = retry
+	svc := msgraph.NewServiceClient(
+		msgraph.WithAuthorizer(c.Connections["default"].Authorizer),
+		msgraph.WithEnvironment(env),
+		msgraph.WithRetryMax(retry),
+	)
+
+	b2c := msgraph.NewServiceClient(
+		msgraph.WithAuthorizer(c.Connections["b2c"].Authorizer),
+		msgraph.WithEnvironment(env),
+		msgraph.WithRetryMax(retry),
+	)
+
+	c.AccessPackageAssignmentPolicyClient = svc.AccessPackageAssignmentPolicy()
+	c.AccessPackageAssignmentRequestClient = svc.AccessPackageAssignmentRequest()
+	c.AccessPackageCatalogClient = svc.AccessPackageCatalog()
+	c.AccessPackageClient = svc.AccessPackage()
+	c.AccessPackageResourceClient = svc.AccessPackageResource()
+	c.AccessPackageResourceRequestClient = svc.AccessPackageResourceRequest()
+	c.AccessPackageResourceRoleClient = svc.AccessPackageResourceRole()
+	c.AccessPackageResourceRoleScopeClient = svc.AccessPackageResourceRoleScope()
+	c.ActivityBasedTimeoutPolicyClient = svc.ActivityBasedTimeoutPolicy()
+	c.AdministrativeUnitsClient = svc.AdministrativeUnits()
+	c.ApplicationProxyConnectorGroupsClient = svc.ApplicationProxyConnectorGroups()
+	c.ApplicationProxyConnectorsClient = svc.ApplicationProxyConnectors()
+	c.ApplicationTemplatesClient = svc.ApplicationTemplates()
+	c.ApplicationsClient = svc.Applications(msgraph.WithApiVersion(msgraph.Version10))
+	c.AppRoleAssignedToClient = svc.AppRoleAssignedTo()
+	c.AttributeSetClient = svc.AttributeSet()
+	c.AuthenticationMethodsClient = svc.AuthenticationMethods()
+	c.AuthenticationStrengthPoliciesClient = svc.AuthenticationStrengthPolicies()
+	c.B2CUserFlowClient = b2c.B2CUserFlow()
+	c.ClaimsMappingPolicyClient = svc.ClaimsMappingPolicy()
+	c.ConditionalAccessPoliciesClient = svc.ConditionalAccessPolicies()
+	c.ConnectedOrganizationClient = svc.ConnectedOrganization()
+	c.CustomSecurityAttributeDefinitionClient = svc.CustomSecurityAttributeDefinition()
+	c.DelegatedPermissionGrantsClient = svc.DelegatedPermissionGrants()
+	c.DirectoryAuditReportsClient = svc.DirectoryAuditReports()
+	c.DirectoryObjectsClient = svc.DirectoryObjects()
+	c.DirectoryRoleTemplatesClient = svc.DirectoryRoleTemplates()
+	c.DirectoryRolesClient = svc.DirectoryRoles()
+	c.DirectorySettingsClient = svc.DirectorySettings()
+	c.DomainsClient = svc.Domains()
+	c.EntitlementRoleAssignmentsClient = svc.EntitlementRoleAssignments()
+	c.EntitlementRoleDefinitionsClient = svc.EntitlementRoleDefinitions()
+	c.GroupLifecyclePoliciesClient = svc.GroupLifecyclePolicies()
+	c.GroupsAppRoleAssignmentsClient = svc.GroupsAppRoleAssignments()
+	c.GroupsClient = svc.Groups()
+	c.HomeRealmDiscoveryPolicyClient = svc.HomeRealmDiscoveryPolicy()
+	c.IdentityProvidersClient = svc.IdentityProviders()
+	c.InvitationsClient = svc.Invitations()
+	c.MeClient = svc.Me()
+	c.NamedLocationsClient = svc.NamedLocations()
+	c.PrivilegedAccessGroupAssignmentScheduleClient = svc.PrivilegedAccessGroupAssignmentSchedule()
+	c.PrivilegedAccessGroupAssignmentScheduleInstancesClient = svc.PrivilegedAccessGroupAssignmentScheduleInstances()
+	c.PrivilegedAccessGroupAssignmentScheduleRequestsClient = svc.PrivilegedAccessGroupAssignmentScheduleRequests()
+	c.PrivilegedAccessGroupEligibilityScheduleClient = svc.PrivilegedAccessGroupEligibilitySchedule()
+	c.PrivilegedAccessGroupEligibilityScheduleInstancesClient = svc.PrivilegedAccessGroupEligibilityScheduleInstances()
+	c.PrivilegedAccessGroupEligibilityScheduleRequestsClient = svc.PrivilegedAccessGroupEligibilityScheduleRequests()
+	c.ReportsClient = svc.Reports()
+	c.RoleAssignmentsClient = svc.RoleAssignments()
+	c.RoleDefinitionsClient = svc.RoleDefinitions()
+	c.RoleEligibilityScheduleRequestClient = svc.RoleEligibilityScheduleRequest()
+	c.RoleManagementPolicyClient = svc.RoleManagementPolicy()
+	c.RoleManagementPolicyAssignmentClient = svc.RoleManagementPolicyAssignment()
+	c.RoleManagementPolicyRuleClient = svc.RoleManagementPolicyRule()
+	c.SchemaExtensionsClient = svc.SchemaExtensions()
+	c.ServicePrincipalsAppRoleAssignmentsClient = svc.ServicePrincipalsAppRoleAssignments()
+	c.ServicePrincipalsClient = svc.ServicePrincipals(msgraph.WithApiVersion(msgraph.Version10))
+	c.SignInReportsClient = svc.SignInReports()
+	c.SubscribedSkusClient = svc.SubscribedSkus()
+	c.SynchronizationJobClient = svc.SynchronizationJob()
+	c.TermsOfUseAgreementClient = svc.TermsOfUseAgreement()
+	c.TokenIssuancePolicyClient = svc.TokenIssuancePolicy()
+	c.UserFlowAttributesClient = b2c.UserFlowAttributes()
+	c.TokenLifetimePolicyClient = svc.TokenLifetimePolicy()
+	c.UsersAppRoleAssignmentsClient = svc.UsersAppRoleAssignments()
+	c.UsersClient = svc.Users()
+	c.WindowsAutopilotDeploymentProfilesClient = svc.WindowsAutopilotDeploymentProfiles()
 
 	return
 }
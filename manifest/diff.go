@@ -0,0 +1,188 @@
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// ChangeType describes how a value differs between two manifests.
+type ChangeType = string
+
+const (
+	ChangeTypeAdd    ChangeType = "Add"
+	ChangeTypeRemove ChangeType = "Remove"
+	ChangeTypeModify ChangeType = "Modify"
+)
+
+// Change is a single difference between two manifests. Path identifies the value using the JSON property names of the
+// manifest, e.g. "application.web.redirectUris". Elements of lists of objects are identified by their name, ID or
+// value where they have one, e.g. "federatedIdentityCredentials[name=deploy]", and otherwise lists are compared as a
+// whole.
+type Change struct {
+	Type ChangeType  `json:"type"`
+	Path string      `json:"path"`
+	Old  interface{} `json:"old,omitempty"`
+	New  interface{} `json:"new,omitempty"`
+}
+
+// String formats the change as a single line, prefixed with "+", "-" or "~" for additions, removals and modifications.
+func (c Change) String() string {
+	format := func(v interface{}) string {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(b)
+	}
+	switch c.Type {
+	case ChangeTypeAdd:
+		return fmt.Sprintf("+ %s: %s", c.Path, format(c.New))
+	case ChangeTypeRemove:
+		return fmt.Sprintf("- %s: %s", c.Path, format(c.Old))
+	default:
+		return fmt.Sprintf("~ %s: %s -> %s", c.Path, format(c.Old), format(c.New))
+	}
+}
+
+// listKeys are the properties used, in order of preference, to match up elements of lists of objects.
+var listKeys = []string{"name", "id", "value"}
+
+// Diff returns the changes needed to turn the manifest from into the manifest to. A nil from is treated as an empty
+// manifest, so that every value in to is reported as an addition.
+func Diff(from, to *Manifest) ([]Change, error) {
+	if from == nil {
+		from = &Manifest{Version: Version}
+	}
+	a, err := generic(from)
+	if err != nil {
+		return nil, err
+	}
+	b, err := generic(to)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]Change, 0)
+	diffValues("", a, b, &changes)
+	return changes, nil
+}
+
+// generic round-trips a manifest through JSON to obtain maps and slices that can be compared.
+func generic(m *Manifest) (interface{}, error) {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("json.Marshal(): %v", err)
+	}
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+	return v, nil
+}
+
+func diffValues(path string, a, b interface{}, changes *[]Change) {
+	if reflect.DeepEqual(a, b) {
+		return
+	}
+	switch {
+	case a == nil:
+		*changes = append(*changes, Change{Type: ChangeTypeAdd, Path: path, New: b})
+		return
+	case b == nil:
+		*changes = append(*changes, Change{Type: ChangeTypeRemove, Path: path, Old: a})
+		return
+	}
+
+	switch av := a.(type) {
+	case map[string]interface{}:
+		if bv, ok := b.(map[string]interface{}); ok {
+			diffObjects(path, av, bv, changes)
+			return
+		}
+	case []interface{}:
+		if bv, ok := b.([]interface{}); ok {
+			if key := listKey(av, bv); key != "" {
+				diffLists(path, key, av, bv, changes)
+				return
+			}
+		}
+	}
+
+	*changes = append(*changes, Change{Type: ChangeTypeModify, Path: path, Old: a, New: b})
+}
+
+func diffObjects(path string, a, b map[string]interface{}, changes *[]Change) {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		diffValues(joinPath(path, k), a[k], b[k], changes)
+	}
+}
+
+func diffLists(path, key string, a, b []interface{}, changes *[]Change) {
+	index := func(list []interface{}) (map[string]interface{}, []string) {
+		elements := make(map[string]interface{})
+		order := make([]string, 0, len(list))
+		for _, element := range list {
+			k := fmt.Sprintf("%v", element.(map[string]interface{})[key])
+			elements[k] = element
+			order = append(order, k)
+		}
+		return elements, order
+	}
+	aElements, aOrder := index(a)
+	bElements, bOrder := index(b)
+
+	for _, k := range aOrder {
+		diffValues(fmt.Sprintf("%s[%s=%s]", path, key, k), aElements[k], bElements[k], changes)
+	}
+	for _, k := range bOrder {
+		if _, ok := aElements[k]; !ok {
+			diffValues(fmt.Sprintf("%s[%s=%s]", path, key, k), nil, bElements[k], changes)
+		}
+	}
+}
+
+// listKey returns the property that uniquely identifies every element of both lists, or an empty string when the
+// lists do not both contain only objects with such a property.
+func listKey(a, b []interface{}) string {
+	for _, key := range listKeys {
+		if hasUniqueKey(a, key) && hasUniqueKey(b, key) {
+			return key
+		}
+	}
+	return ""
+}
+
+func hasUniqueKey(list []interface{}, key string) bool {
+	seen := make(map[string]bool)
+	for _, element := range list {
+		object, ok := element.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		value, ok := object[key].(string)
+		if !ok || value == "" || seen[value] {
+			return false
+		}
+		seen[value] = true
+	}
+	return true
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
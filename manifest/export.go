@@ -0,0 +1,297 @@
+package manifest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+// Client exports and imports manifests using the clients from a ServiceClient.
+type Client struct {
+	applications      *msgraph.ApplicationsClient
+	servicePrincipals *msgraph.ServicePrincipalsClient
+	directoryObjects  *msgraph.DirectoryObjectsClient
+	users             *msgraph.UsersClient
+	resolver          *msgraph.PermissionResolver
+}
+
+// New returns a Client which uses the clients from the specified ServiceClient.
+func New(svc *msgraph.ServiceClient) *Client {
+	servicePrincipals := svc.ServicePrincipals()
+	return &Client{
+		applications:      svc.Applications(),
+		servicePrincipals: servicePrincipals,
+		directoryObjects:  svc.DirectoryObjects(),
+		users:             svc.Users(),
+		resolver:          msgraph.NewPermissionResolver(servicePrincipals),
+	}
+}
+
+// Export builds a manifest for the application with the specified object ID.
+func (c *Client) Export(ctx context.Context, applicationId string) (*Manifest, error) {
+	app, _, err := c.applications.Get(ctx, applicationId, odata.Query{})
+	if err != nil {
+		return nil, fmt.Errorf("retrieving application: %v", err)
+	}
+	return c.export(ctx, *app)
+}
+
+func (c *Client) export(ctx context.Context, app msgraph.Application) (*Manifest, error) {
+	if app.ID() == nil || app.AppId == nil {
+		return nil, fmt.Errorf("application has no object ID or application ID")
+	}
+	id, appId := *app.ID(), *app.AppId
+
+	m := Manifest{Version: Version}
+
+	requiredPermissions, err := c.requiredPermissions(ctx, app)
+	if err != nil {
+		return nil, err
+	}
+	m.RequiredPermissions = requiredPermissions
+
+	if m.Application, err = portableApplication(app); err != nil {
+		return nil, err
+	}
+
+	servicePrincipals, _, err := c.servicePrincipals.List(ctx, odata.Query{Filter: fmt.Sprintf("appId eq '%s'", appId)})
+	if err != nil {
+		return nil, fmt.Errorf("retrieving service principal: %v", err)
+	}
+	if servicePrincipals != nil && len(*servicePrincipals) > 0 {
+		sp := (*servicePrincipals)[0]
+		m.ServicePrincipal = &ServicePrincipalSettings{
+			AccountEnabled:             sp.AccountEnabled,
+			AppRoleAssignmentRequired:  sp.AppRoleAssignmentRequired,
+			Description:                sp.Description,
+			LoginUrl:                   sp.LoginUrl,
+			Notes:                      sp.Notes,
+			NotificationEmailAddresses: sp.NotificationEmailAddresses,
+			PreferredSingleSignOnMode:  sp.PreferredSingleSignOnMode,
+			Tags:                       sp.Tags,
+		}
+	}
+
+	if m.Owners, err = c.owners(ctx, id); err != nil {
+		return nil, err
+	}
+
+	credentials, _, err := c.applications.ListFederatedIdentityCredentials(ctx, id, odata.Query{})
+	if err != nil {
+		return nil, fmt.Errorf("listing federated identity credentials: %v", err)
+	}
+	if credentials != nil {
+		for _, credential := range *credentials {
+			credential.ID = nil
+			m.FederatedIdentityCredentials = append(m.FederatedIdentityCredentials, credential)
+		}
+		sort.Slice(m.FederatedIdentityCredentials, func(i, j int) bool {
+			return stringValue(m.FederatedIdentityCredentials[i].Name) < stringValue(m.FederatedIdentityCredentials[j].Name)
+		})
+	}
+
+	extensions, _, err := c.applications.ListExtensions(ctx, id, odata.Query{})
+	if err != nil {
+		return nil, fmt.Errorf("listing extensions: %v", err)
+	}
+	if extensions != nil {
+		prefix := extensionPrefix(appId)
+		for _, extension := range *extensions {
+			if extension.Name != nil {
+				name := strings.TrimPrefix(*extension.Name, prefix)
+				extension.Name = &name
+			}
+			extension.Id = nil
+			extension.AppDisplayName = nil
+			extension.IsSyncedFromOnPremises = nil
+			m.Extensions = append(m.Extensions, extension)
+		}
+		sort.Slice(m.Extensions, func(i, j int) bool {
+			return stringValue(m.Extensions[i].Name) < stringValue(m.Extensions[j].Name)
+		})
+	}
+
+	logo, status, err := c.applications.GetLogo(ctx, id)
+	if err != nil && status != http.StatusNotFound {
+		return nil, fmt.Errorf("retrieving logo: %v", err)
+	}
+	if err == nil {
+		defer logo.Close()
+		data, err := io.ReadAll(logo)
+		if err != nil {
+			return nil, fmt.Errorf("reading logo: %v", err)
+		}
+		if len(data) > 0 {
+			m.Logo = &Logo{ContentType: http.DetectContentType(data), Data: data}
+		}
+	}
+
+	return &m, nil
+}
+
+// requiredPermissions translates the RequiredResourceAccess of an application to permission names. Permissions that
+// the application requires from itself are resolved from its own app roles and permission scopes, since its service
+// principal may not exist.
+func (c *Client) requiredPermissions(ctx context.Context, app msgraph.Application) ([]RequiredPermissions, error) {
+	if app.RequiredResourceAccess == nil {
+		return nil, nil
+	}
+
+	own := ownPermissions(app)
+	result := make([]RequiredPermissions, 0)
+	for _, rra := range *app.RequiredResourceAccess {
+		if rra.ResourceAppId == nil || rra.ResourceAccess == nil {
+			continue
+		}
+		permissions := RequiredPermissions{ResourceAppId: *rra.ResourceAppId}
+		self := strings.EqualFold(*rra.ResourceAppId, *app.AppId)
+		if self {
+			permissions.ResourceAppId = AppIdPlaceholder
+		}
+
+		for _, access := range *rra.ResourceAccess {
+			if access.ID == nil {
+				continue
+			}
+			var value string
+			if self {
+				permission, ok := own.byId[permissionKey(access.Type, *access.ID)]
+				if !ok {
+					return nil, fmt.Errorf("%s %q is not published by the application", access.Type, *access.ID)
+				}
+				value = permission
+			} else {
+				permission, err := c.resolver.ResolveId(ctx, *rra.ResourceAppId, access.Type, *access.ID)
+				if err != nil {
+					return nil, fmt.Errorf("resolving required permissions: %v", err)
+				}
+				value = permission.Value
+			}
+
+			switch access.Type {
+			case msgraph.ResourceAccessTypeScope:
+				permissions.Scopes = append(permissions.Scopes, value)
+			case msgraph.ResourceAccessTypeRole:
+				permissions.Roles = append(permissions.Roles, value)
+			}
+		}
+		result = append(result, permissions)
+	}
+
+	return result, nil
+}
+
+// owners returns the sorted user principal names of the owners of an application which are users.
+func (c *Client) owners(ctx context.Context, applicationId string) ([]string, error) {
+	ownerIds, _, err := c.applications.ListOwners(ctx, applicationId)
+	if err != nil {
+		return nil, fmt.Errorf("listing owners: %v", err)
+	}
+	if ownerIds == nil || len(*ownerIds) == 0 {
+		return nil, nil
+	}
+
+	users, _, err := c.directoryObjects.GetByIds(ctx, *ownerIds, []odata.ShortType{odata.ShortTypeUser})
+	if err != nil {
+		return nil, fmt.Errorf("retrieving owners: %v", err)
+	}
+
+	owners := make([]string, 0)
+	for _, user := range *users {
+		if upn, ok := user.AdditionalData["userPrincipalName"].(string); ok && upn != "" {
+			owners = append(owners, upn)
+		}
+	}
+	sort.Strings(owners)
+	return owners, nil
+}
+
+// portableApplication returns a copy of an application without its tenant-specific identifiers, credentials, owners
+// and required resource access, and with its application ID replaced by AppIdPlaceholder.
+func portableApplication(app msgraph.Application) (msgraph.Application, error) {
+	appId := *app.AppId
+
+	app.DirectoryObject = msgraph.DirectoryObject{}
+	app.Owners = nil
+	app.AppId = nil
+	app.CreatedDateTime = nil
+	app.DeletedDateTime = nil
+	app.DisabledByMicrosoftStatus = nil
+	app.FederatedIdentityCredentials = nil
+	app.KeyCredentials = nil
+	app.PasswordCredentials = nil
+	app.PublisherDomain = nil
+	app.RequiredResourceAccess = nil
+	app.TokenEncryptionKeyId = nil
+	app.VerifiedPublisher = nil
+
+	return substitute(app, appId, AppIdPlaceholder)
+}
+
+// substitute replaces every occurrence of old with new in an application. Neither may contain characters that are
+// escaped in JSON.
+func substitute(app msgraph.Application, old, new string) (msgraph.Application, error) {
+	data, err := json.Marshal(app)
+	if err != nil {
+		return app, fmt.Errorf("json.Marshal(): %v", err)
+	}
+
+	data = bytes.ReplaceAll(data, []byte(old), []byte(new))
+
+	var result msgraph.Application
+	if err := json.Unmarshal(data, &result); err != nil {
+		return app, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+	return result, nil
+}
+
+// ownPermissionIndex maps between the IDs and values of the app roles and permission scopes of an application.
+type ownPermissionIndex struct {
+	byId    map[string]string
+	byValue map[string]string
+}
+
+func ownPermissions(app msgraph.Application) ownPermissionIndex {
+	index := ownPermissionIndex{byId: make(map[string]string), byValue: make(map[string]string)}
+	add := func(permissionType msgraph.ResourceAccessType, id, value *string) {
+		if id != nil && value != nil {
+			index.byId[permissionKey(permissionType, *id)] = *value
+			index.byValue[permissionKey(permissionType, *value)] = *id
+		}
+	}
+	if app.AppRoles != nil {
+		for _, role := range *app.AppRoles {
+			add(msgraph.ResourceAccessTypeRole, role.ID, role.Value)
+		}
+	}
+	if app.Api != nil && app.Api.OAuth2PermissionScopes != nil {
+		for _, scope := range *app.Api.OAuth2PermissionScopes {
+			add(msgraph.ResourceAccessTypeScope, scope.ID, scope.Value)
+		}
+	}
+	return index
+}
+
+func permissionKey(permissionType msgraph.ResourceAccessType, idOrValue string) string {
+	return strings.ToLower(permissionType + "/" + idOrValue)
+}
+
+// extensionPrefix returns the prefix of the names of extension properties registered by an application.
+func extensionPrefix(appId string) string {
+	return fmt.Sprintf("extension_%s_", strings.ReplaceAll(appId, "-", ""))
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,334 @@
+package manifest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+// ImportOptions configures Import.
+type ImportOptions struct {
+	// ApplicationId is the object ID of the application to update. When empty, the application with the same
+	// uniqueName as the manifest, or otherwise the same displayName, is updated. When there is no such application, a
+	// new application is created.
+	ApplicationId string
+
+	// DryRun reports the changes that would be made without making them.
+	DryRun bool
+}
+
+// ImportResult describes the outcome of an import. Changes lists the changes made to the target application. Properties
+// which are absent from the manifest are not removed from the target application, and likewise owners, federated
+// identity credentials and extensions are only ever added or updated, and existing extensions are never modified.
+// Ignored lists the differences between the manifest and the target application which remain after the import.
+type ImportResult struct {
+	ApplicationId string
+	AppId         string
+	Created       bool
+	Changes       []Change
+	Ignored       []Change
+	Warnings      []string
+}
+
+// Import creates or updates an application so that it matches the manifest.
+func (c *Client) Import(ctx context.Context, m *Manifest, options ImportOptions) (*ImportResult, error) {
+	if m == nil {
+		return nil, fmt.Errorf("manifest was nil")
+	}
+
+	result := ImportResult{}
+
+	target, err := c.findTarget(ctx, m, options)
+	if err != nil {
+		return nil, err
+	}
+
+	var current *Manifest
+	if target != nil {
+		result.ApplicationId, result.AppId = *target.ID(), *target.AppId
+		if current, err = c.export(ctx, *target); err != nil {
+			return nil, fmt.Errorf("exporting target application: %v", err)
+		}
+	}
+
+	effective, err := imported(current, m)
+	if err != nil {
+		return nil, err
+	}
+	if result.Changes, err = Diff(current, effective); err != nil {
+		return nil, err
+	}
+	if result.Ignored, err = Diff(effective, m); err != nil {
+		return nil, err
+	}
+
+	// check the credentials the application will have after the import before anything is written
+	if err := msgraph.ValidateFederatedIdentityCredentials(effective.FederatedIdentityCredentials); err != nil {
+		return &result, fmt.Errorf("validating federated identity credentials: %v", err)
+	}
+	if options.DryRun || len(result.Changes) == 0 {
+		return &result, nil
+	}
+
+	if target == nil {
+		// the application ID is needed to resolve placeholders, so create the application first and update it after
+		created, _, err := c.applications.Create(ctx, msgraph.Application{
+			DisplayName:    m.Application.DisplayName,
+			SignInAudience: m.Application.SignInAudience,
+			UniqueName:     m.Application.UniqueName,
+		})
+		if err != nil {
+			return &result, fmt.Errorf("creating application: %v", err)
+		}
+		result.ApplicationId, result.AppId, result.Created = *created.ID(), *created.AppId, true
+		current = &Manifest{Version: Version}
+	}
+
+	app, err := substitute(m.Application, AppIdPlaceholder, result.AppId)
+	if err != nil {
+		return &result, err
+	}
+	app.DirectoryObject = msgraph.DirectoryObject{Id: &result.ApplicationId}
+	app.UniqueName = nil // can only be set on creation
+	if app.RequiredResourceAccess, err = c.requiredResourceAccess(ctx, m, result.AppId); err != nil {
+		return &result, err
+	}
+	if _, err := c.applications.Update(ctx, app); err != nil {
+		return &result, fmt.Errorf("updating application: %v", err)
+	}
+
+	if m.ServicePrincipal != nil {
+		if err := c.importServicePrincipal(ctx, result.AppId, *m.ServicePrincipal); err != nil {
+			return &result, err
+		}
+	}
+
+	if err := c.importOwners(ctx, &result, m.Owners, current.Owners); err != nil {
+		return &result, err
+	}
+
+	if err := c.importFederatedIdentityCredentials(ctx, result.ApplicationId, m.FederatedIdentityCredentials); err != nil {
+		return &result, err
+	}
+
+	existingExtensions := make(map[string]msgraph.ApplicationExtension)
+	for _, extension := range current.Extensions {
+		existingExtensions[stringValue(extension.Name)] = extension
+	}
+	for _, extension := range m.Extensions {
+		name := stringValue(extension.Name)
+		if existing, ok := existingExtensions[name]; ok {
+			if changes, _ := Diff(&Manifest{Extensions: []msgraph.ApplicationExtension{existing}}, &Manifest{Extensions: []msgraph.ApplicationExtension{extension}}); len(changes) > 0 {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("extension %q differs from the manifest but extensions cannot be updated", name))
+			}
+			continue
+		}
+		if _, _, err := c.applications.CreateExtension(ctx, extension, result.ApplicationId); err != nil {
+			return &result, fmt.Errorf("creating extension %q: %v", name, err)
+		}
+	}
+
+	if m.Logo != nil && (current.Logo == nil || !bytes.Equal(current.Logo.Data, m.Logo.Data)) {
+		if _, err := c.applications.UploadLogo(ctx, result.ApplicationId, m.Logo.ContentType, m.Logo.Data); err != nil {
+			return &result, fmt.Errorf("uploading logo: %v", err)
+		}
+	}
+
+	return &result, nil
+}
+
+// findTarget returns the application to be updated, or nil when a new application should be created.
+func (c *Client) findTarget(ctx context.Context, m *Manifest, options ImportOptions) (*msgraph.Application, error) {
+	if options.ApplicationId != "" {
+		app, _, err := c.applications.Get(ctx, options.ApplicationId, odata.Query{})
+		if err != nil {
+			return nil, fmt.Errorf("retrieving application: %v", err)
+		}
+		return app, nil
+	}
+
+	var filter string
+	switch {
+	case m.Application.UniqueName != nil && *m.Application.UniqueName != "":
+		filter = fmt.Sprintf("uniqueName eq '%s'", odataEscape(*m.Application.UniqueName))
+	case m.Application.DisplayName != nil && *m.Application.DisplayName != "":
+		filter = fmt.Sprintf("displayName eq '%s'", odataEscape(*m.Application.DisplayName))
+	default:
+		return nil, fmt.Errorf("manifest application has no uniqueName or displayName")
+	}
+
+	apps, _, err := c.applications.List(ctx, odata.Query{Filter: filter})
+	if err != nil {
+		return nil, fmt.Errorf("listing applications: %v", err)
+	}
+	if apps == nil || len(*apps) == 0 {
+		return nil, nil
+	}
+	if len(*apps) > 1 {
+		return nil, fmt.Errorf("found %d applications matching %s, specify the ApplicationId to update", len(*apps), filter)
+	}
+	return &(*apps)[0], nil
+}
+
+// requiredResourceAccess resolves the permission names in a manifest to the RequiredResourceAccess for the application
+// with the specified application ID.
+func (c *Client) requiredResourceAccess(ctx context.Context, m *Manifest, appId string) (*[]msgraph.RequiredResourceAccess, error) {
+	result := make([]msgraph.RequiredResourceAccess, 0)
+	own := ownPermissions(m.Application)
+
+	for _, permissions := range m.RequiredPermissions {
+		if permissions.ResourceAppId != AppIdPlaceholder {
+			rra, err := c.resolver.RequiredResourceAccess(ctx, msgraph.PermissionNames{
+				ResourceAppId: permissions.ResourceAppId,
+				Scopes:        permissions.Scopes,
+				Roles:         permissions.Roles,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("resolving required permissions: %v", err)
+			}
+			result = append(result, *rra...)
+			continue
+		}
+
+		resourceAccess := make([]msgraph.ResourceAccess, 0)
+		for _, declared := range []struct {
+			permissionType msgraph.ResourceAccessType
+			values         []string
+		}{{msgraph.ResourceAccessTypeScope, permissions.Scopes}, {msgraph.ResourceAccessTypeRole, permissions.Roles}} {
+			for _, value := range declared.values {
+				id, ok := own.byValue[permissionKey(declared.permissionType, value)]
+				if !ok {
+					return nil, fmt.Errorf("%s %q is not published by the application", declared.permissionType, value)
+				}
+				resourceAccess = append(resourceAccess, msgraph.ResourceAccess{ID: &id, Type: declared.permissionType})
+			}
+		}
+		resourceAppId := appId
+		result = append(result, msgraph.RequiredResourceAccess{ResourceAppId: &resourceAppId, ResourceAccess: &resourceAccess})
+	}
+
+	return &result, nil
+}
+
+func (c *Client) importServicePrincipal(ctx context.Context, appId string, settings ServicePrincipalSettings) error {
+	sp := msgraph.ServicePrincipal{
+		AccountEnabled:             settings.AccountEnabled,
+		AppRoleAssignmentRequired:  settings.AppRoleAssignmentRequired,
+		Description:                settings.Description,
+		LoginUrl:                   settings.LoginUrl,
+		Notes:                      settings.Notes,
+		NotificationEmailAddresses: settings.NotificationEmailAddresses,
+		PreferredSingleSignOnMode:  settings.PreferredSingleSignOnMode,
+		Tags:                       settings.Tags,
+	}
+
+	existing, _, err := c.servicePrincipals.List(ctx, odata.Query{Filter: fmt.Sprintf("appId eq '%s'", appId)})
+	if err != nil {
+		return fmt.Errorf("retrieving service principal: %v", err)
+	}
+	if existing == nil || len(*existing) == 0 {
+		sp.AppId = &appId
+		if _, _, err := c.servicePrincipals.Create(ctx, sp); err != nil {
+			return fmt.Errorf("creating service principal: %v", err)
+		}
+		return nil
+	}
+
+	sp.DirectoryObject = msgraph.DirectoryObject{Id: (*existing)[0].ID()}
+	if _, err := c.servicePrincipals.Update(ctx, sp); err != nil {
+		return fmt.Errorf("updating service principal: %v", err)
+	}
+	return nil
+}
+
+// importOwners adds the owners listed in the manifest that are not already owners. Owners who cannot be found in the
+// target tenant are reported as warnings.
+func (c *Client) importOwners(ctx context.Context, result *ImportResult, owners, existing []string) error {
+	current := make(map[string]bool)
+	for _, upn := range existing {
+		current[strings.ToLower(upn)] = true
+	}
+
+	add := make(msgraph.Owners, 0)
+	for _, upn := range owners {
+		if current[strings.ToLower(upn)] {
+			continue
+		}
+		users, _, err := c.users.List(ctx, odata.Query{
+			Filter: fmt.Sprintf("userPrincipalName eq '%s'", odataEscape(upn)),
+			Select: []string{"id"},
+		})
+		if err != nil {
+			return fmt.Errorf("retrieving owner %q: %v", upn, err)
+		}
+		if users == nil || len(*users) == 0 || (*users)[0].ID() == nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("owner %q was not found", upn))
+			continue
+		}
+		owner := msgraph.DirectoryObject{Id: (*users)[0].ID()}
+		odataId := odata.Id(owner.Uri(c.applications.BaseClient.Endpoint, c.applications.BaseClient.ApiVersion))
+		owner.ODataId = &odataId
+		add = append(add, owner)
+	}
+
+	if len(add) == 0 {
+		return nil
+	}
+	app := msgraph.Application{
+		DirectoryObject: msgraph.DirectoryObject{Id: &result.ApplicationId},
+		Owners:          &add,
+	}
+	if _, err := c.applications.AddOwners(ctx, &app); err != nil {
+		return fmt.Errorf("adding owners: %v", err)
+	}
+	return nil
+}
+
+// importFederatedIdentityCredentials creates or updates federated identity credentials, matching them by name. The
+// resulting set of credentials is validated by Import before any changes are made.
+func (c *Client) importFederatedIdentityCredentials(ctx context.Context, applicationId string, credentials []msgraph.FederatedIdentityCredential) error {
+	if len(credentials) == 0 {
+		return nil
+	}
+
+	existing, _, err := c.applications.ListFederatedIdentityCredentials(ctx, applicationId, odata.Query{})
+	if err != nil {
+		return fmt.Errorf("listing federated identity credentials: %v", err)
+	}
+	ids := make(map[string]msgraph.FederatedIdentityCredential)
+	if existing != nil {
+		for _, credential := range *existing {
+			ids[stringValue(credential.Name)] = credential
+		}
+	}
+
+	for _, credential := range credentials {
+		name := stringValue(credential.Name)
+		if current, ok := ids[name]; ok {
+			id := current.ID
+			current.ID = nil
+			if changes, _ := Diff(&Manifest{FederatedIdentityCredentials: []msgraph.FederatedIdentityCredential{current}}, &Manifest{FederatedIdentityCredentials: []msgraph.FederatedIdentityCredential{credential}}); len(changes) == 0 {
+				continue
+			}
+			credential.ID = id
+			if _, err := c.applications.UpdateFederatedIdentityCredential(ctx, applicationId, credential); err != nil {
+				return fmt.Errorf("updating federated identity credential %q: %v", name, err)
+			}
+			continue
+		}
+		if _, _, err := c.applications.CreateFederatedIdentityCredential(ctx, applicationId, credential); err != nil {
+			return fmt.Errorf("creating federated identity credential %q: %v", name, err)
+		}
+	}
+
+	return nil
+}
+
+// odataEscape escapes single quotes in a string literal for use in a $filter expression.
+func odataEscape(s string) string {
+	return strings.ReplaceAll(s, "'", "''")
+}
@@ -0,0 +1,128 @@
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// imported returns the manifest of the target application as it will be after importing m, by applying the same rules
+// as Import: objects are updated without the properties which are absent from the manifest, lists within them are
+// replaced as a whole, the required permissions are replaced, owners and federated identity credentials are only added
+// or updated, existing extensions are kept as they are, and the logo is only replaced when the manifest has one.
+func imported(current, m *Manifest) (*Manifest, error) {
+	if current == nil {
+		current = &Manifest{Version: Version}
+	}
+	a, err := generic(current)
+	if err != nil {
+		return nil, err
+	}
+	b, err := generic(m)
+	if err != nil {
+		return nil, err
+	}
+	from, to := a.(map[string]interface{}), b.(map[string]interface{})
+
+	result := make(map[string]interface{})
+	for k, v := range to {
+		result[k] = v
+	}
+	result["application"] = mergeObject(from["application"], to["application"])
+	result["servicePrincipal"] = mergeObject(from["servicePrincipal"], to["servicePrincipal"])
+	result["owners"] = mergeOwners(from["owners"], to["owners"])
+	result["federatedIdentityCredentials"] = mergeNamed(from["federatedIdentityCredentials"], to["federatedIdentityCredentials"], true)
+	result["extensions"] = mergeNamed(from["extensions"], to["extensions"], false)
+	if to["logo"] == nil {
+		result["logo"] = from["logo"]
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("json.Marshal(): %v", err)
+	}
+	var effective Manifest
+	if err := json.Unmarshal(data, &effective); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+	return &effective, nil
+}
+
+// mergeObject returns b with any properties it lacks taken from a, recursively for nested objects.
+func mergeObject(a, b interface{}) interface{} {
+	if b == nil {
+		return a
+	}
+	am, ok := a.(map[string]interface{})
+	if !ok {
+		return b
+	}
+	bm, ok := b.(map[string]interface{})
+	if !ok {
+		return b
+	}
+	result := make(map[string]interface{})
+	for k, v := range am {
+		result[k] = v
+	}
+	for k, v := range bm {
+		result[k] = mergeObject(am[k], v)
+	}
+	return result
+}
+
+// mergeOwners returns the owners in a followed by those in b which are not in a, compared case-insensitively.
+func mergeOwners(a, b interface{}) interface{} {
+	result, _ := a.([]interface{})
+	seen := make(map[string]bool)
+	for _, owner := range result {
+		seen[strings.ToLower(fmt.Sprint(owner))] = true
+	}
+	list, _ := b.([]interface{})
+	for _, owner := range list {
+		if !seen[strings.ToLower(fmt.Sprint(owner))] {
+			result = append(result, owner)
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// mergeNamed returns the elements of a followed by those of b with names not in a. When replace is true, elements of a
+// are replaced by the elements of b with the same name.
+func mergeNamed(a, b interface{}, replace bool) interface{} {
+	list, _ := b.([]interface{})
+	byName := make(map[string]interface{})
+	for _, element := range list {
+		if object, ok := element.(map[string]interface{}); ok {
+			byName[fmt.Sprint(object["name"])] = element
+		}
+	}
+
+	result := make([]interface{}, 0)
+	seen := make(map[string]bool)
+	existing, _ := a.([]interface{})
+	for _, element := range existing {
+		object, ok := element.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name := fmt.Sprint(object["name"])
+		seen[name] = true
+		if replacement, ok := byName[name]; ok && replace {
+			element = replacement
+		}
+		result = append(result, element)
+	}
+	for _, element := range list {
+		if object, ok := element.(map[string]interface{}); ok && !seen[fmt.Sprint(object["name"])] {
+			result = append(result, element)
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
@@ -0,0 +1,95 @@
+// Package manifest exports application registrations to a portable JSON manifest, and imports them into another
+// tenant. Identifiers that differ between tenants are abstracted away: the application ID is replaced with a
+// placeholder, permissions are recorded by name, owners by user principal name and extension properties by their
+// unprefixed name. Credentials are never exported.
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+// Version is the current manifest format version.
+const Version = 1
+
+// AppIdPlaceholder is substituted for the application ID wherever it appears in an exported application, e.g. in
+// identifier URIs such as "api://${appId}", and replaced with the application ID of the target application on import.
+const AppIdPlaceholder = "${appId}"
+
+// Manifest is a portable description of an application registration.
+type Manifest struct {
+	Version int `json:"version"`
+
+	// Application holds the properties of the application, without its identifiers, credentials, owners or
+	// RequiredResourceAccess.
+	Application msgraph.Application `json:"application"`
+
+	// RequiredPermissions replaces the RequiredResourceAccess of the application, using permission names instead of
+	// IDs.
+	RequiredPermissions []RequiredPermissions `json:"requiredPermissions,omitempty"`
+
+	// ServicePrincipal holds settings for the service principal of the application, when it has one.
+	ServicePrincipal *ServicePrincipalSettings `json:"servicePrincipal,omitempty"`
+
+	// Owners lists the user principal names of owners who are users.
+	Owners []string `json:"owners,omitempty"`
+
+	FederatedIdentityCredentials []msgraph.FederatedIdentityCredential `json:"federatedIdentityCredentials,omitempty"`
+
+	// Extensions lists the directory extension properties, with names excluding the "extension_<appId>_" prefix.
+	Extensions []msgraph.ApplicationExtension `json:"extensions,omitempty"`
+
+	Logo *Logo `json:"logo,omitempty"`
+}
+
+// RequiredPermissions lists the permissions required from a resource by name. The ResourceAppId is AppIdPlaceholder
+// when an application requires permissions that it publishes itself.
+type RequiredPermissions struct {
+	ResourceAppId string   `json:"resourceAppId"`
+	Scopes        []string `json:"scopes,omitempty"`
+	Roles         []string `json:"roles,omitempty"`
+}
+
+// ServicePrincipalSettings holds the properties of a service principal which are configured by administrators, rather
+// than being derived from the application.
+type ServicePrincipalSettings struct {
+	AccountEnabled             *bool                              `json:"accountEnabled,omitempty"`
+	AppRoleAssignmentRequired  *bool                              `json:"appRoleAssignmentRequired,omitempty"`
+	Description                *msgraph.StringNullWhenEmpty       `json:"description,omitempty"`
+	LoginUrl                   *msgraph.StringNullWhenEmpty       `json:"loginUrl,omitempty"`
+	Notes                      *msgraph.StringNullWhenEmpty       `json:"notes,omitempty"`
+	NotificationEmailAddresses *[]string                          `json:"notificationEmailAddresses,omitempty"`
+	PreferredSingleSignOnMode  *msgraph.PreferredSingleSignOnMode `json:"preferredSingleSignOnMode,omitempty"`
+	Tags                       *[]string                          `json:"tags,omitempty"`
+}
+
+// Logo is the logo image of an application. Data is encoded as base64 in JSON.
+type Logo struct {
+	ContentType string `json:"contentType"`
+	Data        []byte `json:"data"`
+}
+
+// Read decodes a manifest written by Write.
+func Read(r io.Reader) (*Manifest, error) {
+	var m Manifest
+	if err := json.NewDecoder(r).Decode(&m); err != nil {
+		return nil, fmt.Errorf("decoding manifest: %v", err)
+	}
+	if m.Version != Version {
+		return nil, fmt.Errorf("unsupported manifest version %d, expected %d", m.Version, Version)
+	}
+	return &m, nil
+}
+
+// Write encodes a manifest as indented JSON.
+func Write(w io.Writer, m *Manifest) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(m); err != nil {
+		return fmt.Errorf("encoding manifest: %v", err)
+	}
+	return nil
+}
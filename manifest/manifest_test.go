@@ -0,0 +1,355 @@
+package manifest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+const (
+	sourceAppId = "11111111-2222-3333-4444-555555555555"
+	targetAppId = "99999999-8888-7777-6666-555555555555"
+)
+
+var testLogo = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\x0dIHDR")
+
+const testGraphServicePrincipal = `{"value":[{
+	"id": "graph-sp-id",
+	"appId": "00000003-0000-0000-c000-000000000000",
+	"displayName": "Microsoft Graph",
+	"publishedPermissionScopes": [
+		{"id": "e1fe6dd8-ba31-4d61-89e7-88639da4683d", "value": "User.Read", "isEnabled": true}
+	]
+}]}`
+
+func respond(w http.ResponseWriter, status int, body string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write([]byte(body))
+}
+
+func newSourceServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		filter := r.URL.Query().Get("$filter")
+		switch {
+		case r.URL.Path == "/beta/applications/src-id":
+			respond(w, http.StatusOK, fmt.Sprintf(`{
+				"id": "src-id",
+				"appId": %[1]q,
+				"displayName": "Payroll",
+				"publisherDomain": "contoso.com",
+				"identifierUris": ["api://%[1]s"],
+				"appRoles": [{"id": "role-1", "value": "Payroll.Read", "displayName": "Read payroll", "isEnabled": true, "allowedMemberTypes": ["Application"]}],
+				"passwordCredentials": [{"keyId": "secret-1", "displayName": "ci"}],
+				"requiredResourceAccess": [
+					{"resourceAppId": "00000003-0000-0000-c000-000000000000", "resourceAccess": [{"id": "e1fe6dd8-ba31-4d61-89e7-88639da4683d", "type": "Scope"}]},
+					{"resourceAppId": %[1]q, "resourceAccess": [{"id": "role-1", "type": "Role"}]}
+				]
+			}`, sourceAppId))
+		case r.URL.Path == "/beta/servicePrincipals" && filter == "appId eq '00000003-0000-0000-c000-000000000000'":
+			respond(w, http.StatusOK, testGraphServicePrincipal)
+		case r.URL.Path == "/beta/servicePrincipals":
+			respond(w, http.StatusOK, `{"value":[{"id":"src-sp","appRoleAssignmentRequired":true,"tags":["HideApp"]}]}`)
+		case r.URL.Path == "/beta/applications/src-id/owners":
+			respond(w, http.StatusOK, `{"value":[{"id":"user-1"},{"id":"sp-owner"}]}`)
+		case r.URL.Path == "/v1.0/directoryObjects/getByIds":
+			respond(w, http.StatusOK, `{"value":[{"@odata.type":"#microsoft.graph.user","id":"user-1","userPrincipalName":"alice@contoso.com"}]}`)
+		case r.URL.Path == "/beta/applications/src-id/federatedIdentityCredentials":
+			respond(w, http.StatusOK, `{"value":[{"id":"fic-1","name":"deploy","issuer":"https://token.actions.githubusercontent.com","subject":"repo:contoso/payroll:environment:prod","audiences":["api://AzureADTokenExchange"]}]}`)
+		case r.URL.Path == "/beta/applications/src-id/extensionProperties":
+			respond(w, http.StatusOK, fmt.Sprintf(`{"value":[{"id":"ext-1","name":"extension_%s_costCenter","dataType":"String","targetObjects":["User"]}]}`, strings.ReplaceAll(sourceAppId, "-", "")))
+		case r.URL.Path == "/beta/applications/src-id/logo":
+			w.Header().Set("Content-Type", "image/png")
+			w.Write(testLogo)
+		default:
+			t.Errorf("unexpected request to source tenant: %s %s", r.Method, r.URL.String())
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+type targetTenant struct {
+	sync.Mutex
+	writes map[string]string
+}
+
+func newTargetServer(t *testing.T, tenant *targetTenant) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenant.Lock()
+		defer tenant.Unlock()
+		body, _ := io.ReadAll(r.Body)
+		filter := r.URL.Query().Get("$filter")
+
+		if r.Method != http.MethodGet {
+			tenant.writes[r.Method+" "+r.URL.Path] = string(body)
+		}
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/beta/applications" && filter == "displayName eq 'Payroll'":
+			respond(w, http.StatusOK, `{"value":[]}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/beta/applications":
+			respond(w, http.StatusCreated, fmt.Sprintf(`{"id":"dst-id","appId":%q}`, targetAppId))
+		case r.Method == http.MethodPatch && r.URL.Path == "/beta/applications/dst-id":
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && r.URL.Path == "/beta/servicePrincipals" && filter == "appId eq '00000003-0000-0000-c000-000000000000'":
+			respond(w, http.StatusOK, testGraphServicePrincipal)
+		case r.Method == http.MethodGet && r.URL.Path == "/beta/servicePrincipals":
+			respond(w, http.StatusOK, `{"value":[]}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/beta/servicePrincipals":
+			respond(w, http.StatusCreated, `{"id":"dst-sp"}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/beta/users":
+			respond(w, http.StatusOK, `{"value":[{"id":"user-9"}]}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/beta/applications/dst-id/owners/$ref":
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && r.URL.Path == "/beta/applications/dst-id/federatedIdentityCredentials":
+			respond(w, http.StatusOK, `{"value":[]}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/beta/applications/dst-id/federatedIdentityCredentials":
+			respond(w, http.StatusCreated, `{"id":"fic-9"}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/beta/applications/dst-id/extensionProperties":
+			respond(w, http.StatusCreated, `{"id":"ext-9"}`)
+		case r.Method == http.MethodPut && r.URL.Path == "/beta/applications/dst-id/logo":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request to target tenant: %s %s", r.Method, r.URL.String())
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestExportImport(t *testing.T) {
+	source := newSourceServer(t)
+	defer source.Close()
+
+	m, err := New(msgraph.NewServiceClient(msgraph.WithEndpoint(source.URL), msgraph.WithRetryMax(0))).Export(context.Background(), "src-id")
+	if err != nil {
+		t.Fatalf("Export(): %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, m); err != nil {
+		t.Fatalf("Write(): %v", err)
+	}
+	exported := buf.String()
+	for _, unexpected := range []string{sourceAppId, strings.ReplaceAll(sourceAppId, "-", ""), "src-id", "secret-1", "fic-1", "ext-1", "user-1", "publisherDomain"} {
+		if strings.Contains(exported, unexpected) {
+			t.Errorf("exported manifest contains %q:\n%s", unexpected, exported)
+		}
+	}
+	if m.Application.IdentifierUris == nil || (*m.Application.IdentifierUris)[0] != "api://"+AppIdPlaceholder {
+		t.Errorf("unexpected identifierUris: %v", m.Application.IdentifierUris)
+	}
+	if len(m.RequiredPermissions) != 2 || m.RequiredPermissions[0].Scopes[0] != "User.Read" || m.RequiredPermissions[1].ResourceAppId != AppIdPlaceholder || m.RequiredPermissions[1].Roles[0] != "Payroll.Read" {
+		t.Errorf("unexpected requiredPermissions: %+v", m.RequiredPermissions)
+	}
+	if len(m.Owners) != 1 || m.Owners[0] != "alice@contoso.com" {
+		t.Errorf("unexpected owners: %v", m.Owners)
+	}
+	if len(m.Extensions) != 1 || *m.Extensions[0].Name != "costCenter" {
+		t.Errorf("unexpected extensions: %+v", m.Extensions)
+	}
+	if m.Logo == nil || m.Logo.ContentType != "image/png" {
+		t.Errorf("unexpected logo: %+v", m.Logo)
+	}
+
+	m, err = Read(&buf)
+	if err != nil {
+		t.Fatalf("Read(): %v", err)
+	}
+
+	tenant := &targetTenant{writes: make(map[string]string)}
+	target := newTargetServer(t, tenant)
+	defer target.Close()
+	client := New(msgraph.NewServiceClient(msgraph.WithEndpoint(target.URL), msgraph.WithRetryMax(0)))
+
+	// a dry run reports the changes without making any
+	result, err := client.Import(context.Background(), m, ImportOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("Import(): %v", err)
+	}
+	if len(result.Changes) == 0 || len(tenant.writes) != 0 {
+		t.Fatalf("expected changes and no writes, got %d changes and writes %v", len(result.Changes), tenant.writes)
+	}
+	for _, change := range result.Changes {
+		if change.Type != ChangeTypeAdd {
+			t.Errorf("expected only additions for a new application, got %s", change)
+		}
+	}
+
+	result, err = client.Import(context.Background(), m, ImportOptions{})
+	if err != nil {
+		t.Fatalf("Import(): %v", err)
+	}
+	if !result.Created || result.AppId != targetAppId || len(result.Warnings) != 0 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	var app msgraph.Application
+	if err := json.Unmarshal([]byte(tenant.writes["PATCH /beta/applications/dst-id"]), &app); err != nil {
+		t.Fatalf("decoding application update: %v", err)
+	}
+	if app.IdentifierUris == nil || (*app.IdentifierUris)[0] != "api://"+targetAppId {
+		t.Errorf("placeholder was not replaced: %v", app.IdentifierUris)
+	}
+	if app.RequiredResourceAccess == nil || len(*app.RequiredResourceAccess) != 2 {
+		t.Fatalf("unexpected requiredResourceAccess: %+v", app.RequiredResourceAccess)
+	}
+	if rra := (*app.RequiredResourceAccess)[1]; *rra.ResourceAppId != targetAppId || *(*rra.ResourceAccess)[0].ID != "role-1" {
+		t.Errorf("unexpected requiredResourceAccess for own permissions: %+v", rra)
+	}
+	for _, write := range []string{
+		"POST /beta/servicePrincipals",
+		"POST /beta/applications/dst-id/owners/$ref",
+		"POST /beta/applications/dst-id/federatedIdentityCredentials",
+		"POST /beta/applications/dst-id/extensionProperties",
+		"PUT /beta/applications/dst-id/logo",
+	} {
+		if _, ok := tenant.writes[write]; !ok {
+			t.Errorf("expected request %s", write)
+		}
+	}
+	if body := tenant.writes["POST /beta/applications/dst-id/extensionProperties"]; !strings.Contains(body, `"name":"costCenter"`) {
+		t.Errorf("unexpected extension: %s", body)
+	}
+}
+
+func TestImportInvalidFederatedIdentityCredentials(t *testing.T) {
+	tenant := &targetTenant{writes: make(map[string]string)}
+	target := newTargetServer(t, tenant)
+	defer target.Close()
+	client := New(msgraph.NewServiceClient(msgraph.WithEndpoint(target.URL), msgraph.WithRetryMax(0)))
+
+	tooMany := make([]msgraph.FederatedIdentityCredential, 0)
+	for i := 0; i <= msgraph.FederatedIdentityCredentialsLimit; i++ {
+		credential, err := msgraph.NewGitHubActionsCredential(fmt.Sprintf("env-%d", i), "contoso/payroll", msgraph.GitHubActionsEntityTypeEnvironment, fmt.Sprintf("env-%d", i))
+		if err != nil {
+			t.Fatalf("NewGitHubActionsCredential(): %v", err)
+		}
+		tooMany = append(tooMany, *credential)
+	}
+	duplicate := []msgraph.FederatedIdentityCredential{tooMany[0], tooMany[0]}
+	duplicate[1].Name = stringPtr("env-0-copy")
+
+	for name, credentials := range map[string][]msgraph.FederatedIdentityCredential{"too many": tooMany, "duplicate subject": duplicate} {
+		m := &Manifest{
+			Version:                      Version,
+			Application:                  msgraph.Application{DisplayName: stringPtr("Payroll")},
+			FederatedIdentityCredentials: credentials,
+		}
+		for _, dryRun := range []bool{true, false} {
+			if _, err := client.Import(context.Background(), m, ImportOptions{DryRun: dryRun}); err == nil || !strings.Contains(err.Error(), "federated identity credentials") {
+				t.Errorf("%s (dry run %t): expected a federated identity credentials error, got: %v", name, dryRun, err)
+			}
+		}
+	}
+	if len(tenant.writes) != 0 {
+		t.Errorf("expected no writes, got %v", tenant.writes)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	from := &Manifest{
+		Version: Version,
+		FederatedIdentityCredentials: []msgraph.FederatedIdentityCredential{
+			{Name: stringPtr("deploy"), Subject: stringPtr("repo:contoso/payroll:environment:test")},
+			{Name: stringPtr("old"), Subject: stringPtr("repo:contoso/payroll:ref:refs/heads/main")},
+		},
+		Owners: []string{"alice@contoso.com"},
+	}
+	to := &Manifest{
+		Version: Version,
+		FederatedIdentityCredentials: []msgraph.FederatedIdentityCredential{
+			{Name: stringPtr("deploy"), Subject: stringPtr("repo:contoso/payroll:environment:prod")},
+		},
+		Owners: []string{"alice@contoso.com", "bob@contoso.com"},
+	}
+	to.Application.DisplayName = stringPtr("Payroll")
+
+	changes, err := Diff(from, to)
+	if err != nil {
+		t.Fatalf("Diff(): %v", err)
+	}
+	var lines []string
+	for _, change := range changes {
+		lines = append(lines, change.String())
+	}
+	expected := []string{
+		`+ application.displayName: "Payroll"`,
+		`~ federatedIdentityCredentials[name=deploy].subject: "repo:contoso/payroll:environment:test" -> "repo:contoso/payroll:environment:prod"`,
+		`- federatedIdentityCredentials[name=old]: {"name":"old","subject":"repo:contoso/payroll:ref:refs/heads/main"}`,
+		`~ owners: ["alice@contoso.com"] -> ["alice@contoso.com","bob@contoso.com"]`,
+	}
+	if strings.Join(lines, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected changes:\n%s\nexpected:\n%s", strings.Join(lines, "\n"), strings.Join(expected, "\n"))
+	}
+}
+
+func TestImportedChanges(t *testing.T) {
+	current := &Manifest{
+		Version: Version,
+		FederatedIdentityCredentials: []msgraph.FederatedIdentityCredential{
+			{Name: stringPtr("deploy"), Subject: stringPtr("repo:contoso/payroll:environment:test")},
+			{Name: stringPtr("old"), Subject: stringPtr("repo:contoso/payroll:ref:refs/heads/main")},
+		},
+		Owners: []string{"alice@contoso.com", "carol@contoso.com"},
+	}
+	current.Application.DisplayName = stringPtr("Payroll")
+	current.Application.Notes = msgraph.NullableString("managed by the payroll team")
+	m := &Manifest{
+		Version: Version,
+		FederatedIdentityCredentials: []msgraph.FederatedIdentityCredential{
+			{Name: stringPtr("deploy"), Subject: stringPtr("repo:contoso/payroll:environment:prod")},
+		},
+		Owners: []string{"Alice@contoso.com", "bob@contoso.com"},
+	}
+	m.Application.DisplayName = stringPtr("Payroll")
+
+	effective, err := imported(current, m)
+	if err != nil {
+		t.Fatalf("imported(): %v", err)
+	}
+	changes, err := Diff(current, effective)
+	if err != nil {
+		t.Fatalf("Diff(): %v", err)
+	}
+	var lines []string
+	for _, change := range changes {
+		lines = append(lines, change.String())
+	}
+	expected := []string{
+		`~ federatedIdentityCredentials[name=deploy].subject: "repo:contoso/payroll:environment:test" -> "repo:contoso/payroll:environment:prod"`,
+		`~ owners: ["alice@contoso.com","carol@contoso.com"] -> ["alice@contoso.com","carol@contoso.com","bob@contoso.com"]`,
+	}
+	if strings.Join(lines, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected changes:\n%s\nexpected:\n%s", strings.Join(lines, "\n"), strings.Join(expected, "\n"))
+	}
+
+	ignored, err := Diff(effective, m)
+	if err != nil {
+		t.Fatalf("Diff(): %v", err)
+	}
+	if len(ignored) != 3 {
+		t.Errorf("expected the notes, owner and credential removals to be ignored, got %v", ignored)
+	}
+
+	// once applied, importing the same manifest again makes no changes
+	again, err := imported(effective, m)
+	if err != nil {
+		t.Fatalf("imported(): %v", err)
+	}
+	if changes, _ := Diff(effective, again); len(changes) != 0 {
+		t.Errorf("expected no changes on a second import, got %v", changes)
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -15,9 +15,9 @@ type AccessPackageClient struct {
 	BaseClient Client
 }
 
-func NewAccessPackageClient() *AccessPackageClient {
+func NewAccessPackageClient(opts ...ClientOption) *AccessPackageClient {
 	return &AccessPackageClient{
-		BaseClient: NewClient(Version10),
+		BaseClient: NewClient(Version10, opts...).withFeature(FeatureEntitlementManagement),
 	}
 }
 
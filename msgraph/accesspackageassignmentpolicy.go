@@ -15,9 +15,9 @@ type AccessPackageAssignmentPolicyClient struct {
 	BaseClient Client
 }
 
-func NewAccessPackageAssignmentPolicyClient() *AccessPackageAssignmentPolicyClient {
+func NewAccessPackageAssignmentPolicyClient(opts ...ClientOption) *AccessPackageAssignmentPolicyClient {
 	return &AccessPackageAssignmentPolicyClient{
-		BaseClient: NewClient(VersionBeta),
+		BaseClient: NewClient(VersionBeta, opts...).withFeature(FeatureEntitlementManagement),
 	}
 }
 
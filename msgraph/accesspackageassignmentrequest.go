@@ -14,9 +14,9 @@ type AccessPackageAssignmentRequestClient struct {
 	BaseClient Client
 }
 
-func NewAccessPackageAssignmentRequestClient() *AccessPackageAssignmentRequestClient {
+func NewAccessPackageAssignmentRequestClient(opts ...ClientOption) *AccessPackageAssignmentRequestClient {
 	return &AccessPackageAssignmentRequestClient{
-		BaseClient: NewClient(Version10),
+		BaseClient: NewClient(Version10, opts...).withFeature(FeatureEntitlementManagement),
 	}
 }
 
@@ -15,9 +15,9 @@ type AccessPackageCatalogClient struct {
 	BaseClient Client
 }
 
-func NewAccessPackageCatalogClient() *AccessPackageCatalogClient {
+func NewAccessPackageCatalogClient(opts ...ClientOption) *AccessPackageCatalogClient {
 	return &AccessPackageCatalogClient{
-		BaseClient: NewClient(Version10),
+		BaseClient: NewClient(Version10, opts...).withFeature(FeatureEntitlementManagement),
 	}
 }
 
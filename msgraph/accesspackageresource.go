@@ -10,18 +10,24 @@ import (
 	"github.com/hashicorp/go-azure-sdk/sdk/odata"
 )
 
+// AccessPackageResourceClient performs operations on AccessPackageResources.
+// These operations are only available in the beta API.
 type AccessPackageResourceClient struct {
 	BaseClient Client
 }
 
-func NewAccessPackageResourceClient() *AccessPackageResourceClient {
+func NewAccessPackageResourceClient(opts ...ClientOption) *AccessPackageResourceClient {
 	return &AccessPackageResourceClient{
-		BaseClient: NewClient(VersionBeta),
+		BaseClient: NewClient(VersionBeta, opts...).withFeature(FeatureEntitlementManagement),
 	}
 }
 
 // List retrieves a list of AccessPackageResources for the specified catalog
 func (c *AccessPackageResourceClient) List(ctx context.Context, catalogId string, query odata.Query) (*[]AccessPackageResource, int, error) {
+	if err := c.BaseClient.requireBeta("AccessPackageResourceClient.List()"); err != nil {
+		return nil, 0, err
+	}
+
 	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
 		DisablePaging:    query.Top > 0,
 		OData:            query,
@@ -53,6 +59,10 @@ func (c *AccessPackageResourceClient) List(ctx context.Context, catalogId string
 // Get retrieves an AccessPackageResource for the specified catalog
 // This uses OData Filter as there is no native Get method
 func (c *AccessPackageResourceClient) Get(ctx context.Context, catalogId string, originId string) (*AccessPackageResource, int, error) {
+	if err := c.BaseClient.requireBeta("AccessPackageResourceClient.Get()"); err != nil {
+		return nil, 0, err
+	}
+
 	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
 		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
 		OData: odata.Query{
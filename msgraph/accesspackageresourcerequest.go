@@ -12,18 +12,24 @@ import (
 	"github.com/hashicorp/go-azure-sdk/sdk/odata"
 )
 
+// AccessPackageResourceRequestClient performs operations on AccessPackageResourceRequests.
+// These operations are only available in the beta API.
 type AccessPackageResourceRequestClient struct {
 	BaseClient Client
 }
 
-func NewAccessPackageResourceRequestClient() *AccessPackageResourceRequestClient {
+func NewAccessPackageResourceRequestClient(opts ...ClientOption) *AccessPackageResourceRequestClient {
 	return &AccessPackageResourceRequestClient{
-		BaseClient: NewClient(VersionBeta),
+		BaseClient: NewClient(VersionBeta, opts...).withFeature(FeatureEntitlementManagement),
 	}
 }
 
 // List returns a list of AccessPackageResourceRequest
 func (c *AccessPackageResourceRequestClient) List(ctx context.Context, query odata.Query) (*[]AccessPackageResourceRequest, int, error) {
+	if err := c.BaseClient.requireBeta("AccessPackageResourceRequestClient.List()"); err != nil {
+		return nil, 0, err
+	}
+
 	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
 		DisablePaging:    query.Top > 0,
 		ValidStatusCodes: []int{http.StatusOK},
@@ -54,6 +60,10 @@ func (c *AccessPackageResourceRequestClient) List(ctx context.Context, query oda
 
 // Create creates a new AccessPackageResourceRequest.
 func (c *AccessPackageResourceRequestClient) Create(ctx context.Context, accessPackageResourceRequest AccessPackageResourceRequest, pollForId bool) (*AccessPackageResourceRequest, int, error) {
+	if err := c.BaseClient.requireBeta("AccessPackageResourceRequestClient.Create()"); err != nil {
+		return nil, 0, err
+	}
+
 	// We are always going to assume a user wants to execute this immediately as having a wait on this makes no sense programmatically
 	accessPackageResourceRequest.ExecuteImmediately = utils.BoolPtr(true)
 
@@ -149,6 +159,10 @@ func (c *AccessPackageResourceRequestClient) Create(ctx context.Context, accessP
 // Get retrieves an AccessPackageResourceRequest
 // This uses OData Filter as there is no native Get method
 func (c *AccessPackageResourceRequestClient) Get(ctx context.Context, id string) (*AccessPackageResourceRequest, int, error) {
+	if err := c.BaseClient.requireBeta("AccessPackageResourceRequestClient.Get()"); err != nil {
+		return nil, 0, err
+	}
+
 	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
 		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
 		ValidStatusCodes:       []int{http.StatusOK},
@@ -183,6 +197,10 @@ func (c *AccessPackageResourceRequestClient) Get(ctx context.Context, id string)
 // See tests for example usage.
 // Docs: https://docs.microsoft.com/en-us/graph/api/accesspackageresourcerequest-post?view=graph-rest-beta#example-5-create-an-accesspackageresourcerequest-for-removing-a-resource
 func (c *AccessPackageResourceRequestClient) Delete(ctx context.Context, accessPackageResourceRequest AccessPackageResourceRequest) (int, error) {
+	if err := c.BaseClient.requireBeta("AccessPackageResourceRequestClient.Delete()"); err != nil {
+		return 0, err
+	}
+
 	var status int
 
 	// Deletion request based off the initial resource request
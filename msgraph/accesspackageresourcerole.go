@@ -10,19 +10,25 @@ import (
 	"github.com/hashicorp/go-azure-sdk/sdk/odata"
 )
 
+// AccessPackageResourceRoleClient performs operations on AccessPackageResourceRoles.
+// These operations are only available in the beta API.
 type AccessPackageResourceRoleClient struct {
 	BaseClient Client
 }
 
-func NewAccessPackageResourceRoleClient() *AccessPackageResourceRoleClient {
+func NewAccessPackageResourceRoleClient(opts ...ClientOption) *AccessPackageResourceRoleClient {
 	return &AccessPackageResourceRoleClient{
-		BaseClient: NewClient(VersionBeta),
+		BaseClient: NewClient(VersionBeta, opts...).withFeature(FeatureEntitlementManagement),
 	}
 }
 
 // List retrieves a list of AccessPackageResourceRoles for a specific accessPackageResource for a particular catalog / originSystem
 // This method requires us to use an Odata Filter / Expand to function correctly
 func (c *AccessPackageResourceRoleClient) List(ctx context.Context, catalogId string, originSystem AccessPackageResourceOriginSystem, accessPackageResourceId string) (*[]AccessPackageResourceRole, int, error) {
+	if err := c.BaseClient.requireBeta("AccessPackageResourceRoleClient.List()"); err != nil {
+		return nil, 0, err
+	}
+
 	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
 		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
 		OData: odata.Query{
@@ -13,18 +13,24 @@ import (
 	"github.com/manicminer/hamilton/internal/utils"
 )
 
+// AccessPackageResourceRoleScopeClient performs operations on AccessPackageResourceRoleScopes.
+// These operations are only available in the beta API.
 type AccessPackageResourceRoleScopeClient struct {
 	BaseClient Client
 }
 
-func NewAccessPackageResourceRoleScopeClient() *AccessPackageResourceRoleScopeClient {
+func NewAccessPackageResourceRoleScopeClient(opts ...ClientOption) *AccessPackageResourceRoleScopeClient {
 	return &AccessPackageResourceRoleScopeClient{
-		BaseClient: NewClient(VersionBeta),
+		BaseClient: NewClient(VersionBeta, opts...).withFeature(FeatureEntitlementManagement),
 	}
 }
 
 // List returns a list of AccessPackageResourceRoleScope(s)
 func (c *AccessPackageResourceRoleScopeClient) List(ctx context.Context, query odata.Query, accessPackageId string) (*[]AccessPackageResourceRoleScope, int, error) {
+	if err := c.BaseClient.requireBeta("AccessPackageResourceRoleScopeClient.List()"); err != nil {
+		return nil, 0, err
+	}
+
 	query.Expand = odata.Expand{
 		Relationship: "accessPackageResourceRoleScopes",
 		Select:       []string{"accessPackageResourceRole", "accessPackageResourceScope"},
@@ -59,6 +65,10 @@ func (c *AccessPackageResourceRoleScopeClient) List(ctx context.Context, query o
 
 // Create creates a new AccessPackageResourceRoleScope.
 func (c *AccessPackageResourceRoleScopeClient) Create(ctx context.Context, accessPackageResourceRoleScope AccessPackageResourceRoleScope) (*AccessPackageResourceRoleScope, int, error) {
+	if err := c.BaseClient.requireBeta("AccessPackageResourceRoleScopeClient.Create()"); err != nil {
+		return nil, 0, err
+	}
+
 	var status int
 
 	if accessPackageResourceRoleScope.AccessPackageId == nil {
@@ -108,6 +118,10 @@ func (c *AccessPackageResourceRoleScopeClient) Create(ctx context.Context, acces
 
 // Get retrieves a AccessPackageResourceRoleScope.
 func (c *AccessPackageResourceRoleScopeClient) Get(ctx context.Context, accessPackageId string, id string) (*AccessPackageResourceRoleScope, int, error) {
+	if err := c.BaseClient.requireBeta("AccessPackageResourceRoleScopeClient.Get()"); err != nil {
+		return nil, 0, err
+	}
+
 	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
 		OData: odata.Query{
 			Expand: odata.Expand{
@@ -156,6 +170,10 @@ func (c *AccessPackageResourceRoleScopeClient) Get(ctx context.Context, accessPa
 
 // Delete removes a AccessPackageResourceRoleScope.
 func (c *AccessPackageResourceRoleScopeClient) Delete(ctx context.Context, accessPackageId string, id string) (int, error) {
+	if err := c.BaseClient.requireBeta("AccessPackageResourceRoleScopeClient.Delete()"); err != nil {
+		return 0, err
+	}
+
 	_, status, _, err := c.BaseClient.Delete(ctx, DeleteHttpRequestInput{
 		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
 		ValidStatusCodes:       []int{http.StatusOK},
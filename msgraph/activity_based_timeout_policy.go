@@ -0,0 +1,203 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+)
+
+// ActivityBasedTimeoutPolicyClient performs operations on ActivityBasedTimeoutPolicies. These policies name the
+// applications they apply to in their definition, so unlike other policies they are not assigned to applications or
+// service principals.
+type ActivityBasedTimeoutPolicyClient struct {
+	BaseClient Client
+}
+
+// NewActivityBasedTimeoutPolicyClient returns a new ActivityBasedTimeoutPolicyClient
+func NewActivityBasedTimeoutPolicyClient(opts ...ClientOption) *ActivityBasedTimeoutPolicyClient {
+	return &ActivityBasedTimeoutPolicyClient{
+		BaseClient: NewClient(Version10, opts...),
+	}
+}
+
+// Create creates a new ActivityBasedTimeoutPolicy.
+func (c *ActivityBasedTimeoutPolicyClient) Create(ctx context.Context, policy ActivityBasedTimeoutPolicy) (*ActivityBasedTimeoutPolicy, int, error) {
+	var status int
+
+	body, err := json.Marshal(policy)
+	if err != nil {
+		return nil, status, fmt.Errorf("json.Marshal(): %v", err)
+	}
+
+	resp, status, _, err := c.BaseClient.Post(ctx, PostHttpRequestInput{
+		Body:             body,
+		OData:            odata.Query{Metadata: odata.MetadataFull},
+		ValidStatusCodes: []int{http.StatusCreated},
+		Uri: Uri{
+			Entity: "/policies/activityBasedTimeoutPolicies",
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("ActivityBasedTimeoutPolicyClient.BaseClient.Post(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var newPolicy ActivityBasedTimeoutPolicy
+	if err := json.Unmarshal(respBody, &newPolicy); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &newPolicy, status, nil
+}
+
+// List returns a list of ActivityBasedTimeoutPolicy, optionally queried using OData.
+func (c *ActivityBasedTimeoutPolicyClient) List(ctx context.Context, query odata.Query) (*[]ActivityBasedTimeoutPolicy, int, error) {
+	query.Metadata = odata.MetadataFull
+
+	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+		DisablePaging:    query.Top > 0,
+		OData:            query,
+		ValidStatusCodes: []int{http.StatusOK},
+		Uri: Uri{
+			Entity: "/policies/activityBasedTimeoutPolicies",
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("ActivityBasedTimeoutPolicyClient.BaseClient.Get(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var data struct {
+		ActivityBasedTimeoutPolicies []ActivityBasedTimeoutPolicy `json:"value"`
+	}
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &data.ActivityBasedTimeoutPolicies, status, nil
+}
+
+// Get retrieves a ActivityBasedTimeoutPolicy.
+func (c *ActivityBasedTimeoutPolicyClient) Get(ctx context.Context, id string, query odata.Query) (*ActivityBasedTimeoutPolicy, int, error) {
+	query.Metadata = odata.MetadataFull
+
+	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		OData:                  query,
+		ValidStatusCodes:       []int{http.StatusOK},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/policies/activityBasedTimeoutPolicies/%s", id),
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("ActivityBasedTimeoutPolicyClient.BaseClient.Get(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var activityBasedTimeoutPolicy ActivityBasedTimeoutPolicy
+	if err := json.Unmarshal(respBody, &activityBasedTimeoutPolicy); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &activityBasedTimeoutPolicy, status, nil
+}
+
+// Update amends an existing ActivityBasedTimeoutPolicy.
+func (c *ActivityBasedTimeoutPolicyClient) Update(ctx context.Context, activityBasedTimeoutPolicy ActivityBasedTimeoutPolicy) (int, error) {
+	var status int
+
+	if activityBasedTimeoutPolicy.ID() == nil {
+		return status, fmt.Errorf("cannot update ActivityBasedTimeoutPolicy with nil ID")
+	}
+
+	activityBasedTimeoutPolicyId := *activityBasedTimeoutPolicy.ID()
+	activityBasedTimeoutPolicy.Id = nil
+	activityBasedTimeoutPolicy.ObjectId = nil
+
+	body, err := json.Marshal(activityBasedTimeoutPolicy)
+	if err != nil {
+		return status, fmt.Errorf("json.Marshal(): %v", err)
+	}
+
+	_, status, _, err = c.BaseClient.Patch(ctx, PatchHttpRequestInput{
+		Body:                   body,
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes: []int{
+			http.StatusOK,
+			http.StatusNoContent,
+		},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/policies/activityBasedTimeoutPolicies/%s", activityBasedTimeoutPolicyId),
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("ActivityBasedTimeoutPolicyClient.BaseClient.Patch(): %v", err)
+	}
+
+	return status, nil
+}
+
+// Delete removes a ActivityBasedTimeoutPolicy.
+func (c *ActivityBasedTimeoutPolicyClient) Delete(ctx context.Context, id string) (int, error) {
+	_, status, _, err := c.BaseClient.Delete(ctx, DeleteHttpRequestInput{
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusNoContent},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/policies/activityBasedTimeoutPolicies/%s", id),
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("ActivityBasedTimeoutPolicyClient.BaseClient.Delete(): %v", err)
+	}
+
+	return status, nil
+}
+
+// ListAppliesTo returns the directory objects, such as Applications and Service Principals, that a ActivityBasedTimeoutPolicy is
+// assigned to.
+func (c *ActivityBasedTimeoutPolicyClient) ListAppliesTo(ctx context.Context, id string) (*[]DirectoryObject, int, error) {
+	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusOK},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/policies/activityBasedTimeoutPolicies/%s/appliesTo", id),
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("ActivityBasedTimeoutPolicyClient.BaseClient.Get(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var data struct {
+		DirectoryObjects []DirectoryObject `json:"value"`
+	}
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &data.DirectoryObjects, status, nil
+}
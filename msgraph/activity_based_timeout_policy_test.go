@@ -0,0 +1,119 @@
+package msgraph_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+	"github.com/manicminer/hamilton/internal/test"
+	"github.com/manicminer/hamilton/internal/utils"
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+func TestActivityBasedTimeoutPolicyClient(t *testing.T) {
+	c := test.NewTest(t)
+	defer c.CancelFunc()
+
+	// a tenant can only have one activity-based timeout policy
+	if policies := testActivityBasedTimeoutPolicyClient_List(t, c); len(*policies) > 0 {
+		t.Skip("an activity-based timeout policy already exists in the test tenant")
+	}
+
+	app := testApplicationsClient_Create(t, c, msgraph.Application{
+		DisplayName: utils.StringPtr(fmt.Sprintf("test-activity-based-timeout-policy-%s", c.RandomString)),
+	})
+
+	definition, err := msgraph.ActivityBasedTimeoutPolicyDefinition{
+		ApplicationPolicies: []msgraph.ActivityBasedTimeoutApplicationPolicy{
+			{ApplicationId: *app.AppId, WebSessionIdleTimeout: 30 * time.Minute},
+		},
+	}.Definition()
+	if err != nil {
+		t.Fatalf("ActivityBasedTimeoutPolicyDefinition.Definition(): %v", err)
+	}
+	policy := testActivityBasedTimeoutPolicyClient_Create(t, c, msgraph.ActivityBasedTimeoutPolicy{
+		DisplayName: utils.StringPtr(fmt.Sprintf("test-activity-based-timeout-policy-%s", c.RandomString)),
+		Definition:  definition,
+	})
+	testActivityBasedTimeoutPolicyClient_List(t, c)
+	testActivityBasedTimeoutPolicyClient_Get(t, c, *policy.ID())
+	policy.DisplayName = utils.StringPtr(fmt.Sprintf("test-activity-based-timeout-policy-updated-%s", c.RandomString))
+	testActivityBasedTimeoutPolicyClient_Update(t, c, *policy)
+	testActivityBasedTimeoutPolicyClient_ListAppliesTo(t, c, *policy.ID())
+	testActivityBasedTimeoutPolicyClient_Delete(t, c, *policy.ID())
+
+	testApplicationsClient_Delete(t, c, *app.ID())
+}
+
+func testActivityBasedTimeoutPolicyClient_Create(t *testing.T, c *test.Test, p msgraph.ActivityBasedTimeoutPolicy) (policy *msgraph.ActivityBasedTimeoutPolicy) {
+	policy, status, err := c.ActivityBasedTimeoutPolicyClient.Create(c.Context, p)
+	if err != nil {
+		t.Fatalf("ActivityBasedTimeoutPolicyClient.Create(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("ActivityBasedTimeoutPolicyClient.Create(): invalid status: %d", status)
+	}
+	if policy == nil {
+		t.Fatal("ActivityBasedTimeoutPolicyClient.Create(): policy was nil")
+	}
+	if policy.ID() == nil {
+		t.Fatal("ActivityBasedTimeoutPolicyClient.Create(): policy.ID was nil")
+	}
+	return
+}
+
+func testActivityBasedTimeoutPolicyClient_List(t *testing.T, c *test.Test) (policies *[]msgraph.ActivityBasedTimeoutPolicy) {
+	policies, _, err := c.ActivityBasedTimeoutPolicyClient.List(c.Context, odata.Query{Top: 10})
+	if err != nil {
+		t.Fatalf("ActivityBasedTimeoutPolicyClient.List(): %v", err)
+	}
+	if policies == nil {
+		t.Fatal("ActivityBasedTimeoutPolicyClient.List(): policies was nil")
+	}
+	return
+}
+
+func testActivityBasedTimeoutPolicyClient_Get(t *testing.T, c *test.Test, id string) (policy *msgraph.ActivityBasedTimeoutPolicy) {
+	policy, status, err := c.ActivityBasedTimeoutPolicyClient.Get(c.Context, id, odata.Query{})
+	if err != nil {
+		t.Fatalf("ActivityBasedTimeoutPolicyClient.Get(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("ActivityBasedTimeoutPolicyClient.Get(): invalid status: %d", status)
+	}
+	if policy == nil {
+		t.Fatal("ActivityBasedTimeoutPolicyClient.Get(): policy was nil")
+	}
+	return
+}
+
+func testActivityBasedTimeoutPolicyClient_Update(t *testing.T, c *test.Test, p msgraph.ActivityBasedTimeoutPolicy) {
+	status, err := c.ActivityBasedTimeoutPolicyClient.Update(c.Context, p)
+	if err != nil {
+		t.Fatalf("ActivityBasedTimeoutPolicyClient.Update(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("ActivityBasedTimeoutPolicyClient.Update(): invalid status: %d", status)
+	}
+}
+
+func testActivityBasedTimeoutPolicyClient_ListAppliesTo(t *testing.T, c *test.Test, id string) {
+	objects, _, err := c.ActivityBasedTimeoutPolicyClient.ListAppliesTo(c.Context, id)
+	if err != nil {
+		t.Fatalf("ActivityBasedTimeoutPolicyClient.ListAppliesTo(): %v", err)
+	}
+	if objects == nil {
+		t.Fatal("ActivityBasedTimeoutPolicyClient.ListAppliesTo(): objects was nil")
+	}
+}
+
+func testActivityBasedTimeoutPolicyClient_Delete(t *testing.T, c *test.Test, id string) {
+	status, err := c.ActivityBasedTimeoutPolicyClient.Delete(c.Context, id)
+	if err != nil {
+		t.Fatalf("ActivityBasedTimeoutPolicyClient.Delete(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("ActivityBasedTimeoutPolicyClient.Delete(): invalid status: %d", status)
+	}
+}
@@ -0,0 +1,255 @@
+package msgraph
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+)
+
+// defaultAppRoleId is the ID used for app role assignments made without a specific app role, which are not permissions.
+const defaultAppRoleId = "00000000-0000-0000-0000-000000000000"
+
+// AdminConsentOptions configures GrantAdminConsent.
+type AdminConsentOptions struct {
+	// RevokeUnrequired removes delegated permission scopes and app role assignments that were previously granted to
+	// the application but are no longer declared in its RequiredResourceAccess. When false, existing grants are only
+	// ever added to.
+	RevokeUnrequired bool
+}
+
+// GrantAdminConsent grants tenant-wide admin consent for the permissions declared in the RequiredResourceAccess of
+// the application with the specified application ID (not object ID). The service principal for the application is
+// created if it does not yet exist. Delegated permissions are granted with an AllPrincipals DelegatedPermissionGrant
+// for each resource, merging with any existing grant, and application permissions are granted by assigning the app
+// role to the service principal. Grants that already exist are left unchanged, so it is safe to call repeatedly.
+//
+// Each change is attempted and recorded in the returned report, and an error is returned if any of them failed.
+func (s *ServiceClient) GrantAdminConsent(ctx context.Context, appId string, options AdminConsentOptions) (*AdminConsentReport, error) {
+	applications := s.Applications()
+	servicePrincipals := s.ServicePrincipals()
+	grants := s.DelegatedPermissionGrants()
+	appRoleAssignments := s.ServicePrincipalsAppRoleAssignments()
+
+	apps, _, err := applications.List(ctx, odata.Query{
+		Filter: fmt.Sprintf("appId eq '%s'", appId),
+		Select: []string{"id", "appId", "displayName", "requiredResourceAccess"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("retrieving application: %v", err)
+	}
+	if apps == nil || len(*apps) == 0 || (*apps)[0].ID() == nil {
+		return nil, fmt.Errorf("no application found with appId %q", appId)
+	}
+	app := (*apps)[0]
+
+	report := AdminConsentReport{
+		AppId:         appId,
+		ApplicationId: *app.ID(),
+		Changes:       make([]AdminConsentChange, 0),
+	}
+
+	sps, _, err := servicePrincipals.List(ctx, odata.Query{
+		Filter: fmt.Sprintf("appId eq '%s'", appId),
+		Select: []string{"id", "appId"},
+	})
+	if err != nil {
+		return &report, fmt.Errorf("retrieving service principal: %v", err)
+	}
+	if sps != nil && len(*sps) > 0 && (*sps)[0].ID() != nil {
+		report.ServicePrincipalId = *(*sps)[0].ID()
+	} else {
+		sp, _, err := servicePrincipals.Create(ctx, ServicePrincipal{AppId: &appId})
+		if err != nil {
+			report.Changes = append(report.Changes, AdminConsentChange{Action: AdminConsentActionCreateServicePrincipal, Err: err})
+			return &report, fmt.Errorf("creating service principal: %v", err)
+		}
+		report.ServicePrincipalId = *sp.ID()
+		report.Changes = append(report.Changes, AdminConsentChange{Action: AdminConsentActionCreateServicePrincipal, ResourceId: report.ServicePrincipalId})
+	}
+
+	// determine the required scopes and app roles for each resource service principal
+	var required []Permission
+	if app.RequiredResourceAccess != nil {
+		required, err = NewPermissionResolver(servicePrincipals).ResolveRequiredResourceAccess(ctx, *app.RequiredResourceAccess)
+		if err != nil {
+			return &report, fmt.Errorf("resolving required permissions: %v", err)
+		}
+	}
+	requiredScopes := make(map[string]map[string]bool)
+	requiredRoles := make(map[string]bool)
+	resourceNames := make(map[string]string)
+	for _, permission := range required {
+		resourceNames[permission.ResourceId] = permission.ResourceDisplayName
+		switch permission.Type {
+		case ResourceAccessTypeScope:
+			if requiredScopes[permission.ResourceId] == nil {
+				requiredScopes[permission.ResourceId] = make(map[string]bool)
+			}
+			requiredScopes[permission.ResourceId][permission.Value] = true
+		case ResourceAccessTypeRole:
+			requiredRoles[permission.ResourceId+"/"+strings.ToLower(permission.Id)] = true
+		}
+	}
+
+	// reconcile delegated permission grants
+	existingGrants, _, err := grants.List(ctx, odata.Query{
+		Filter: fmt.Sprintf("clientId eq '%s' and consentType eq '%s'", report.ServicePrincipalId, DelegatedPermissionGrantConsentTypeAllPrincipals),
+	})
+	if err != nil {
+		return &report, fmt.Errorf("listing delegated permission grants: %v", err)
+	}
+	granted := make(map[string]bool)
+	if existingGrants != nil {
+		for _, grant := range *existingGrants {
+			if grant.Id == nil || grant.ResourceId == nil {
+				continue
+			}
+			resourceId := *grant.ResourceId
+			granted[resourceId] = true
+
+			scopes := make(map[string]bool)
+			if grant.Scopes != nil {
+				for _, scope := range *grant.Scopes {
+					if scope != "" {
+						scopes[scope] = true
+					}
+				}
+			}
+			desired := make(map[string]bool)
+			if !options.RevokeUnrequired {
+				for scope := range scopes {
+					desired[scope] = true
+				}
+			}
+			for scope := range requiredScopes[resourceId] {
+				desired[scope] = true
+			}
+			if equalScopes(scopes, desired) {
+				continue
+			}
+
+			change := AdminConsentChange{
+				ResourceId:          resourceId,
+				ResourceDisplayName: resourceNames[resourceId],
+				Scopes:              sortedScopes(desired),
+			}
+			if len(desired) == 0 {
+				change.Action = AdminConsentActionDeleteDelegatedPermissionGrant
+				_, change.Err = grants.Delete(ctx, *grant.Id)
+			} else {
+				change.Action = AdminConsentActionUpdateDelegatedPermissionGrant
+				_, change.Err = grants.Update(ctx, DelegatedPermissionGrant{
+					Id:     grant.Id,
+					Scopes: &change.Scopes,
+				})
+			}
+			report.Changes = append(report.Changes, change)
+		}
+	}
+
+	resourceIds := make([]string, 0, len(requiredScopes))
+	for resourceId := range requiredScopes {
+		if !granted[resourceId] {
+			resourceIds = append(resourceIds, resourceId)
+		}
+	}
+	sort.Strings(resourceIds)
+	for _, resourceId := range resourceIds {
+		change := AdminConsentChange{
+			Action:              AdminConsentActionCreateDelegatedPermissionGrant,
+			ResourceId:          resourceId,
+			ResourceDisplayName: resourceNames[resourceId],
+			Scopes:              sortedScopes(requiredScopes[resourceId]),
+		}
+		resourceId := resourceId
+		consentType := DelegatedPermissionGrantConsentTypeAllPrincipals
+		_, _, change.Err = grants.Create(ctx, DelegatedPermissionGrant{
+			ClientId:    &report.ServicePrincipalId,
+			ConsentType: &consentType,
+			ResourceId:  &resourceId,
+			Scopes:      &change.Scopes,
+		})
+		report.Changes = append(report.Changes, change)
+	}
+
+	// reconcile app role assignments
+	existingAssignments, _, err := appRoleAssignments.List(ctx, report.ServicePrincipalId, odata.Query{})
+	if err != nil {
+		return &report, fmt.Errorf("listing app role assignments: %v", err)
+	}
+	assigned := make(map[string]bool)
+	if existingAssignments != nil {
+		for _, assignment := range *existingAssignments {
+			if assignment.ResourceId == nil || assignment.AppRoleId == nil || *assignment.AppRoleId == defaultAppRoleId {
+				continue
+			}
+			key := *assignment.ResourceId + "/" + strings.ToLower(*assignment.AppRoleId)
+			assigned[key] = true
+			if requiredRoles[key] || !options.RevokeUnrequired || assignment.Id == nil {
+				continue
+			}
+
+			change := AdminConsentChange{
+				Action:              AdminConsentActionRemoveAppRoleAssignment,
+				ResourceId:          *assignment.ResourceId,
+				ResourceDisplayName: resourceNames[*assignment.ResourceId],
+				AppRoleId:           *assignment.AppRoleId,
+			}
+			if assignment.ResourceDisplayName != nil {
+				change.ResourceDisplayName = *assignment.ResourceDisplayName
+			}
+			_, change.Err = servicePrincipals.RemoveAppRoleAssignment(ctx, *assignment.ResourceId, *assignment.Id)
+			report.Changes = append(report.Changes, change)
+		}
+	}
+
+	for _, permission := range required {
+		key := permission.ResourceId + "/" + strings.ToLower(permission.Id)
+		if permission.Type != ResourceAccessTypeRole || assigned[key] {
+			continue
+		}
+		assigned[key] = true
+
+		change := AdminConsentChange{
+			Action:              AdminConsentActionAssignAppRole,
+			ResourceId:          permission.ResourceId,
+			ResourceDisplayName: permission.ResourceDisplayName,
+			AppRoleId:           permission.Id,
+			AppRoleValue:        permission.Value,
+		}
+		_, _, change.Err = servicePrincipals.AssignAppRoleForResource(ctx, report.ServicePrincipalId, permission.ResourceId, permission.Id)
+		report.Changes = append(report.Changes, change)
+	}
+
+	if failed := report.Failed(); len(failed) > 0 {
+		return &report, fmt.Errorf("%d of %d admin consent changes could not be applied: %v", len(failed), len(report.Changes), failed[0].Err)
+	}
+
+	return &report, nil
+}
+
+// equalScopes reports whether two sets of scopes contain the same values.
+func equalScopes(a, b map[string]bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for scope := range a {
+		if !b[scope] {
+			return false
+		}
+	}
+	return true
+}
+
+// sortedScopes returns the values in a set of scopes in sorted order.
+func sortedScopes(scopes map[string]bool) []string {
+	result := make([]string, 0, len(scopes))
+	for scope := range scopes {
+		result = append(result, scope)
+	}
+	sort.Strings(result)
+	return result
+}
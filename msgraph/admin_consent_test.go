@@ -0,0 +1,185 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type testConsentTenant struct {
+	sync.Mutex
+	servicePrincipalCreated bool
+	grants                  map[string][]string
+	assignments             map[string]string
+	nextId                  int
+}
+
+func (tenant *testConsentTenant) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	tenant.Lock()
+	defer tenant.Unlock()
+
+	respond := func(status int, body string) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}
+	body, _ := io.ReadAll(r.Body)
+	filter := r.URL.Query().Get("$filter")
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/beta/applications":
+		respond(http.StatusOK, `{"value":[{"id":"client-app-id","appId":"client-app","requiredResourceAccess":[{
+			"resourceAppId":"00000003-0000-0000-c000-000000000000",
+			"resourceAccess":[
+				{"id":"e1fe6dd8-ba31-4d61-89e7-88639da4683d","type":"Scope"},
+				{"id":"df021288-bdef-4463-88db-98f22de89214","type":"Role"}
+			]
+		}]}]}`)
+
+	case r.Method == http.MethodGet && r.URL.Path == "/beta/servicePrincipals" && filter == "appId eq 'client-app'":
+		if tenant.servicePrincipalCreated {
+			respond(http.StatusOK, `{"value":[{"id":"client-sp","appId":"client-app"}]}`)
+		} else {
+			respond(http.StatusOK, `{"value":[]}`)
+		}
+
+	case r.Method == http.MethodGet && r.URL.Path == "/beta/servicePrincipals":
+		respond(http.StatusOK, `{"value":[`+testGraphServicePrincipal+`]}`)
+
+	case r.Method == http.MethodPost && r.URL.Path == "/beta/servicePrincipals":
+		tenant.servicePrincipalCreated = true
+		respond(http.StatusCreated, `{"id":"client-sp","appId":"client-app"}`)
+
+	case r.Method == http.MethodGet && r.URL.Path == "/v1.0/oauth2PermissionGrants":
+		var grants []string
+		for id, scopes := range tenant.grants {
+			grants = append(grants, fmt.Sprintf(`{"id":%q,"clientId":"client-sp","consentType":"AllPrincipals","resourceId":"graph-sp-id","scope":%q}`, id, strings.Join(scopes, " ")))
+		}
+		respond(http.StatusOK, `{"value":[`+strings.Join(grants, ",")+`]}`)
+
+	case r.Method == http.MethodPost && r.URL.Path == "/v1.0/oauth2PermissionGrants":
+		var grant DelegatedPermissionGrant
+		json.Unmarshal(body, &grant)
+		tenant.nextId++
+		id := fmt.Sprintf("grant-%d", tenant.nextId)
+		tenant.grants[id] = *grant.Scopes
+		respond(http.StatusCreated, fmt.Sprintf(`{"id":%q}`, id))
+
+	case r.Method == http.MethodPatch && strings.HasPrefix(r.URL.Path, "/v1.0/oauth2PermissionGrants/"):
+		var grant DelegatedPermissionGrant
+		json.Unmarshal(body, &grant)
+		tenant.grants[strings.TrimPrefix(r.URL.Path, "/v1.0/oauth2PermissionGrants/")] = *grant.Scopes
+		w.WriteHeader(http.StatusNoContent)
+
+	case r.Method == http.MethodGet && r.URL.Path == "/v1.0/servicePrincipals/client-sp/appRoleAssignments":
+		var assignments []string
+		for id, appRoleId := range tenant.assignments {
+			assignments = append(assignments, fmt.Sprintf(`{"id":%q,"appRoleId":%q,"principalId":"client-sp","resourceId":"graph-sp-id"}`, id, appRoleId))
+		}
+		respond(http.StatusOK, `{"value":[`+strings.Join(assignments, ",")+`]}`)
+
+	case r.Method == http.MethodPost && r.URL.Path == "/beta/servicePrincipals/graph-sp-id/appRoleAssignedTo":
+		var assignment AppRoleAssignment
+		json.Unmarshal(body, &assignment)
+		tenant.nextId++
+		id := fmt.Sprintf("assignment-%d", tenant.nextId)
+		tenant.assignments[id] = *assignment.AppRoleId
+		respond(http.StatusCreated, fmt.Sprintf(`{"id":%q}`, id))
+
+	case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/beta/servicePrincipals/graph-sp-id/appRoleAssignedTo/"):
+		delete(tenant.assignments, strings.TrimPrefix(r.URL.Path, "/beta/servicePrincipals/graph-sp-id/appRoleAssignedTo/"))
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (tenant *testConsentTenant) state() string {
+	tenant.Lock()
+	defer tenant.Unlock()
+	var state []string
+	for _, scopes := range tenant.grants {
+		state = append(state, "scopes:"+strings.Join(scopes, " "))
+	}
+	for _, appRoleId := range tenant.assignments {
+		state = append(state, "role:"+appRoleId)
+	}
+	sort.Strings(state)
+	return strings.Join(state, ", ")
+}
+
+func TestServiceClient_GrantAdminConsent(t *testing.T) {
+	tenant := &testConsentTenant{
+		grants: map[string][]string{"existing-grant": {"openid"}},
+		assignments: map[string]string{
+			"existing-assignment": "19dbc75e-c2e2-444c-a770-ec69d8559fc7",
+			"default-assignment":  defaultAppRoleId,
+		},
+	}
+	ts := httptest.NewServer(tenant)
+	defer ts.Close()
+
+	svc := NewServiceClient(WithEndpoint(ts.URL), WithRetryMax(0))
+	actions := func(report *AdminConsentReport) string {
+		var actions []string
+		for _, change := range report.Changes {
+			actions = append(actions, change.Action)
+		}
+		return strings.Join(actions, ",")
+	}
+
+	// the service principal is created, and existing grants are merged with the required permissions
+	report, err := svc.GrantAdminConsent(context.Background(), "client-app", AdminConsentOptions{})
+	if err != nil {
+		t.Fatalf("GrantAdminConsent(): %v", err)
+	}
+	if expected := "CreateServicePrincipal,UpdateDelegatedPermissionGrant,AssignAppRole"; actions(report) != expected {
+		t.Fatalf("expected actions %s, got %s", expected, actions(report))
+	}
+	if report.ServicePrincipalId != "client-sp" || report.ApplicationId != "client-app-id" {
+		t.Errorf("unexpected report: %+v", report)
+	}
+	expected := "role:00000000-0000-0000-0000-000000000000, role:19dbc75e-c2e2-444c-a770-ec69d8559fc7, role:df021288-bdef-4463-88db-98f22de89214, scopes:User.Read openid"
+	if state := tenant.state(); state != expected {
+		t.Fatalf("expected state %q, got %q", expected, state)
+	}
+
+	// granting again makes no changes
+	if report, err = svc.GrantAdminConsent(context.Background(), "client-app", AdminConsentOptions{}); err != nil {
+		t.Fatalf("GrantAdminConsent(): %v", err)
+	}
+	if len(report.Changes) != 0 {
+		t.Fatalf("expected no changes, got %s", actions(report))
+	}
+
+	// permissions that are no longer required are revoked
+	if report, err = svc.GrantAdminConsent(context.Background(), "client-app", AdminConsentOptions{RevokeUnrequired: true}); err != nil {
+		t.Fatalf("GrantAdminConsent(): %v", err)
+	}
+	if expected := "UpdateDelegatedPermissionGrant,RemoveAppRoleAssignment"; actions(report) != expected {
+		t.Fatalf("expected actions %s, got %s", expected, actions(report))
+	}
+	expected = "role:00000000-0000-0000-0000-000000000000, role:df021288-bdef-4463-88db-98f22de89214, scopes:User.Read"
+	if state := tenant.state(); state != expected {
+		t.Fatalf("expected state %q, got %q", expected, state)
+	}
+
+	// a new grant is created when none exists for the resource
+	tenant.Lock()
+	tenant.grants = map[string][]string{}
+	tenant.Unlock()
+	if report, err = svc.GrantAdminConsent(context.Background(), "client-app", AdminConsentOptions{RevokeUnrequired: true}); err != nil {
+		t.Fatalf("GrantAdminConsent(): %v", err)
+	}
+	if expected := "CreateDelegatedPermissionGrant"; actions(report) != expected {
+		t.Fatalf("expected actions %s, got %s", expected, actions(report))
+	}
+}
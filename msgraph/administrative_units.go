@@ -16,9 +16,9 @@ type AdministrativeUnitsClient struct {
 }
 
 // NewAdministrativeUnitsClient returns a new AdministrativeUnitsClient.
-func NewAdministrativeUnitsClient() *AdministrativeUnitsClient {
+func NewAdministrativeUnitsClient(opts ...ClientOption) *AdministrativeUnitsClient {
 	return &AdministrativeUnitsClient{
-		BaseClient: NewClient(VersionBeta),
+		BaseClient: NewClient(VersionBeta, opts...),
 	}
 }
 
@@ -236,6 +236,9 @@ func (c *AdministrativeUnitsClient) GetMember(ctx context.Context, administrativ
 
 func (c *AdministrativeUnitsClient) CreateGroup(ctx context.Context, administrativeUnitId string, group *Group) (*Group, int, error) {
 	var status int
+	if err := c.BaseClient.validateFields("AdministrativeUnitsClient.CreateGroup()", group); err != nil {
+		return nil, status, err
+	}
 	odataTypeGroup := odata.TypeGroup
 	group.ODataType = &odataTypeGroup
 	body, err := json.Marshal(group)
@@ -269,42 +272,13 @@ func (c *AdministrativeUnitsClient) CreateGroup(ctx context.Context, administrat
 }
 
 // AddMembers adds new members to a AdministrativeUnit.
-func (c *AdministrativeUnitsClient) AddMembers(ctx context.Context, administrativeUnitId string, members *Members) (int, error) {
-	var status int
-
+// Members are added in batches of up to 20 per request, and the outcome for each member is returned.
+func (c *AdministrativeUnitsClient) AddMembers(ctx context.Context, administrativeUnitId string, members *Members) (*[]MemberResult, int, error) {
 	if members == nil || len(*members) == 0 {
-		return status, fmt.Errorf("no members specified")
-	}
-
-	for _, member := range *members {
-		// don't fail if a member already exists
-		checkMemberAlreadyExists := func(resp *http.Response, o *odata.OData) bool {
-			if resp != nil && resp.StatusCode == http.StatusBadRequest && o != nil && o.Error != nil {
-				return o.Error.Match(odata.ErrorAddedObjectReferencesAlreadyExist)
-			}
-			return false
-		}
-
-		body, err := json.Marshal(DirectoryObject{ODataId: member.ODataId})
-		if err != nil {
-			return status, fmt.Errorf("json.Marshal(): %v", err)
-		}
-
-		_, status, _, err = c.BaseClient.Post(ctx, PostHttpRequestInput{
-			Body:                   body,
-			ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
-			ValidStatusCodes:       []int{http.StatusNoContent},
-			ValidStatusFunc:        checkMemberAlreadyExists,
-			Uri: Uri{
-				Entity: fmt.Sprintf("/administrativeUnits/%s/members/$ref", administrativeUnitId),
-			},
-		})
-		if err != nil {
-			return status, fmt.Errorf("AdministrativeUnitsClient.BaseClient.Post(): %v", err)
-		}
+		return nil, 0, fmt.Errorf("no members specified")
 	}
 
-	return status, nil
+	return c.BaseClient.addMembers(ctx, "AdministrativeUnitsClient", fmt.Sprintf("/administrativeUnits/%s", administrativeUnitId), *members)
 }
 
 // RemoveMembers removes members from a AdministrativeUnit.
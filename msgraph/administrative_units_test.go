@@ -172,7 +172,7 @@ func testAdministrativeUnitsClient_GetMember(t *testing.T, c *test.Test, adminis
 }
 
 func testAdministrativeUnitsClient_AddMembers(t *testing.T, c *test.Test, administrativeUnitId string, members *msgraph.Members) {
-	status, err := c.AdministrativeUnitsClient.AddMembers(c.Context, administrativeUnitId, members)
+	_, status, err := c.AdministrativeUnitsClient.AddMembers(c.Context, administrativeUnitId, members)
 	if err != nil {
 		t.Fatalf("AdministrativeUnitsClient.AddMembers(): %v", err)
 	}
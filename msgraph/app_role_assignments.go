@@ -26,25 +26,25 @@ type AppRoleAssignmentsClient struct {
 }
 
 // NewUsersAppRoleAssignmentsClient returns a new AppRoleAssignmentsClient for users assignments
-func NewUsersAppRoleAssignmentsClient() *AppRoleAssignmentsClient {
+func NewUsersAppRoleAssignmentsClient(opts ...ClientOption) *AppRoleAssignmentsClient {
 	return &AppRoleAssignmentsClient{
-		BaseClient:   NewClient(Version10),
+		BaseClient:   NewClient(Version10, opts...),
 		resourceType: usersAppRoleAssignmentsResource,
 	}
 }
 
 // NewGroupsAppRoleAssignmentsClient returns a new AppRoleAssignmentsClient for groups assignments
-func NewGroupsAppRoleAssignmentsClient() *AppRoleAssignmentsClient {
+func NewGroupsAppRoleAssignmentsClient(opts ...ClientOption) *AppRoleAssignmentsClient {
 	return &AppRoleAssignmentsClient{
-		BaseClient:   NewClient(Version10),
+		BaseClient:   NewClient(Version10, opts...),
 		resourceType: groupsAppRoleAssignmentsResource,
 	}
 }
 
 // NewServicePrincipalsAppRoleAssignmentsClient returns a new AppRoleAssignmentsClient for service principal assignments
-func NewServicePrincipalsAppRoleAssignmentsClient() *AppRoleAssignmentsClient {
+func NewServicePrincipalsAppRoleAssignmentsClient(opts ...ClientOption) *AppRoleAssignmentsClient {
 	return &AppRoleAssignmentsClient{
-		BaseClient:   NewClient(Version10),
+		BaseClient:   NewClient(Version10, opts...),
 		resourceType: servicePrincipalsAppRoleAssignmentsResource,
 	}
 }
@@ -145,9 +145,9 @@ type AppRoleAssignedToClient struct {
 }
 
 // NewAppRoleAssignedToClient returns a new AppRoleAssignedToClient
-func NewAppRoleAssignedToClient() *AppRoleAssignedToClient {
+func NewAppRoleAssignedToClient(opts ...ClientOption) *AppRoleAssignedToClient {
 	return &AppRoleAssignedToClient{
-		BaseClient: NewClient(Version10),
+		BaseClient: NewClient(Version10, opts...),
 	}
 }
 
@@ -0,0 +1,110 @@
+package msgraph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/manicminer/hamilton/internal/utils"
+)
+
+// ApplicationProxyTemplateId is the ID of the application template used to publish on-premises applications via
+// Application Proxy.
+const ApplicationProxyTemplateId = "8adf8e6e-67b2-4cf2-a259-e3dc5476c621"
+
+// ApplicationProxyOptions configures PublishApplicationProxy.
+type ApplicationProxyOptions struct {
+	// DisplayName is the display name of the new application.
+	DisplayName string
+
+	// InternalUrl is the URL used to reach the application from the connectors on-premises.
+	InternalUrl string
+
+	// ExternalUrl is the URL users access the application with. It must use an application proxy domain, such as
+	// https://myapp-contoso.msappproxy.net/, or a verified custom domain. It is also used as the identifier URI,
+	// home page URL and redirect URI of the application.
+	ExternalUrl string
+
+	// ExternalAuthenticationType defaults to OnPremisesPublishingExternalAuthenticationTypeAadPreAuthentication.
+	ExternalAuthenticationType OnPremisesPublishingExternalAuthenticationType
+
+	// ConnectorGroupId is the ID of the ConnectorGroup to assign the application to. When empty, the application uses
+	// the default connector group.
+	ConnectorGroupId string
+
+	// OnPremisesPublishing optionally holds further settings, such as cookie and translation settings. Its URLs and
+	// external authentication type are overwritten with the values above.
+	OnPremisesPublishing *OnPremisesPublishing
+}
+
+// PublishApplicationProxy publishes an on-premises application via Application Proxy. It instantiates the
+// application proxy template, configures the URLs of the new application, enables on-premises publishing and assigns
+// the application to a connector group.
+//
+// When a step fails after the application was instantiated, the application and service principal are returned
+// along with the error so that the caller can complete the configuration or clean up.
+func (s *ServiceClient) PublishApplicationProxy(ctx context.Context, options ApplicationProxyOptions) (*Application, *ServicePrincipal, error) {
+	if options.DisplayName == "" {
+		return nil, nil, errors.New("DisplayName is required")
+	}
+	if options.InternalUrl == "" || options.ExternalUrl == "" {
+		return nil, nil, errors.New("InternalUrl and ExternalUrl are required")
+	}
+
+	applications := s.Applications()
+
+	template, _, err := s.ApplicationTemplates().Instantiate(ctx, ApplicationTemplate{
+		ID:          utils.StringPtr(ApplicationProxyTemplateId),
+		DisplayName: utils.StringPtr(options.DisplayName),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("instantiating application proxy template: %v", err)
+	}
+	if template.Application == nil || template.Application.ID() == nil {
+		return nil, template.ServicePrincipal, errors.New("instantiating application proxy template: no application was returned")
+	}
+	app := template.Application
+	sp := template.ServicePrincipal
+
+	// the URLs must be configured before on-premises publishing can be enabled
+	app.IdentifierUris = &[]string{options.ExternalUrl}
+	app.Web = &ApplicationWeb{
+		HomePageUrl:  NullableString(StringNullWhenEmpty(options.ExternalUrl)),
+		RedirectUris: &[]string{options.ExternalUrl},
+	}
+	if _, err := applications.Update(ctx, Application{
+		DirectoryObject: DirectoryObject{Id: app.ID()},
+		IdentifierUris:  app.IdentifierUris,
+		Web:             app.Web,
+	}); err != nil {
+		return app, sp, fmt.Errorf("configuring application URLs: %v", err)
+	}
+
+	publishing := OnPremisesPublishing{}
+	if options.OnPremisesPublishing != nil {
+		publishing = *options.OnPremisesPublishing
+	}
+	authenticationType := options.ExternalAuthenticationType
+	if authenticationType == "" {
+		authenticationType = OnPremisesPublishingExternalAuthenticationTypeAadPreAuthentication
+	}
+	publishing.ExternalAuthenticationType = utils.StringPtr(authenticationType)
+	publishing.ExternalUrl = utils.StringPtr(options.ExternalUrl)
+	publishing.InternalUrl = utils.StringPtr(options.InternalUrl)
+	app.OnPremisesPublishing = &publishing
+
+	if _, err := applications.Update(ctx, Application{
+		DirectoryObject:      DirectoryObject{Id: app.ID()},
+		OnPremisesPublishing: app.OnPremisesPublishing,
+	}); err != nil {
+		return app, sp, fmt.Errorf("configuring on-premises publishing: %v", err)
+	}
+
+	if options.ConnectorGroupId != "" {
+		if _, err := s.ApplicationProxyConnectorGroups().AssignApplication(ctx, options.ConnectorGroupId, *app.ID()); err != nil {
+			return app, sp, fmt.Errorf("assigning connector group: %v", err)
+		}
+	}
+
+	return app, sp, nil
+}
@@ -0,0 +1,348 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+)
+
+// ApplicationProxyConnectorGroupsClient performs operations on application proxy ConnectorGroups. Each Connector
+// belongs to exactly one ConnectorGroup, and each application published via Application Proxy is assigned to a
+// ConnectorGroup whose connectors handle its traffic.
+type ApplicationProxyConnectorGroupsClient struct {
+	BaseClient Client
+}
+
+// NewApplicationProxyConnectorGroupsClient returns a new ApplicationProxyConnectorGroupsClient.
+func NewApplicationProxyConnectorGroupsClient(opts ...ClientOption) *ApplicationProxyConnectorGroupsClient {
+	return &ApplicationProxyConnectorGroupsClient{
+		BaseClient: NewClient(VersionBeta, opts...),
+	}
+}
+
+// List returns a list of ConnectorGroups, optionally queried using OData.
+func (c *ApplicationProxyConnectorGroupsClient) List(ctx context.Context, query odata.Query) (*[]ConnectorGroup, int, error) {
+	if err := c.BaseClient.requireBeta("ApplicationProxyConnectorGroupsClient.List()"); err != nil {
+		return nil, 0, err
+	}
+
+	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+		DisablePaging:    query.Top > 0,
+		OData:            query,
+		ValidStatusCodes: []int{http.StatusOK},
+		Uri: Uri{
+			Entity: "/onPremisesPublishingProfiles/applicationProxy/connectorGroups",
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("ApplicationProxyConnectorGroupsClient.BaseClient.Get(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var data struct {
+		ConnectorGroups []ConnectorGroup `json:"value"`
+	}
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &data.ConnectorGroups, status, nil
+}
+
+// Create creates a new ConnectorGroup.
+func (c *ApplicationProxyConnectorGroupsClient) Create(ctx context.Context, connectorGroup ConnectorGroup) (*ConnectorGroup, int, error) {
+	if err := c.BaseClient.requireBeta("ApplicationProxyConnectorGroupsClient.Create()"); err != nil {
+		return nil, 0, err
+	}
+
+	var status int
+
+	body, err := json.Marshal(connectorGroup)
+	if err != nil {
+		return nil, status, fmt.Errorf("json.Marshal(): %v", err)
+	}
+
+	resp, status, _, err := c.BaseClient.Post(ctx, PostHttpRequestInput{
+		Body:             body,
+		ValidStatusCodes: []int{http.StatusCreated},
+		Uri: Uri{
+			Entity: "/onPremisesPublishingProfiles/applicationProxy/connectorGroups",
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("ApplicationProxyConnectorGroupsClient.BaseClient.Post(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var newConnectorGroup ConnectorGroup
+	if err := json.Unmarshal(respBody, &newConnectorGroup); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &newConnectorGroup, status, nil
+}
+
+// Get retrieves a ConnectorGroup.
+func (c *ApplicationProxyConnectorGroupsClient) Get(ctx context.Context, id string, query odata.Query) (*ConnectorGroup, int, error) {
+	if err := c.BaseClient.requireBeta("ApplicationProxyConnectorGroupsClient.Get()"); err != nil {
+		return nil, 0, err
+	}
+
+	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		OData:                  query,
+		ValidStatusCodes:       []int{http.StatusOK},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/onPremisesPublishingProfiles/applicationProxy/connectorGroups/%s", id),
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("ApplicationProxyConnectorGroupsClient.BaseClient.Get(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var connectorGroup ConnectorGroup
+	if err := json.Unmarshal(respBody, &connectorGroup); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &connectorGroup, status, nil
+}
+
+// Update amends an existing ConnectorGroup.
+func (c *ApplicationProxyConnectorGroupsClient) Update(ctx context.Context, connectorGroup ConnectorGroup) (int, error) {
+	if err := c.BaseClient.requireBeta("ApplicationProxyConnectorGroupsClient.Update()"); err != nil {
+		return 0, err
+	}
+
+	var status int
+
+	if connectorGroup.ID == nil {
+		return status, errors.New("cannot update ConnectorGroup with nil ID")
+	}
+
+	connectorGroupId := *connectorGroup.ID
+	connectorGroup.ID = nil
+
+	body, err := json.Marshal(connectorGroup)
+	if err != nil {
+		return status, fmt.Errorf("json.Marshal(): %v", err)
+	}
+
+	_, status, _, err = c.BaseClient.Patch(ctx, PatchHttpRequestInput{
+		Body:                   body,
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes: []int{
+			http.StatusOK,
+			http.StatusNoContent,
+		},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/onPremisesPublishingProfiles/applicationProxy/connectorGroups/%s", connectorGroupId),
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("ApplicationProxyConnectorGroupsClient.BaseClient.Patch(): %v", err)
+	}
+
+	return status, nil
+}
+
+// Delete removes a ConnectorGroup. A ConnectorGroup can only be deleted when it has no connectors or applications.
+func (c *ApplicationProxyConnectorGroupsClient) Delete(ctx context.Context, id string) (int, error) {
+	if err := c.BaseClient.requireBeta("ApplicationProxyConnectorGroupsClient.Delete()"); err != nil {
+		return 0, err
+	}
+
+	_, status, _, err := c.BaseClient.Delete(ctx, DeleteHttpRequestInput{
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusNoContent},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/onPremisesPublishingProfiles/applicationProxy/connectorGroups/%s", id),
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("ApplicationProxyConnectorGroupsClient.BaseClient.Delete(): %v", err)
+	}
+
+	return status, nil
+}
+
+// ListMembers returns the Connectors which belong to a ConnectorGroup.
+func (c *ApplicationProxyConnectorGroupsClient) ListMembers(ctx context.Context, id string) (*[]Connector, int, error) {
+	if err := c.BaseClient.requireBeta("ApplicationProxyConnectorGroupsClient.ListMembers()"); err != nil {
+		return nil, 0, err
+	}
+
+	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusOK},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/onPremisesPublishingProfiles/applicationProxy/connectorGroups/%s/members", id),
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("ApplicationProxyConnectorGroupsClient.BaseClient.Get(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var data struct {
+		Connectors []Connector `json:"value"`
+	}
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &data.Connectors, status, nil
+}
+
+// AddMember moves a Connector into a ConnectorGroup. Since a Connector belongs to exactly one ConnectorGroup, it is
+// removed from its previous ConnectorGroup.
+func (c *ApplicationProxyConnectorGroupsClient) AddMember(ctx context.Context, id, connectorId string) (int, error) {
+	if err := c.BaseClient.requireBeta("ApplicationProxyConnectorGroupsClient.AddMember()"); err != nil {
+		return 0, err
+	}
+
+	var status int
+
+	body, err := json.Marshal(DirectoryObject{ODataId: c.odataId(id)})
+	if err != nil {
+		return status, fmt.Errorf("json.Marshal(): %v", err)
+	}
+
+	_, status, _, err = c.BaseClient.Post(ctx, PostHttpRequestInput{
+		Body:                   body,
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusNoContent},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/onPremisesPublishingProfiles/applicationProxy/connectors/%s/memberOf/$ref", connectorId),
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("ApplicationProxyConnectorGroupsClient.BaseClient.Post(): %v", err)
+	}
+
+	return status, nil
+}
+
+// ListApplications returns the Applications assigned to a ConnectorGroup.
+func (c *ApplicationProxyConnectorGroupsClient) ListApplications(ctx context.Context, id string) (*[]Application, int, error) {
+	if err := c.BaseClient.requireBeta("ApplicationProxyConnectorGroupsClient.ListApplications()"); err != nil {
+		return nil, 0, err
+	}
+
+	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusOK},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/onPremisesPublishingProfiles/applicationProxy/connectorGroups/%s/applications", id),
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("ApplicationProxyConnectorGroupsClient.BaseClient.Get(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var data struct {
+		Applications []Application `json:"value"`
+	}
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &data.Applications, status, nil
+}
+
+// GetForApplication retrieves the ConnectorGroup assigned to an Application.
+func (c *ApplicationProxyConnectorGroupsClient) GetForApplication(ctx context.Context, applicationId string) (*ConnectorGroup, int, error) {
+	if err := c.BaseClient.requireBeta("ApplicationProxyConnectorGroupsClient.GetForApplication()"); err != nil {
+		return nil, 0, err
+	}
+
+	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusOK},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/applications/%s/connectorGroup", applicationId),
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("ApplicationProxyConnectorGroupsClient.BaseClient.Get(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var connectorGroup ConnectorGroup
+	if err := json.Unmarshal(respBody, &connectorGroup); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &connectorGroup, status, nil
+}
+
+// AssignApplication assigns an Application to a ConnectorGroup, replacing any existing assignment.
+func (c *ApplicationProxyConnectorGroupsClient) AssignApplication(ctx context.Context, id, applicationId string) (int, error) {
+	if err := c.BaseClient.requireBeta("ApplicationProxyConnectorGroupsClient.AssignApplication()"); err != nil {
+		return 0, err
+	}
+
+	var status int
+
+	body, err := json.Marshal(DirectoryObject{ODataId: c.odataId(id)})
+	if err != nil {
+		return status, fmt.Errorf("json.Marshal(): %v", err)
+	}
+
+	_, status, _, err = c.BaseClient.Put(ctx, PutHttpRequestInput{
+		Body:                   body,
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusNoContent},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/applications/%s/connectorGroup/$ref", applicationId),
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("ApplicationProxyConnectorGroupsClient.BaseClient.Put(): %v", err)
+	}
+
+	return status, nil
+}
+
+// odataId returns the OData ID of a ConnectorGroup, for use when referencing it from a Connector or Application.
+func (c *ApplicationProxyConnectorGroupsClient) odataId(id string) *odata.Id {
+	odataId := odata.Id(fmt.Sprintf("%s/%s/onPremisesPublishingProfiles/applicationProxy/connectorGroups/%s", c.BaseClient.Endpoint, c.BaseClient.ApiVersion, id))
+	return &odataId
+}
@@ -0,0 +1,159 @@
+package msgraph_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+	"github.com/manicminer/hamilton/internal/test"
+	"github.com/manicminer/hamilton/internal/utils"
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+func TestApplicationProxyConnectorGroupsClient(t *testing.T) {
+	c := test.NewTest(t)
+	defer c.CancelFunc()
+
+	connectorGroup := testApplicationProxyConnectorGroupsClient_Create(t, c, msgraph.ConnectorGroup{
+		Name: utils.StringPtr(fmt.Sprintf("test-connector-group-%s", c.RandomString)),
+	})
+	testApplicationProxyConnectorGroupsClient_List(t, c)
+	testApplicationProxyConnectorGroupsClient_Get(t, c, *connectorGroup.ID)
+	connectorGroup.Name = utils.StringPtr(fmt.Sprintf("test-connector-group-updated-%s", c.RandomString))
+	testApplicationProxyConnectorGroupsClient_Update(t, c, *connectorGroup)
+	testApplicationProxyConnectorGroupsClient_ListMembers(t, c, *connectorGroup.ID)
+	testApplicationProxyConnectorGroupsClient_ListApplications(t, c, *connectorGroup.ID)
+	testApplicationProxyConnectorGroupsClient_Delete(t, c, *connectorGroup.ID)
+}
+
+func TestApplicationProxyConnectorsClient(t *testing.T) {
+	c := test.NewTest(t)
+	defer c.CancelFunc()
+
+	connectors := testApplicationProxyConnectorsClient_List(t, c)
+	if len(*connectors) == 0 {
+		t.Skip("no application proxy connectors are registered in the test tenant")
+	}
+	connector := testApplicationProxyConnectorsClient_Get(t, c, *(*connectors)[0].ID)
+	testApplicationProxyConnectorsClient_ListMemberOf(t, c, *connector.ID)
+}
+
+func testApplicationProxyConnectorGroupsClient_Create(t *testing.T, c *test.Test, g msgraph.ConnectorGroup) (connectorGroup *msgraph.ConnectorGroup) {
+	connectorGroup, status, err := c.ApplicationProxyConnectorGroupsClient.Create(c.Context, g)
+	if err != nil {
+		t.Fatalf("ApplicationProxyConnectorGroupsClient.Create(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("ApplicationProxyConnectorGroupsClient.Create(): invalid status: %d", status)
+	}
+	if connectorGroup == nil {
+		t.Fatal("ApplicationProxyConnectorGroupsClient.Create(): connectorGroup was nil")
+	}
+	if connectorGroup.ID == nil {
+		t.Fatal("ApplicationProxyConnectorGroupsClient.Create(): connectorGroup.ID was nil")
+	}
+	return
+}
+
+func testApplicationProxyConnectorGroupsClient_List(t *testing.T, c *test.Test) (connectorGroups *[]msgraph.ConnectorGroup) {
+	connectorGroups, _, err := c.ApplicationProxyConnectorGroupsClient.List(c.Context, odata.Query{Top: 10})
+	if err != nil {
+		t.Fatalf("ApplicationProxyConnectorGroupsClient.List(): %v", err)
+	}
+	if connectorGroups == nil {
+		t.Fatal("ApplicationProxyConnectorGroupsClient.List(): connectorGroups was nil")
+	}
+	return
+}
+
+func testApplicationProxyConnectorGroupsClient_Get(t *testing.T, c *test.Test, id string) (connectorGroup *msgraph.ConnectorGroup) {
+	connectorGroup, status, err := c.ApplicationProxyConnectorGroupsClient.Get(c.Context, id, odata.Query{})
+	if err != nil {
+		t.Fatalf("ApplicationProxyConnectorGroupsClient.Get(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("ApplicationProxyConnectorGroupsClient.Get(): invalid status: %d", status)
+	}
+	if connectorGroup == nil {
+		t.Fatal("ApplicationProxyConnectorGroupsClient.Get(): connectorGroup was nil")
+	}
+	return
+}
+
+func testApplicationProxyConnectorGroupsClient_Update(t *testing.T, c *test.Test, g msgraph.ConnectorGroup) {
+	status, err := c.ApplicationProxyConnectorGroupsClient.Update(c.Context, g)
+	if err != nil {
+		t.Fatalf("ApplicationProxyConnectorGroupsClient.Update(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("ApplicationProxyConnectorGroupsClient.Update(): invalid status: %d", status)
+	}
+}
+
+func testApplicationProxyConnectorGroupsClient_ListMembers(t *testing.T, c *test.Test, id string) (connectors *[]msgraph.Connector) {
+	connectors, _, err := c.ApplicationProxyConnectorGroupsClient.ListMembers(c.Context, id)
+	if err != nil {
+		t.Fatalf("ApplicationProxyConnectorGroupsClient.ListMembers(): %v", err)
+	}
+	if connectors == nil {
+		t.Fatal("ApplicationProxyConnectorGroupsClient.ListMembers(): connectors was nil")
+	}
+	return
+}
+
+func testApplicationProxyConnectorGroupsClient_ListApplications(t *testing.T, c *test.Test, id string) (applications *[]msgraph.Application) {
+	applications, _, err := c.ApplicationProxyConnectorGroupsClient.ListApplications(c.Context, id)
+	if err != nil {
+		t.Fatalf("ApplicationProxyConnectorGroupsClient.ListApplications(): %v", err)
+	}
+	if applications == nil {
+		t.Fatal("ApplicationProxyConnectorGroupsClient.ListApplications(): applications was nil")
+	}
+	return
+}
+
+func testApplicationProxyConnectorGroupsClient_Delete(t *testing.T, c *test.Test, id string) {
+	status, err := c.ApplicationProxyConnectorGroupsClient.Delete(c.Context, id)
+	if err != nil {
+		t.Fatalf("ApplicationProxyConnectorGroupsClient.Delete(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("ApplicationProxyConnectorGroupsClient.Delete(): invalid status: %d", status)
+	}
+}
+
+func testApplicationProxyConnectorsClient_List(t *testing.T, c *test.Test) (connectors *[]msgraph.Connector) {
+	connectors, _, err := c.ApplicationProxyConnectorsClient.List(c.Context, odata.Query{})
+	if err != nil {
+		t.Fatalf("ApplicationProxyConnectorsClient.List(): %v", err)
+	}
+	if connectors == nil {
+		t.Fatal("ApplicationProxyConnectorsClient.List(): connectors was nil")
+	}
+	return
+}
+
+func testApplicationProxyConnectorsClient_Get(t *testing.T, c *test.Test, id string) (connector *msgraph.Connector) {
+	connector, status, err := c.ApplicationProxyConnectorsClient.Get(c.Context, id, odata.Query{})
+	if err != nil {
+		t.Fatalf("ApplicationProxyConnectorsClient.Get(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("ApplicationProxyConnectorsClient.Get(): invalid status: %d", status)
+	}
+	if connector == nil || connector.ID == nil {
+		t.Fatal("ApplicationProxyConnectorsClient.Get(): connector was nil")
+	}
+	return
+}
+
+func testApplicationProxyConnectorsClient_ListMemberOf(t *testing.T, c *test.Test, id string) (connectorGroups *[]msgraph.ConnectorGroup) {
+	connectorGroups, _, err := c.ApplicationProxyConnectorsClient.ListMemberOf(c.Context, id)
+	if err != nil {
+		t.Fatalf("ApplicationProxyConnectorsClient.ListMemberOf(): %v", err)
+	}
+	if connectorGroups == nil || len(*connectorGroups) != 1 {
+		t.Fatalf("ApplicationProxyConnectorsClient.ListMemberOf(): expected the connector to belong to one connector group, got %v", connectorGroups)
+	}
+	return
+}
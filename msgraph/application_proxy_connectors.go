@@ -0,0 +1,123 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+)
+
+// ApplicationProxyConnectorsClient performs operations on application proxy Connectors, which are the agents installed
+// on-premises that publish applications via Application Proxy.
+type ApplicationProxyConnectorsClient struct {
+	BaseClient Client
+}
+
+// NewApplicationProxyConnectorsClient returns a new ApplicationProxyConnectorsClient.
+func NewApplicationProxyConnectorsClient(opts ...ClientOption) *ApplicationProxyConnectorsClient {
+	return &ApplicationProxyConnectorsClient{
+		BaseClient: NewClient(VersionBeta, opts...),
+	}
+}
+
+// List returns a list of Connectors, optionally queried using OData.
+func (c *ApplicationProxyConnectorsClient) List(ctx context.Context, query odata.Query) (*[]Connector, int, error) {
+	if err := c.BaseClient.requireBeta("ApplicationProxyConnectorsClient.List()"); err != nil {
+		return nil, 0, err
+	}
+
+	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+		DisablePaging:    query.Top > 0,
+		OData:            query,
+		ValidStatusCodes: []int{http.StatusOK},
+		Uri: Uri{
+			Entity: "/onPremisesPublishingProfiles/applicationProxy/connectors",
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("ApplicationProxyConnectorsClient.BaseClient.Get(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var data struct {
+		Connectors []Connector `json:"value"`
+	}
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &data.Connectors, status, nil
+}
+
+// Get retrieves a Connector.
+func (c *ApplicationProxyConnectorsClient) Get(ctx context.Context, id string, query odata.Query) (*Connector, int, error) {
+	if err := c.BaseClient.requireBeta("ApplicationProxyConnectorsClient.Get()"); err != nil {
+		return nil, 0, err
+	}
+
+	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		OData:                  query,
+		ValidStatusCodes:       []int{http.StatusOK},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/onPremisesPublishingProfiles/applicationProxy/connectors/%s", id),
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("ApplicationProxyConnectorsClient.BaseClient.Get(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var connector Connector
+	if err := json.Unmarshal(respBody, &connector); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &connector, status, nil
+}
+
+// ListMemberOf returns the ConnectorGroups that a Connector belongs to.
+func (c *ApplicationProxyConnectorsClient) ListMemberOf(ctx context.Context, id string) (*[]ConnectorGroup, int, error) {
+	if err := c.BaseClient.requireBeta("ApplicationProxyConnectorsClient.ListMemberOf()"); err != nil {
+		return nil, 0, err
+	}
+
+	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusOK},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/onPremisesPublishingProfiles/applicationProxy/connectors/%s/memberOf", id),
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("ApplicationProxyConnectorsClient.BaseClient.Get(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var data struct {
+		ConnectorGroups []ConnectorGroup `json:"value"`
+	}
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &data.ConnectorGroups, status, nil
+}
@@ -0,0 +1,375 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	goerrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+	"github.com/manicminer/hamilton/errors"
+	"github.com/manicminer/hamilton/internal/utils"
+)
+
+func TestServiceClient_PublishApplicationProxy(t *testing.T) {
+	requests := make([]string, 0)
+	bodies := make(map[string][]string)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := r.Method + " " + r.URL.Path
+		requests = append(requests, request)
+		body, _ := io.ReadAll(r.Body)
+		bodies[request] = append(bodies[request], string(body))
+
+		switch request {
+		case "POST /v1.0/applicationTemplates/" + ApplicationProxyTemplateId + "/instantiate":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"application":{"id":"app-1","appId":"11111111-2222-3333-4444-555555555555"},"servicePrincipal":{"id":"sp-1"}}`))
+		case "PATCH /beta/applications/app-1", "PUT /beta/applications/app-1/connectorGroup/$ref":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s", request)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	svc := NewServiceClient(WithEndpoint(ts.URL), WithRetryMax(0))
+	app, sp, err := svc.PublishApplicationProxy(context.Background(), ApplicationProxyOptions{
+		DisplayName:      "Payroll",
+		InternalUrl:      "http://payroll.corp.contoso.com/",
+		ExternalUrl:      "https://payroll-contoso.msappproxy.net/",
+		ConnectorGroupId: "group-1",
+	})
+	if err != nil {
+		t.Fatalf("PublishApplicationProxy(): %v", err)
+	}
+	if *app.ID() != "app-1" || *sp.ID() != "sp-1" {
+		t.Errorf("unexpected application %q or service principal %q", *app.ID(), *sp.ID())
+	}
+
+	expected := []string{
+		"POST /v1.0/applicationTemplates/" + ApplicationProxyTemplateId + "/instantiate",
+		"PATCH /beta/applications/app-1",
+		"PATCH /beta/applications/app-1",
+		"PUT /beta/applications/app-1/connectorGroup/$ref",
+	}
+	if strings.Join(requests, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("unexpected requests:\n%s", strings.Join(requests, "\n"))
+	}
+
+	patches := bodies["PATCH /beta/applications/app-1"]
+	if !strings.Contains(patches[0], `"identifierUris":["https://payroll-contoso.msappproxy.net/"]`) || strings.Contains(patches[0], "onPremisesPublishing") {
+		t.Errorf("unexpected URL configuration: %s", patches[0])
+	}
+	var publishing struct {
+		OnPremisesPublishing OnPremisesPublishing `json:"onPremisesPublishing"`
+	}
+	if err := json.Unmarshal([]byte(patches[1]), &publishing); err != nil {
+		t.Fatalf("json.Unmarshal(): %v", err)
+	}
+	if *publishing.OnPremisesPublishing.ExternalAuthenticationType != OnPremisesPublishingExternalAuthenticationTypeAadPreAuthentication ||
+		*publishing.OnPremisesPublishing.InternalUrl != "http://payroll.corp.contoso.com/" {
+		t.Errorf("unexpected on-premises publishing: %s", patches[1])
+	}
+
+	ref := bodies["PUT /beta/applications/app-1/connectorGroup/$ref"][0]
+	if expected := `{"@odata.id":"` + ts.URL + `/beta/onPremisesPublishingProfiles/applicationProxy/connectorGroups/group-1"}`; ref != expected {
+		t.Errorf("unexpected connector group reference: %s, expected %s", ref, expected)
+	}
+}
+
+// fakeApplicationProxy is an in-memory implementation of the connector and connector group endpoints.
+type fakeApplicationProxy struct {
+	sync.Mutex
+	t           *testing.T
+	groups      map[string]ConnectorGroup
+	memberOf    map[string]string
+	assignments map[string]string
+	nextId      int
+}
+
+func (f *fakeApplicationProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+
+	write := func(status int, v interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(v)
+	}
+	groupId := func(body io.Reader) string {
+		var ref DirectoryObject
+		json.NewDecoder(body).Decode(&ref)
+		if ref.ODataId == nil {
+			return ""
+		}
+		return path.Base(string(*ref.ODataId))
+	}
+
+	const prefix = "/beta/onPremisesPublishingProfiles/applicationProxy/"
+	segments := strings.Split(strings.TrimPrefix(r.URL.Path, prefix), "/")
+	if strings.HasPrefix(r.URL.Path, "/beta/applications/") {
+		segments = strings.Split(strings.TrimPrefix(r.URL.Path, "/beta/"), "/")
+	}
+
+	switch {
+	case r.Method == http.MethodPost && len(segments) == 1 && segments[0] == "connectorGroups":
+		var group ConnectorGroup
+		json.NewDecoder(r.Body).Decode(&group)
+		f.nextId++
+		group.ID = utils.StringPtr(fmt.Sprintf("group-%d", f.nextId))
+		f.groups[*group.ID] = group
+		write(http.StatusCreated, group)
+
+	case r.Method == http.MethodGet && len(segments) == 1 && segments[0] == "connectorGroups":
+		groups := make([]ConnectorGroup, 0)
+		for _, group := range f.groups {
+			groups = append(groups, group)
+		}
+		write(http.StatusOK, map[string]interface{}{"value": groups})
+
+	case len(segments) == 2 && segments[0] == "connectorGroups":
+		group, ok := f.groups[segments[1]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			write(http.StatusOK, group)
+		case http.MethodPatch:
+			var update ConnectorGroup
+			json.NewDecoder(r.Body).Decode(&update)
+			if update.ID != nil {
+				f.t.Errorf("unexpected ID in update: %s", *update.ID)
+			}
+			group.Name = update.Name
+			f.groups[segments[1]] = group
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodDelete:
+			delete(f.groups, segments[1])
+			w.WriteHeader(http.StatusNoContent)
+		}
+
+	case r.Method == http.MethodGet && len(segments) == 3 && segments[0] == "connectorGroups" && segments[2] == "members":
+		connectors := make([]Connector, 0)
+		for connectorId, id := range f.memberOf {
+			if id == segments[1] {
+				connectors = append(connectors, Connector{ID: utils.StringPtr(connectorId)})
+			}
+		}
+		write(http.StatusOK, map[string]interface{}{"value": connectors})
+
+	case r.Method == http.MethodGet && len(segments) == 3 && segments[0] == "connectorGroups" && segments[2] == "applications":
+		applications := make([]Application, 0)
+		for applicationId, id := range f.assignments {
+			if id == segments[1] {
+				applications = append(applications, Application{DirectoryObject: DirectoryObject{Id: utils.StringPtr(applicationId)}})
+			}
+		}
+		write(http.StatusOK, map[string]interface{}{"value": applications})
+
+	case r.Method == http.MethodGet && len(segments) == 1 && segments[0] == "connectors":
+		connectors := make([]Connector, 0)
+		for connectorId := range f.memberOf {
+			connectors = append(connectors, Connector{ID: utils.StringPtr(connectorId)})
+		}
+		write(http.StatusOK, map[string]interface{}{"value": connectors})
+
+	case r.Method == http.MethodGet && len(segments) == 2 && segments[0] == "connectors":
+		if _, ok := f.memberOf[segments[1]]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		write(http.StatusOK, Connector{ID: utils.StringPtr(segments[1]), Status: utils.StringPtr(ConnectorStatusActive)})
+
+	case r.Method == http.MethodGet && len(segments) == 3 && segments[0] == "connectors" && segments[2] == "memberOf":
+		write(http.StatusOK, map[string]interface{}{"value": []ConnectorGroup{f.groups[f.memberOf[segments[1]]]}})
+
+	case r.Method == http.MethodPost && len(segments) == 4 && segments[0] == "connectors" && segments[2] == "memberOf" && segments[3] == "$ref":
+		f.memberOf[segments[1]] = groupId(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+
+	case r.Method == http.MethodPut && len(segments) == 4 && segments[0] == "applications" && segments[2] == "connectorGroup" && segments[3] == "$ref":
+		f.assignments[segments[1]] = groupId(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+
+	case r.Method == http.MethodGet && len(segments) == 3 && segments[0] == "applications" && segments[2] == "connectorGroup":
+		id, ok := f.assignments[segments[1]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		write(http.StatusOK, f.groups[id])
+
+	default:
+		f.t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestApplicationProxyClients(t *testing.T) {
+	fake := &fakeApplicationProxy{
+		t:           t,
+		groups:      map[string]ConnectorGroup{"default-group": {ID: utils.StringPtr("default-group"), Name: utils.StringPtr("Default"), IsDefault: utils.BoolPtr(true)}},
+		memberOf:    map[string]string{"connector-1": "default-group", "connector-2": "default-group"},
+		assignments: map[string]string{},
+	}
+	ts := httptest.NewServer(fake)
+	defer ts.Close()
+
+	ctx := context.Background()
+	groups := NewApplicationProxyConnectorGroupsClient(WithEndpoint(ts.URL), WithRetryMax(0))
+	connectors := NewApplicationProxyConnectorsClient(WithEndpoint(ts.URL), WithRetryMax(0))
+
+	group, _, err := groups.Create(ctx, ConnectorGroup{Name: utils.StringPtr("Payroll")})
+	if err != nil {
+		t.Fatalf("Create(): %v", err)
+	}
+	if group.ID == nil || *group.Name != "Payroll" {
+		t.Fatalf("unexpected connector group: %+v", group)
+	}
+	id := *group.ID
+
+	if list, _, err := groups.List(ctx, odata.Query{}); err != nil || len(*list) != 2 {
+		t.Fatalf("List(): expected 2 connector groups, got %v (%v)", list, err)
+	}
+
+	group.Name = utils.StringPtr("Payroll (EU)")
+	if _, err := groups.Update(ctx, *group); err != nil {
+		t.Fatalf("Update(): %v", err)
+	}
+	if group.ID == nil {
+		t.Fatal("Update(): the ID of the connector group passed by value was cleared")
+	}
+	if got, _, err := groups.Get(ctx, id, odata.Query{}); err != nil || *got.Name != "Payroll (EU)" {
+		t.Fatalf("Get(): unexpected connector group %+v (%v)", got, err)
+	}
+	if _, err := groups.Update(ctx, ConnectorGroup{Name: utils.StringPtr("no ID")}); err == nil {
+		t.Error("Update(): expected an error for a connector group without an ID")
+	}
+
+	// a connector moves from its previous connector group
+	if _, err := groups.AddMember(ctx, id, "connector-1"); err != nil {
+		t.Fatalf("AddMember(): %v", err)
+	}
+	if members, _, err := groups.ListMembers(ctx, id); err != nil || len(*members) != 1 || *(*members)[0].ID != "connector-1" {
+		t.Fatalf("ListMembers(): unexpected members %v (%v)", members, err)
+	}
+	if members, _, err := groups.ListMembers(ctx, "default-group"); err != nil || len(*members) != 1 || *(*members)[0].ID != "connector-2" {
+		t.Fatalf("ListMembers(): unexpected members of the default group %v (%v)", members, err)
+	}
+
+	if list, _, err := connectors.List(ctx, odata.Query{}); err != nil || len(*list) != 2 {
+		t.Fatalf("ApplicationProxyConnectorsClient.List(): expected 2 connectors, got %v (%v)", list, err)
+	}
+	if connector, _, err := connectors.Get(ctx, "connector-1", odata.Query{}); err != nil || *connector.Status != ConnectorStatusActive {
+		t.Fatalf("ApplicationProxyConnectorsClient.Get(): unexpected connector %+v (%v)", connector, err)
+	}
+	if memberOf, _, err := connectors.ListMemberOf(ctx, "connector-1"); err != nil || len(*memberOf) != 1 || *(*memberOf)[0].ID != id {
+		t.Fatalf("ApplicationProxyConnectorsClient.ListMemberOf(): unexpected connector groups %v (%v)", memberOf, err)
+	}
+
+	if _, err := groups.AssignApplication(ctx, id, "app-1"); err != nil {
+		t.Fatalf("AssignApplication(): %v", err)
+	}
+	if applications, _, err := groups.ListApplications(ctx, id); err != nil || len(*applications) != 1 || *(*applications)[0].ID() != "app-1" {
+		t.Fatalf("ListApplications(): unexpected applications %v (%v)", applications, err)
+	}
+	if assigned, _, err := groups.GetForApplication(ctx, "app-1"); err != nil || *assigned.ID != id {
+		t.Fatalf("GetForApplication(): unexpected connector group %+v (%v)", assigned, err)
+	}
+
+	if _, err := groups.Delete(ctx, id); err != nil {
+		t.Fatalf("Delete(): %v", err)
+	}
+	if _, status, err := groups.Get(ctx, id, odata.Query{}); err == nil || status != http.StatusNotFound {
+		t.Fatalf("Get(): expected the connector group to be deleted, got status %d (%v)", status, err)
+	}
+}
+
+func TestApplicationProxyClients_Version10(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request sent to %s", r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	groups := NewApplicationProxyConnectorGroupsClient(WithEndpoint(ts.URL), WithApiVersion(Version10), WithRetryMax(0))
+	connectors := NewApplicationProxyConnectorsClient(WithEndpoint(ts.URL), WithApiVersion(Version10), WithRetryMax(0))
+	id := "11111111-1111-1111-1111-111111111111"
+
+	calls := map[string]func() error{
+		"ApplicationProxyConnectorGroupsClient.List()": func() error {
+			_, _, err := groups.List(ctx, odata.Query{})
+			return err
+		},
+		"ApplicationProxyConnectorGroupsClient.Create()": func() error {
+			_, _, err := groups.Create(ctx, ConnectorGroup{Name: utils.StringPtr("test")})
+			return err
+		},
+		"ApplicationProxyConnectorGroupsClient.Get()": func() error {
+			_, _, err := groups.Get(ctx, id, odata.Query{})
+			return err
+		},
+		"ApplicationProxyConnectorGroupsClient.Update()": func() error {
+			_, err := groups.Update(ctx, ConnectorGroup{ID: &id, Name: utils.StringPtr("test")})
+			return err
+		},
+		"ApplicationProxyConnectorGroupsClient.Delete()": func() error {
+			_, err := groups.Delete(ctx, id)
+			return err
+		},
+		"ApplicationProxyConnectorGroupsClient.ListMembers()": func() error {
+			_, _, err := groups.ListMembers(ctx, id)
+			return err
+		},
+		"ApplicationProxyConnectorGroupsClient.AddMember()": func() error {
+			_, err := groups.AddMember(ctx, id, id)
+			return err
+		},
+		"ApplicationProxyConnectorGroupsClient.ListApplications()": func() error {
+			_, _, err := groups.ListApplications(ctx, id)
+			return err
+		},
+		"ApplicationProxyConnectorGroupsClient.GetForApplication()": func() error {
+			_, _, err := groups.GetForApplication(ctx, id)
+			return err
+		},
+		"ApplicationProxyConnectorGroupsClient.AssignApplication()": func() error {
+			_, err := groups.AssignApplication(ctx, id, id)
+			return err
+		},
+		"ApplicationProxyConnectorsClient.List()": func() error {
+			_, _, err := connectors.List(ctx, odata.Query{})
+			return err
+		},
+		"ApplicationProxyConnectorsClient.Get()": func() error {
+			_, _, err := connectors.Get(ctx, id, odata.Query{})
+			return err
+		},
+		"ApplicationProxyConnectorsClient.ListMemberOf()": func() error {
+			_, _, err := connectors.ListMemberOf(ctx, id)
+			return err
+		},
+	}
+	for operation, call := range calls {
+		var versionErr errors.UnsupportedApiVersionError
+		if err := call(); !goerrors.As(err, &versionErr) {
+			t.Errorf("%s: expected UnsupportedApiVersionError, got: %v", operation, err)
+			continue
+		}
+		if versionErr.Operation != operation {
+			t.Errorf("%s: unexpected operation in error: %q", operation, versionErr.Operation)
+		}
+	}
+}
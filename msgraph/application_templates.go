@@ -17,9 +17,9 @@ type ApplicationTemplatesClient struct {
 }
 
 // NewApplicationTemplatesClient returns a new ApplicationTemplatesClient
-func NewApplicationTemplatesClient() *ApplicationTemplatesClient {
+func NewApplicationTemplatesClient(opts ...ClientOption) *ApplicationTemplatesClient {
 	return &ApplicationTemplatesClient{
-		BaseClient: NewClient(Version10),
+		BaseClient: NewClient(Version10, opts...),
 	}
 }
 
@@ -17,9 +17,9 @@ type ApplicationsClient struct {
 }
 
 // NewApplicationsClient returns a new ApplicationsClient
-func NewApplicationsClient() *ApplicationsClient {
+func NewApplicationsClient(opts ...ClientOption) *ApplicationsClient {
 	return &ApplicationsClient{
-		BaseClient: NewClient(VersionBeta),
+		BaseClient: NewClient(VersionBeta, opts...),
 	}
 }
 
@@ -57,6 +57,10 @@ func (c *ApplicationsClient) List(ctx context.Context, query odata.Query) (*[]Ap
 func (c *ApplicationsClient) Create(ctx context.Context, application Application) (*Application, int, error) {
 	var status int
 
+	if err := c.BaseClient.validateFields("ApplicationsClient.Create()", application); err != nil {
+		return nil, status, err
+	}
+
 	body, err := json.Marshal(application)
 	if err != nil {
 		return nil, status, fmt.Errorf("json.Marshal(): %v", err)
@@ -155,6 +159,10 @@ func (c *ApplicationsClient) Update(ctx context.Context, application Application
 		return status, errors.New("ApplicationsClient.Update(): cannot update application with nil ID")
 	}
 
+	if err := c.BaseClient.validateFields("ApplicationsClient.Update()", application); err != nil {
+		return status, err
+	}
+
 	body, err := json.Marshal(application)
 	if err != nil {
 		return status, fmt.Errorf("json.Marshal(): %v", err)
@@ -188,6 +196,91 @@ func (c *ApplicationsClient) Update(ctx context.Context, application Application
 	return status, nil
 }
 
+// GetByUniqueName retrieves an Application using its uniqueName alternate key.
+func (c *ApplicationsClient) GetByUniqueName(ctx context.Context, uniqueName string, query odata.Query) (*Application, int, error) {
+	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		OData:                  query,
+		ValidStatusCodes:       []int{http.StatusOK},
+		Uri: Uri{
+			Entity: alternateKey("applications", "uniqueName", uniqueName),
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("ApplicationsClient.BaseClient.Get(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var application Application
+	if err := json.Unmarshal(respBody, &application); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &application, status, nil
+}
+
+// Upsert creates or updates the Application with the uniqueName of the provided application. The returned bool is true
+// when a new application was created, and false when an existing application was updated. Either way, the returned
+// Application is the application as it exists after the upsert.
+func (c *ApplicationsClient) Upsert(ctx context.Context, application Application) (*Application, bool, int, error) {
+	var status int
+
+	if application.UniqueName == nil || *application.UniqueName == "" {
+		return nil, false, status, errors.New("ApplicationsClient.Upsert(): cannot upsert application with nil or empty uniqueName")
+	}
+
+	uniqueName := *application.UniqueName
+	application.DirectoryObject = DirectoryObject{}
+	application.UniqueName = nil
+
+	if err := c.BaseClient.validateFields("ApplicationsClient.Upsert()", application); err != nil {
+		return nil, false, status, err
+	}
+
+	body, err := json.Marshal(application)
+	if err != nil {
+		return nil, false, status, fmt.Errorf("json.Marshal(): %v", err)
+	}
+
+	resp, status, _, err := c.BaseClient.Patch(ctx, PatchHttpRequestInput{
+		Body:             body,
+		Prefer:           preferCreateIfMissing,
+		ValidStatusCodes: []int{http.StatusCreated, http.StatusNoContent},
+		Uri: Uri{
+			Entity: alternateKey("applications", "uniqueName", uniqueName),
+		},
+	})
+	if err != nil {
+		return nil, false, status, fmt.Errorf("ApplicationsClient.BaseClient.Patch(): %v", err)
+	}
+
+	if status == http.StatusNoContent {
+		updated, status, err := c.GetByUniqueName(ctx, uniqueName, odata.Query{})
+		if err != nil {
+			return nil, false, status, fmt.Errorf("retrieving updated application: %v", err)
+		}
+		return updated, false, status, nil
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, true, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var newApplication Application
+	if err := json.Unmarshal(respBody, &newApplication); err != nil {
+		return nil, true, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &newApplication, true, status, nil
+}
+
 // Delete removes an Application.
 func (c *ApplicationsClient) Delete(ctx context.Context, id string) (int, error) {
 	_, status, _, err := c.BaseClient.Delete(ctx, DeleteHttpRequestInput{
@@ -386,6 +479,83 @@ func (c *ApplicationsClient) RemovePassword(ctx context.Context, applicationId s
 	return status, nil
 }
 
+// AddKey appends a new certificate credential to an Application, optionally accompanied by a password credential when
+// the key type is X509CertAndPassword. proof is a token signed with an existing certificate of the application, as
+// built by NewProofOfPossessionToken. This enables an application to roll its own certificate without
+// additional privileges. To add the first certificate, use Update instead.
+func (c *ApplicationsClient) AddKey(ctx context.Context, applicationId string, keyCredential KeyCredential, passwordCredential *PasswordCredential, proof string) (*KeyCredential, int, error) {
+	var status int
+
+	body, err := json.Marshal(struct {
+		KeyCredential      KeyCredential       `json:"keyCredential"`
+		PasswordCredential *PasswordCredential `json:"passwordCredential"`
+		Proof              string              `json:"proof"`
+	}{
+		KeyCredential:      keyCredential,
+		PasswordCredential: passwordCredential,
+		Proof:              proof,
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("json.Marshal(): %v", err)
+	}
+
+	resp, status, _, err := c.BaseClient.Post(ctx, PostHttpRequestInput{
+		Body:                   body,
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusOK, http.StatusCreated},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/applications/%s/addKey", applicationId),
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("ApplicationsClient.BaseClient.Post(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var newKeyCredential KeyCredential
+	if err := json.Unmarshal(respBody, &newKeyCredential); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &newKeyCredential, status, nil
+}
+
+// RemoveKey removes a certificate credential from an Application. proof is a token signed with an existing certificate
+// of the application, as built by NewProofOfPossessionToken.
+func (c *ApplicationsClient) RemoveKey(ctx context.Context, applicationId string, keyId string, proof string) (int, error) {
+	var status int
+
+	body, err := json.Marshal(struct {
+		KeyId string `json:"keyId"`
+		Proof string `json:"proof"`
+	}{
+		KeyId: keyId,
+		Proof: proof,
+	})
+	if err != nil {
+		return status, fmt.Errorf("json.Marshal(): %v", err)
+	}
+
+	_, status, _, err = c.BaseClient.Post(ctx, PostHttpRequestInput{
+		Body:                   body,
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusOK, http.StatusNoContent},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/applications/%s/removeKey", applicationId),
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("ApplicationsClient.BaseClient.Post(): %v", err)
+	}
+
+	return status, nil
+}
+
 // ListOwners retrieves the owners of the specified Application.
 // id is the object ID of the application.
 func (c *ApplicationsClient) ListOwners(ctx context.Context, id string) (*[]string, int, error) {
@@ -649,6 +819,22 @@ func (c *ApplicationsClient) UploadLogo(ctx context.Context, applicationId, cont
 	return status, nil
 }
 
+// GetLogo retrieves the application logo. The response body is not buffered and is returned to the caller, who is
+// responsible for closing it. When the application has no logo, a 404 status is returned along with an error.
+func (c *ApplicationsClient) GetLogo(ctx context.Context, applicationId string) (io.ReadCloser, int, error) {
+	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+		ValidStatusCodes: []int{http.StatusOK},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/applications/%s/logo", applicationId),
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("ApplicationsClient.BaseClient.Get(): %v", err)
+	}
+
+	return resp.Body, status, nil
+}
+
 // ListFederatedIdentityCredentials returns the federated identity credentials for an application
 func (c *ApplicationsClient) ListFederatedIdentityCredentials(ctx context.Context, applicationId string, query odata.Query) (*[]FederatedIdentityCredential, int, error) {
 	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
@@ -707,10 +893,16 @@ func (c *ApplicationsClient) GetFederatedIdentityCredential(ctx context.Context,
 	return &data, status, nil
 }
 
-// CreateFederatedIdentityCredential adds a new federated identity credential for an application
+// CreateFederatedIdentityCredential adds a new federated identity credential for an application. The number of
+// credentials is not checked against FederatedIdentityCredentialsLimit before the request is sent, use
+// ValidateFederatedIdentityCredentials with the existing credentials to check it beforehand.
 func (c *ApplicationsClient) CreateFederatedIdentityCredential(ctx context.Context, applicationId string, credential FederatedIdentityCredential) (*FederatedIdentityCredential, int, error) {
 	var status int
 
+	if err := c.BaseClient.validateFields("ApplicationsClient.CreateFederatedIdentityCredential()", credential); err != nil {
+		return nil, status, err
+	}
+
 	body, err := json.Marshal(credential)
 	if err != nil {
 		return nil, status, fmt.Errorf("json.Marshal(): %v", err)
@@ -749,6 +941,10 @@ func (c *ApplicationsClient) UpdateFederatedIdentityCredential(ctx context.Conte
 		return status, errors.New("ApplicationsClient.UpdateFederatedIdentityCredential(): cannot update federated identity credential with nil ID")
 	}
 
+	if err := c.BaseClient.validateFields("ApplicationsClient.UpdateFederatedIdentityCredential()", credential); err != nil {
+		return status, err
+	}
+
 	body, err := json.Marshal(credential)
 	if err != nil {
 		return status, fmt.Errorf("json.Marshal(): %v", err)
@@ -783,3 +979,125 @@ func (c *ApplicationsClient) DeleteFederatedIdentityCredential(ctx context.Conte
 
 	return status, nil
 }
+
+// AssignTokenLifetimePolicy assigns tokenLifetimePolicies to an application
+func (c *ApplicationsClient) AssignTokenLifetimePolicy(ctx context.Context, applicationId string, policies *[]TokenLifetimePolicy) (int, error) {
+	var status int
+
+	if policies == nil {
+		return status, errors.New("cannot update application with nil TokenLifetimePolicies")
+	}
+
+	for _, policy := range *policies {
+		// don't fail if the policy is already assigned
+		checkPolicyAlreadyExists := func(resp *http.Response, o *odata.OData) bool {
+			if resp != nil && resp.StatusCode == http.StatusBadRequest && o != nil && o.Error != nil {
+				return o.Error.Match(odata.ErrorAddedObjectReferencesAlreadyExist)
+			}
+			return false
+		}
+
+		body, err := json.Marshal(DirectoryObject{ODataId: policy.ODataId})
+		if err != nil {
+			return status, fmt.Errorf("json.Marshal(): %v", err)
+		}
+
+		_, status, _, err = c.BaseClient.Post(ctx, PostHttpRequestInput{
+			Body:                   body,
+			ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+			ValidStatusCodes:       []int{http.StatusNoContent},
+			ValidStatusFunc:        checkPolicyAlreadyExists,
+			Uri: Uri{
+				Entity: fmt.Sprintf("/applications/%s/tokenLifetimePolicies/$ref", applicationId),
+			},
+		})
+		if err != nil {
+			return status, fmt.Errorf("ApplicationsClient.BaseClient.Post(): %v", err)
+		}
+	}
+
+	return status, nil
+}
+
+// ListTokenLifetimePolicy retrieves the tokenLifetimePolicies assigned to the specified Application.
+func (c *ApplicationsClient) ListTokenLifetimePolicy(ctx context.Context, applicationId string) (*[]TokenLifetimePolicy, int, error) {
+	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusOK},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/applications/%s/tokenLifetimePolicies", applicationId),
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("ApplicationsClient.BaseClient.Get(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var data struct {
+		Policies []TokenLifetimePolicy `json:"value"`
+	}
+
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &data.Policies, status, nil
+}
+
+// RemoveTokenLifetimePolicy removes a tokenLifetimePolicy from an application
+func (c *ApplicationsClient) RemoveTokenLifetimePolicy(ctx context.Context, applicationId string, policyIds *[]string) (int, error) {
+	var status int
+
+	if policyIds == nil {
+		return status, errors.New("cannot remove, nil TokenLifetimePolicyIds")
+	}
+
+	assignedPolicies, _, err := c.ListTokenLifetimePolicy(ctx, applicationId)
+	if err != nil {
+		return status, fmt.Errorf("ApplicationsClient.BaseClient.ListTokenLifetimePolicy(): %v", err)
+	}
+
+	if len(*assignedPolicies) == 0 {
+		return http.StatusNoContent, nil
+	}
+
+	mapTokenLifetimePolicy := map[string]TokenLifetimePolicy{}
+	for _, v := range *assignedPolicies {
+		mapTokenLifetimePolicy[*v.ID()] = v
+	}
+
+	for _, policyId := range *policyIds {
+
+		// Check if policy is currently assigned
+		_, ok := mapTokenLifetimePolicy[policyId]
+		if !ok {
+			continue
+		}
+
+		checkPolicyStatus := func(resp *http.Response, o *odata.OData) bool {
+			if resp != nil && resp.StatusCode == http.StatusNotFound && o != nil && o.Error != nil {
+				return o.Error.Match(odata.ErrorResourceDoesNotExist)
+			}
+			return false
+		}
+
+		_, status, _, err = c.BaseClient.Delete(ctx, DeleteHttpRequestInput{
+			ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+			ValidStatusCodes:       []int{http.StatusNoContent},
+			ValidStatusFunc:        checkPolicyStatus,
+			Uri: Uri{
+				Entity: fmt.Sprintf("/applications/%s/tokenLifetimePolicies/%s/$ref", applicationId, policyId),
+			},
+		})
+		if err != nil {
+			return status, fmt.Errorf("ApplicationsClient.BaseClient.Delete(): %v", err)
+		}
+	}
+
+	return status, nil
+}
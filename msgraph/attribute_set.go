@@ -18,9 +18,9 @@ type AttributeSetClient struct {
 	BaseClient Client
 }
 
-func NewAttributeSetClient() *AttributeSetClient {
+func NewAttributeSetClient(opts ...ClientOption) *AttributeSetClient {
 	return &AttributeSetClient{
-		BaseClient: NewClient(Version10),
+		BaseClient: NewClient(Version10, opts...),
 	}
 }
 
@@ -17,9 +17,9 @@ type AuthenticationMethodsClient struct {
 }
 
 // NewAuthenticationMethodsClient returns a new AuthenticationMethodsClient
-func NewAuthenticationMethodsClient() *AuthenticationMethodsClient {
+func NewAuthenticationMethodsClient(opts ...ClientOption) *AuthenticationMethodsClient {
 	return &AuthenticationMethodsClient{
-		BaseClient: NewClient(VersionBeta),
+		BaseClient: NewClient(VersionBeta, opts...),
 	}
 }
 
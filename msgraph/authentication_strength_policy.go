@@ -17,9 +17,9 @@ type AuthenticationStrengthPoliciesClient struct {
 }
 
 // NewAuthenticationStrengthPoliciesClient returns a new AuthenticationStrengthPoliciesClient
-func NewAuthenticationStrengthPoliciesClient() *AuthenticationStrengthPoliciesClient {
+func NewAuthenticationStrengthPoliciesClient(opts ...ClientOption) *AuthenticationStrengthPoliciesClient {
 	return &AuthenticationStrengthPoliciesClient{
-		BaseClient: NewClient(VersionBeta),
+		BaseClient: NewClient(VersionBeta, opts...),
 	}
 }
 
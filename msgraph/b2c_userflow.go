@@ -16,9 +16,9 @@ type B2CUserFlowClient struct {
 }
 
 // NewB2CUserFlowClient returns a new B2CUserFlowClient.
-func NewB2CUserFlowClient() *B2CUserFlowClient {
+func NewB2CUserFlowClient(opts ...ClientOption) *B2CUserFlowClient {
 	return &B2CUserFlowClient{
-		BaseClient: NewClient(VersionBeta),
+		BaseClient: NewClient(VersionBeta, opts...).withFeature(FeatureB2C),
 	}
 }
 
@@ -15,9 +15,9 @@ type ClaimsMappingPolicyClient struct {
 }
 
 // NewClaimsMappingPolicyClient returns a new ClaimsMappingPolicyClient
-func NewClaimsMappingPolicyClient() *ClaimsMappingPolicyClient {
+func NewClaimsMappingPolicyClient(opts ...ClientOption) *ClaimsMappingPolicyClient {
 	return &ClaimsMappingPolicyClient{
-		BaseClient: NewClient(Version10),
+		BaseClient: NewClient(Version10, opts...),
 	}
 }
 
@@ -25,6 +25,10 @@ func NewClaimsMappingPolicyClient() *ClaimsMappingPolicyClient {
 func (c *ClaimsMappingPolicyClient) Create(ctx context.Context, policy ClaimsMappingPolicy) (*ClaimsMappingPolicy, int, error) {
 	var status int
 
+	if err := validateClaimsMappingPolicy(policy); err != nil {
+		return nil, status, fmt.Errorf("ClaimsMappingPolicyClient.Create(): %v", err)
+	}
+
 	body, err := json.Marshal(policy)
 	if err != nil {
 		return nil, status, fmt.Errorf("json.Marshal(): %v", err)
@@ -126,6 +130,10 @@ func (c *ClaimsMappingPolicyClient) Update(ctx context.Context, claimsMappingPol
 		return status, fmt.Errorf("cannot update ClaimsMappingPolicy with nil ID")
 	}
 
+	if err := validateClaimsMappingPolicy(claimsMappingPolicy); err != nil {
+		return status, fmt.Errorf("ClaimsMappingPolicyClient.Update(): %v", err)
+	}
+
 	claimsMappingPolicyId := *claimsMappingPolicy.ID()
 	claimsMappingPolicy.Id = nil
 	claimsMappingPolicy.ObjectId = nil
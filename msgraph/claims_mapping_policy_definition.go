@@ -0,0 +1,375 @@
+package msgraph
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ClaimsMappingPolicyDefinition is a typed model of the Definition of a ClaimsMappingPolicy. Use Definition to encode
+// it for a ClaimsMappingPolicy, and ParseClaimsMappingPolicyDefinition to decode an existing policy.
+type ClaimsMappingPolicyDefinition struct {
+	// Version of the definition schema, which defaults to 1.
+	Version int
+
+	// IncludeBasicClaimSet includes the basic claims in tokens, in addition to those in ClaimsSchema.
+	IncludeBasicClaimSet bool
+
+	ClaimsSchema          []ClaimsSchemaEntry
+	ClaimsTransformations []ClaimsTransformation
+}
+
+// ClaimsSchemaEntry defines a claim emitted in tokens. The value comes from a Source and ID, such as the "employeeid"
+// of the "user", from the output of a transformation, or from a constant Value.
+type ClaimsSchemaEntry struct {
+	Source           ClaimsSchemaSource `json:"Source,omitempty"`
+	ID               string             `json:"ID,omitempty"`
+	ExtensionID      string             `json:"ExtensionID,omitempty"`
+	TransformationId string             `json:"TransformationId,omitempty"`
+	Value            string             `json:"Value,omitempty"`
+	SamlClaimType    string             `json:"SamlClaimType,omitempty"`
+	SamlNameFormat   string             `json:"SamlNameFormat,omitempty"`
+	JwtClaimType     string             `json:"JwtClaimType,omitempty"`
+}
+
+// ClaimsTransformation transforms the values of input claims to produce output claims. InputClaims reference the ID
+// of entries in ClaimsSchema, and OutputClaims reference the ID of ClaimsSchema entries whose Source is
+// ClaimsSchemaSourceTransformation and whose TransformationId is the ID of this transformation.
+type ClaimsTransformation struct {
+	ID                   string                          `json:"ID"`
+	TransformationMethod ClaimsTransformationMethod      `json:"TransformationMethod"`
+	InputClaims          []ClaimsTransformationClaim     `json:"InputClaims,omitempty"`
+	InputParameters      []ClaimsTransformationParameter `json:"InputParameters,omitempty"`
+	OutputClaims         []ClaimsTransformationClaim     `json:"OutputClaims,omitempty"`
+}
+
+type ClaimsTransformationClaim struct {
+	ClaimTypeReferenceId    string `json:"ClaimTypeReferenceId"`
+	TransformationClaimType string `json:"TransformationClaimType"`
+}
+
+type ClaimsTransformationParameter struct {
+	ID       string `json:"ID"`
+	Value    string `json:"Value"`
+	DataType string `json:"DataType,omitempty"`
+}
+
+// claimsTransformationMethodSpec describes the claim types and parameters accepted by a transformation method.
+type claimsTransformationMethodSpec struct {
+	inputClaims        []string
+	requiredInputs     []string
+	inputParameters    []string
+	requiredParameters []string
+}
+
+// claimsTransformationOutputClaim is the TransformationClaimType of the output claim of every method with a spec.
+const claimsTransformationOutputClaim = "outputClaim"
+
+var claimsTransformationMethodSpecs = map[ClaimsTransformationMethod]claimsTransformationMethodSpec{
+	ClaimsTransformationMethodExtractMailPrefix: {inputClaims: []string{"mail"}, requiredInputs: []string{"mail"}},
+	ClaimsTransformationMethodJoin:              {inputClaims: []string{"string1", "string2"}, requiredInputs: []string{"string1"}, inputParameters: []string{"string2", "separator"}, requiredParameters: []string{"separator"}},
+	ClaimsTransformationMethodRegexReplace:      {inputClaims: []string{"sourceClaim"}, requiredInputs: []string{"sourceClaim"}, inputParameters: []string{"regex", "replacement"}, requiredParameters: []string{"regex", "replacement"}},
+	ClaimsTransformationMethodToLowercase:       {inputClaims: []string{"string"}, requiredInputs: []string{"string"}},
+	ClaimsTransformationMethodToUppercase:       {inputClaims: []string{"string"}, requiredInputs: []string{"string"}},
+}
+
+// NewJoinTransformation returns a transformation joining two claims with a separator. When string2 is empty, the
+// separator and constant are appended to string1 instead, e.g. to append a domain.
+func NewJoinTransformation(id, separator, string1, string2, constant, output string) ClaimsTransformation {
+	inputClaims := []ClaimsTransformationClaim{{ClaimTypeReferenceId: string1, TransformationClaimType: "string1"}}
+	inputParameters := make([]ClaimsTransformationParameter, 0, 2)
+	if string2 != "" {
+		inputClaims = append(inputClaims, ClaimsTransformationClaim{ClaimTypeReferenceId: string2, TransformationClaimType: "string2"})
+	} else if constant != "" {
+		inputParameters = append(inputParameters, ClaimsTransformationParameter{ID: "string2", Value: constant})
+	}
+	inputParameters = append(inputParameters, ClaimsTransformationParameter{ID: "separator", Value: separator})
+	return ClaimsTransformation{
+		ID:                   id,
+		TransformationMethod: ClaimsTransformationMethodJoin,
+		InputClaims:          inputClaims,
+		InputParameters:      inputParameters,
+		OutputClaims:         []ClaimsTransformationClaim{{ClaimTypeReferenceId: output, TransformationClaimType: claimsTransformationOutputClaim}},
+	}
+}
+
+// NewExtractMailPrefixTransformation returns a transformation extracting the local part of an email address.
+func NewExtractMailPrefixTransformation(id, mail, output string) ClaimsTransformation {
+	return newSingleInputTransformation(id, ClaimsTransformationMethodExtractMailPrefix, "mail", mail, output)
+}
+
+// NewRegexReplaceTransformation returns a transformation replacing matches of a regular expression in a claim. The
+// replacement can reference named groups of the expression, e.g. "{user}".
+func NewRegexReplaceTransformation(id, source, regex, replacement, output string) ClaimsTransformation {
+	transformation := newSingleInputTransformation(id, ClaimsTransformationMethodRegexReplace, "sourceClaim", source, output)
+	transformation.InputParameters = []ClaimsTransformationParameter{
+		{ID: "regex", Value: regex},
+		{ID: "replacement", Value: replacement},
+	}
+	return transformation
+}
+
+// NewToLowercaseTransformation returns a transformation converting a claim to lowercase.
+func NewToLowercaseTransformation(id, input, output string) ClaimsTransformation {
+	return newSingleInputTransformation(id, ClaimsTransformationMethodToLowercase, "string", input, output)
+}
+
+// NewToUppercaseTransformation returns a transformation converting a claim to uppercase.
+func NewToUppercaseTransformation(id, input, output string) ClaimsTransformation {
+	return newSingleInputTransformation(id, ClaimsTransformationMethodToUppercase, "string", input, output)
+}
+
+func newSingleInputTransformation(id string, method ClaimsTransformationMethod, inputClaimType, input, output string) ClaimsTransformation {
+	return ClaimsTransformation{
+		ID:                   id,
+		TransformationMethod: method,
+		InputClaims:          []ClaimsTransformationClaim{{ClaimTypeReferenceId: input, TransformationClaimType: inputClaimType}},
+		OutputClaims:         []ClaimsTransformationClaim{{ClaimTypeReferenceId: output, TransformationClaimType: claimsTransformationOutputClaim}},
+	}
+}
+
+// claimsMappingPolicyDefinitionJSON is the encoding of a ClaimsMappingPolicyDefinition, in which IncludeBasicClaimSet
+// is a string.
+type claimsMappingPolicyDefinitionJSON struct {
+	Version               int                    `json:"Version"`
+	IncludeBasicClaimSet  json.RawMessage        `json:"IncludeBasicClaimSet,omitempty"`
+	ClaimsSchema          []ClaimsSchemaEntry    `json:"ClaimsSchema,omitempty"`
+	ClaimsTransformations []ClaimsTransformation `json:"ClaimsTransformations,omitempty"`
+}
+
+// Definition validates the definition and encodes it for use as the Definition of a ClaimsMappingPolicy.
+func (d ClaimsMappingPolicyDefinition) Definition() (*[]string, error) {
+	if err := d.Validate(); err != nil {
+		return nil, err
+	}
+
+	version := d.Version
+	if version == 0 {
+		version = 1
+	}
+	return policyDefinition("ClaimsMappingPolicy", claimsMappingPolicyDefinitionJSON{
+		Version:               version,
+		IncludeBasicClaimSet:  json.RawMessage(strconv.Quote(strconv.FormatBool(d.IncludeBasicClaimSet))),
+		ClaimsSchema:          d.ClaimsSchema,
+		ClaimsTransformations: d.ClaimsTransformations,
+	})
+}
+
+// ParseClaimsMappingPolicyDefinition decodes the Definition of a ClaimsMappingPolicy. It does not validate the
+// definition.
+func ParseClaimsMappingPolicyDefinition(definition *[]string) (*ClaimsMappingPolicyDefinition, error) {
+	if definition == nil || len(*definition) != 1 {
+		return nil, errors.New("definition must contain exactly one element")
+	}
+
+	var wrapper struct {
+		ClaimsMappingPolicy *claimsMappingPolicyDefinitionJSON `json:"ClaimsMappingPolicy"`
+	}
+	if err := json.Unmarshal([]byte((*definition)[0]), &wrapper); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+	if wrapper.ClaimsMappingPolicy == nil {
+		return nil, errors.New("definition has no ClaimsMappingPolicy")
+	}
+	data := wrapper.ClaimsMappingPolicy
+
+	result := ClaimsMappingPolicyDefinition{
+		Version:               data.Version,
+		ClaimsSchema:          data.ClaimsSchema,
+		ClaimsTransformations: data.ClaimsTransformations,
+	}
+	if len(data.IncludeBasicClaimSet) > 0 {
+		// the API accepts either a string or a boolean
+		value := strings.Trim(string(data.IncludeBasicClaimSet), `"`)
+		includeBasicClaimSet, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid IncludeBasicClaimSet %s", data.IncludeBasicClaimSet)
+		}
+		result.IncludeBasicClaimSet = includeBasicClaimSet
+	}
+
+	return &result, nil
+}
+
+// Validate checks that the definition is well formed: every claim has a valid source, every transformation referenced
+// by a claim exists, the input and output claims of every transformation reference claims in ClaimsSchema, and
+// transformations using the methods with constructors in this package have the expected claim types and parameters.
+// Sources are compared case-insensitively, and entries without a claim type are accepted when they are the input of a
+// transformation. All problems found are returned together.
+func (d ClaimsMappingPolicyDefinition) Validate() error {
+	problems := make([]error, 0)
+	problem := func(format string, a ...interface{}) {
+		problems = append(problems, fmt.Errorf(format, a...))
+	}
+
+	if d.Version != 0 && d.Version != 1 {
+		problem("unsupported Version %d", d.Version)
+	}
+
+	transformations := make(map[string]ClaimsTransformation)
+	for i, transformation := range d.ClaimsTransformations {
+		if transformation.ID == "" {
+			problem("ClaimsTransformations[%d]: ID is required", i)
+			continue
+		}
+		if _, ok := transformations[transformation.ID]; ok {
+			problem("ClaimsTransformations[%d]: duplicate ID %q", i, transformation.ID)
+		}
+		transformations[transformation.ID] = transformation
+	}
+
+	// entries without a claim type are not emitted, which is only useful as the input of a transformation
+	inputClaims := make(map[string]bool)
+	for _, transformation := range d.ClaimsTransformations {
+		for _, input := range transformation.InputClaims {
+			inputClaims[input.ClaimTypeReferenceId] = true
+		}
+	}
+
+	claims := make(map[string]ClaimsSchemaEntry)
+	jwtClaimTypes := make(map[string]bool)
+	samlClaimTypes := make(map[string]bool)
+	for i, entry := range d.ClaimsSchema {
+		name := fmt.Sprintf("ClaimsSchema[%d]", i)
+		if entry.ID != "" {
+			name = fmt.Sprintf("ClaimsSchema[%d] (%s)", i, entry.ID)
+			claims[entry.ID] = entry
+		}
+
+		switch ClaimsSchemaSource(strings.ToLower(string(entry.Source))) {
+		case "":
+			if entry.Value == "" {
+				problem("%s: either Source or Value is required", name)
+			}
+		case ClaimsSchemaSourceApplication, ClaimsSchemaSourceAudience, ClaimsSchemaSourceCompany, ClaimsSchemaSourceResource, ClaimsSchemaSourceUser:
+			if entry.ID == "" && entry.ExtensionID == "" {
+				problem("%s: ID is required for source %q", name, entry.Source)
+			}
+			if entry.TransformationId != "" {
+				problem("%s: TransformationId requires source %q", name, ClaimsSchemaSourceTransformation)
+			}
+		case ClaimsSchemaSourceTransformation:
+			if entry.ID == "" {
+				problem("%s: ID is required for source %q", name, entry.Source)
+			}
+			if entry.TransformationId == "" {
+				problem("%s: TransformationId is required for source %q", name, entry.Source)
+			} else if _, ok := transformations[entry.TransformationId]; !ok {
+				problem("%s: TransformationId %q does not match any ClaimsTransformation", name, entry.TransformationId)
+			}
+		default:
+			problem("%s: unsupported Source %q", name, entry.Source)
+		}
+		if entry.Source != "" && entry.Value != "" {
+			problem("%s: Value cannot be combined with Source", name)
+		}
+
+		if entry.JwtClaimType == "" && entry.SamlClaimType == "" && !inputClaims[entry.ID] {
+			problem("%s: either JwtClaimType or SamlClaimType is required", name)
+		}
+		if entry.JwtClaimType != "" {
+			if jwtClaimTypes[entry.JwtClaimType] {
+				problem("%s: duplicate JwtClaimType %q", name, entry.JwtClaimType)
+			}
+			jwtClaimTypes[entry.JwtClaimType] = true
+		}
+		if entry.SamlClaimType != "" {
+			if samlClaimTypes[entry.SamlClaimType] {
+				problem("%s: duplicate SamlClaimType %q", name, entry.SamlClaimType)
+			}
+			samlClaimTypes[entry.SamlClaimType] = true
+		}
+	}
+
+	for _, transformation := range d.ClaimsTransformations {
+		if transformation.ID == "" {
+			continue
+		}
+		name := fmt.Sprintf("ClaimsTransformations (%s)", transformation.ID)
+
+		if transformation.TransformationMethod == "" {
+			problem("%s: TransformationMethod is required", name)
+		}
+		if len(transformation.OutputClaims) == 0 {
+			problem("%s: at least one output claim is required", name)
+		}
+
+		for _, input := range transformation.InputClaims {
+			if _, ok := claims[input.ClaimTypeReferenceId]; !ok {
+				problem("%s: input claim %q does not match the ID of any claim in ClaimsSchema", name, input.ClaimTypeReferenceId)
+			}
+		}
+		for _, output := range transformation.OutputClaims {
+			entry, ok := claims[output.ClaimTypeReferenceId]
+			if !ok {
+				problem("%s: output claim %q does not match the ID of any claim in ClaimsSchema", name, output.ClaimTypeReferenceId)
+				continue
+			}
+			if !strings.EqualFold(string(entry.Source), string(ClaimsSchemaSourceTransformation)) || entry.TransformationId != transformation.ID {
+				problem("%s: output claim %q must have source %q and TransformationId %q", name, output.ClaimTypeReferenceId, ClaimsSchemaSourceTransformation, transformation.ID)
+			}
+		}
+
+		spec, ok := claimsTransformationMethodSpecs[transformation.TransformationMethod]
+		if !ok {
+			continue
+		}
+		inputTypes := make(map[string]bool)
+		for _, input := range transformation.InputClaims {
+			if !containsString(spec.inputClaims, input.TransformationClaimType) {
+				problem("%s: unexpected input TransformationClaimType %q for %s, expected one of %s", name, input.TransformationClaimType, transformation.TransformationMethod, strings.Join(spec.inputClaims, ", "))
+			}
+			inputTypes[input.TransformationClaimType] = true
+		}
+		for _, required := range spec.requiredInputs {
+			if !inputTypes[required] {
+				problem("%s: missing input claim with TransformationClaimType %q", name, required)
+			}
+		}
+		parameters := make(map[string]bool)
+		for _, parameter := range transformation.InputParameters {
+			if !containsString(spec.inputParameters, parameter.ID) {
+				problem("%s: unexpected input parameter %q for %s", name, parameter.ID, transformation.TransformationMethod)
+			}
+			parameters[parameter.ID] = true
+		}
+		for _, required := range spec.requiredParameters {
+			if !parameters[required] {
+				problem("%s: missing input parameter %q", name, required)
+			}
+		}
+		for _, output := range transformation.OutputClaims {
+			if output.TransformationClaimType != claimsTransformationOutputClaim {
+				problem("%s: unexpected output TransformationClaimType %q, expected %q", name, output.TransformationClaimType, claimsTransformationOutputClaim)
+			}
+		}
+	}
+
+	return errors.Join(problems...)
+}
+
+// validateClaimsMappingPolicy parses and validates the definition of a ClaimsMappingPolicy, when it has one.
+func validateClaimsMappingPolicy(policy ClaimsMappingPolicy) error {
+	if policy.Definition == nil {
+		return nil
+	}
+	definition, err := ParseClaimsMappingPolicyDefinition(policy.Definition)
+	if err != nil {
+		return fmt.Errorf("parsing definition: %v", err)
+	}
+	if err := definition.Validate(); err != nil {
+		return fmt.Errorf("invalid definition: %v", err)
+	}
+	return nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
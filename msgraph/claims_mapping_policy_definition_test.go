@@ -0,0 +1,229 @@
+package msgraph
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/manicminer/hamilton/internal/utils"
+)
+
+// documentedJoinDefinition is the Join example from the Microsoft Graph claims mapping policy documentation
+const documentedJoinDefinition = `{"ClaimsMappingPolicy":{"Version":1,"IncludeBasicClaimSet":"true","ClaimsSchema":[` +
+	`{"Source":"User","ID":"extensionattribute1"},` +
+	`{"Source":"Transformation","ID":"DataJoin","TransformationId":"JoinTheData","JwtClaimType":"JoinedData"}],` +
+	`"ClaimsTransformations":[{"ID":"JoinTheData","TransformationMethod":"Join",` +
+	`"InputClaims":[{"ClaimTypeReferenceId":"extensionattribute1","TransformationClaimType":"string1"}],` +
+	`"InputParameters":[{"ID":"string2","Value":"sandbox"},{"ID":"separator","Value":"."}],` +
+	`"OutputClaims":[{"ClaimTypeReferenceId":"DataJoin","TransformationClaimType":"outputClaim"}]}]}}`
+
+func TestClaimsMappingPolicyDefinition(t *testing.T) {
+	definition := ClaimsMappingPolicyDefinition{
+		IncludeBasicClaimSet: true,
+		ClaimsSchema: []ClaimsSchemaEntry{
+			{Source: ClaimsSchemaSourceUser, ID: "employeeid", JwtClaimType: "employee_id"},
+			{Source: ClaimsSchemaSourceUser, ID: "mail"},
+			{Source: ClaimsSchemaSourceTransformation, ID: "alias", TransformationId: "ExtractAlias", JwtClaimType: "alias"},
+			{Value: "contoso", JwtClaimType: "org"},
+		},
+		ClaimsTransformations: []ClaimsTransformation{
+			NewExtractMailPrefixTransformation("ExtractAlias", "mail", "alias"),
+		},
+	}
+
+	encoded, err := definition.Definition()
+	if err != nil {
+		t.Fatalf("Definition(): %v", err)
+	}
+	expected := `{"ClaimsMappingPolicy":{"Version":1,"IncludeBasicClaimSet":"true","ClaimsSchema":[` +
+		`{"Source":"user","ID":"employeeid","JwtClaimType":"employee_id"},` +
+		`{"Source":"user","ID":"mail"},` +
+		`{"Source":"transformation","ID":"alias","TransformationId":"ExtractAlias","JwtClaimType":"alias"},` +
+		`{"Value":"contoso","JwtClaimType":"org"}],` +
+		`"ClaimsTransformations":[{"ID":"ExtractAlias","TransformationMethod":"ExtractMailPrefix",` +
+		`"InputClaims":[{"ClaimTypeReferenceId":"mail","TransformationClaimType":"mail"}],` +
+		`"OutputClaims":[{"ClaimTypeReferenceId":"alias","TransformationClaimType":"outputClaim"}]}]}}`
+	if (*encoded)[0] != expected {
+		t.Fatalf("unexpected definition:\n%s\nexpected:\n%s", (*encoded)[0], expected)
+	}
+
+	parsed, err := ParseClaimsMappingPolicyDefinition(encoded)
+	if err != nil {
+		t.Fatalf("ParseClaimsMappingPolicyDefinition(): %v", err)
+	}
+	if !parsed.IncludeBasicClaimSet || parsed.Version != 1 || len(parsed.ClaimsSchema) != 4 || len(parsed.ClaimsTransformations) != 1 {
+		t.Errorf("unexpected parsed definition: %+v", parsed)
+	}
+
+	// definitions written by hand may use a boolean
+	parsed, err = ParseClaimsMappingPolicyDefinition(&[]string{`{"ClaimsMappingPolicy":{"Version":1,"IncludeBasicClaimSet":false}}`})
+	if err != nil {
+		t.Fatalf("ParseClaimsMappingPolicyDefinition(): %v", err)
+	}
+	if parsed.IncludeBasicClaimSet {
+		t.Errorf("expected IncludeBasicClaimSet to be false")
+	}
+}
+
+func TestClaimsMappingPolicyDefinition_Validate(t *testing.T) {
+	// the definition used by the claims mapping policy acceptance test
+	existing := `{"ClaimsMappingPolicy":{"Version":1,"IncludeBasicClaimSet":"true","ClaimsSchema":[` +
+		`{"Source":"user","ID":"employeeid","SamlClaimType":"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/employeeid","JwtClaimType":"employeeid"},` +
+		`{"Source":"company","ID":"tenantcountry","SamlClaimType":"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/country","JwtClaimType":"country"}]}}`
+	definition, err := ParseClaimsMappingPolicyDefinition(&[]string{existing})
+	if err != nil {
+		t.Fatalf("ParseClaimsMappingPolicyDefinition(): %v", err)
+	}
+	if err := definition.Validate(); err != nil {
+		t.Errorf("Validate(): %v", err)
+	}
+
+	for _, tc := range []struct {
+		name       string
+		definition ClaimsMappingPolicyDefinition
+		expected   string
+	}{
+		{
+			name: "unsupported source",
+			definition: ClaimsMappingPolicyDefinition{ClaimsSchema: []ClaimsSchemaEntry{
+				{Source: "tenant", ID: "country", JwtClaimType: "country"},
+			}},
+			expected: `unsupported Source "tenant"`,
+		},
+		{
+			name: "missing claim type",
+			definition: ClaimsMappingPolicyDefinition{ClaimsSchema: []ClaimsSchemaEntry{
+				{Source: ClaimsSchemaSourceUser, ID: "employeeid"},
+			}},
+			expected: `ClaimsSchema[0] (employeeid): either JwtClaimType or SamlClaimType is required`,
+		},
+		{
+			name: "duplicate claim type",
+			definition: ClaimsMappingPolicyDefinition{ClaimsSchema: []ClaimsSchemaEntry{
+				{Source: ClaimsSchemaSourceUser, ID: "employeeid", JwtClaimType: "id"},
+				{Source: ClaimsSchemaSourceUser, ID: "extensionattribute1", JwtClaimType: "id"},
+			}},
+			expected: `duplicate JwtClaimType "id"`,
+		},
+		{
+			name: "missing transformation",
+			definition: ClaimsMappingPolicyDefinition{ClaimsSchema: []ClaimsSchemaEntry{
+				{Source: ClaimsSchemaSourceTransformation, ID: "alias", TransformationId: "ExtractAlias", JwtClaimType: "alias"},
+			}},
+			expected: `TransformationId "ExtractAlias" does not match any ClaimsTransformation`,
+		},
+		{
+			name: "unknown input claim",
+			definition: ClaimsMappingPolicyDefinition{
+				ClaimsSchema: []ClaimsSchemaEntry{
+					{Source: ClaimsSchemaSourceTransformation, ID: "upper", TransformationId: "Upper", JwtClaimType: "upper"},
+				},
+				ClaimsTransformations: []ClaimsTransformation{NewToUppercaseTransformation("Upper", "department", "upper")},
+			},
+			expected: `input claim "department" does not match`,
+		},
+		{
+			name: "output claim from another source",
+			definition: ClaimsMappingPolicyDefinition{
+				ClaimsSchema: []ClaimsSchemaEntry{
+					{Source: ClaimsSchemaSourceUser, ID: "department", JwtClaimType: "department"},
+				},
+				ClaimsTransformations: []ClaimsTransformation{NewToLowercaseTransformation("Lower", "department", "department")},
+			},
+			expected: `output claim "department" must have source "transformation"`,
+		},
+		{
+			name: "missing parameter",
+			definition: ClaimsMappingPolicyDefinition{
+				ClaimsSchema: []ClaimsSchemaEntry{
+					{Source: ClaimsSchemaSourceUser, ID: "userprincipalname"},
+					{Source: ClaimsSchemaSourceTransformation, ID: "name", TransformationId: "Replace", JwtClaimType: "name"},
+				},
+				ClaimsTransformations: []ClaimsTransformation{{
+					ID:                   "Replace",
+					TransformationMethod: ClaimsTransformationMethodRegexReplace,
+					InputClaims:          []ClaimsTransformationClaim{{ClaimTypeReferenceId: "userprincipalname", TransformationClaimType: "sourceClaim"}},
+					InputParameters:      []ClaimsTransformationParameter{{ID: "regex", Value: "@.*$"}},
+					OutputClaims:         []ClaimsTransformationClaim{{ClaimTypeReferenceId: "name", TransformationClaimType: "outputClaim"}},
+				}},
+			},
+			expected: `missing input parameter "replacement"`,
+		},
+	} {
+		err := tc.definition.Validate()
+		if err == nil {
+			t.Errorf("%s: expected an error", tc.name)
+			continue
+		}
+		if !strings.Contains(err.Error(), tc.expected) {
+			t.Errorf("%s: unexpected error %q, expected %q", tc.name, err, tc.expected)
+		}
+	}
+}
+
+func TestClaimsMappingPolicyDefinition_Join(t *testing.T) {
+	definition, err := ParseClaimsMappingPolicyDefinition(&[]string{documentedJoinDefinition})
+	if err != nil {
+		t.Fatalf("ParseClaimsMappingPolicyDefinition(): %v", err)
+	}
+	if err := definition.Validate(); err != nil {
+		t.Errorf("Validate(): %v", err)
+	}
+
+	transformation := NewJoinTransformation("JoinTheData", ".", "extensionattribute1", "", "sandbox", "DataJoin")
+	if !reflect.DeepEqual(transformation, definition.ClaimsTransformations[0]) {
+		t.Errorf("NewJoinTransformation(): got %+v, expected %+v", transformation, definition.ClaimsTransformations[0])
+	}
+
+	transformation = NewJoinTransformation("JoinNames", " ", "givenname", "surname", "", "fullname")
+	if len(transformation.InputClaims) != 2 || len(transformation.InputParameters) != 1 || transformation.InputParameters[0].ID != "separator" {
+		t.Errorf("NewJoinTransformation(): unexpected transformation joining two claims: %+v", transformation)
+	}
+}
+
+func TestClaimsMappingPolicyClient_CreateInvalid(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer ts.Close()
+
+	client := NewClaimsMappingPolicyClient(WithEndpoint(ts.URL), WithRetryMax(0))
+	policy := ClaimsMappingPolicy{
+		DirectoryObject: DirectoryObject{Id: utils.StringPtr("11111111-1111-1111-1111-111111111111")},
+		DisplayName:     utils.StringPtr("invalid"),
+		Definition:      &[]string{`{"ClaimsMappingPolicy":{"Version":1,"ClaimsSchema":[{"Source":"user","ID":"employeeid"}]}}`},
+	}
+
+	if _, _, err := client.Create(context.Background(), policy); err == nil || !strings.Contains(err.Error(), "invalid definition") {
+		t.Errorf("Create(): expected an invalid definition error, got: %v", err)
+	}
+	if _, err := client.Update(context.Background(), policy); err == nil || !strings.Contains(err.Error(), "invalid definition") {
+		t.Errorf("Update(): expected an invalid definition error, got: %v", err)
+	}
+}
+
+func TestClaimsMappingPolicyClient_CreateJoin(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"11111111-1111-1111-1111-111111111111","displayName":"join"}`))
+	}))
+	defer ts.Close()
+
+	policy, _, err := NewClaimsMappingPolicyClient(WithEndpoint(ts.URL), WithRetryMax(0)).Create(context.Background(), ClaimsMappingPolicy{
+		DisplayName: utils.StringPtr("join"),
+		Definition:  &[]string{documentedJoinDefinition},
+	})
+	if err != nil {
+		t.Fatalf("Create(): %v", err)
+	}
+	if requests != 1 || policy == nil || policy.ID() == nil {
+		t.Errorf("expected the policy to be created, got %d requests and policy %+v", requests, policy)
+	}
+}
@@ -9,11 +9,13 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 
 	"github.com/hashicorp/go-azure-sdk/sdk/auth"
 	"github.com/hashicorp/go-azure-sdk/sdk/environments"
 	"github.com/hashicorp/go-azure-sdk/sdk/odata"
 	"github.com/hashicorp/go-retryablehttp"
+	"github.com/manicminer/hamilton/errors"
 )
 
 type ApiVersion string
@@ -71,6 +73,9 @@ type Client struct {
 	// Endpoint is the base endpoint for Microsoft Graph, usually "https://graph.microsoft.com".
 	Endpoint string
 
+	// Environment is the national cloud environment for the client, used to determine feature availability.
+	Environment *environments.Environment
+
 	// ApiVersion is the Microsoft Graph API version to use.
 	ApiVersion ApiVersion
 
@@ -91,28 +96,131 @@ type Client struct {
 	ResponseMiddlewares *[]ResponseMiddleware
 
 	// HttpClient is the underlying http.Client, which by default uses a retryable client
-	HttpClient      *http.Client
+	HttpClient *http.Client
+
+	// RetryableClient is the retryable client used by HttpClient. When a RetryableClient without a CheckRetry policy is
+	// assigned, the default policy handling eventual consistency failures is installed on the first request. A custom
+	// CheckRetry policy should be wrapped with ConsistencyRetryPolicy to retain that handling.
 	RetryableClient *retryablehttp.Client
+
+	// feature, when set, is checked for availability in the configured environment before sending requests
+	feature Feature
+
+	// requestLimiter, when set, limits the number of concurrent requests sent by all clients sharing it
+	requestLimiter chan struct{}
+}
+
+type retryContextKey struct{}
+
+// retryContext holds the per-request settings used when evaluating whether a request should be retried.
+// These are passed via the request context so that the retryable client can be shared between clients and requests.
+type retryContext struct {
+	consistencyFailureFunc ConsistencyFailureFunc
+	disableRetries         bool
+}
+
+// ConsistencyRetryPolicy wraps a retry policy so that requests failing due to eventual consistency are also retried.
+// Clients install it by default. Use it when assigning a custom CheckRetry to the RetryableClient of a Client, to retain
+// the handling of eventual consistency failures.
+func ConsistencyRetryPolicy(next retryablehttp.CheckRetry) retryablehttp.CheckRetry {
+	return func(ctx context.Context, resp *http.Response, err error) (bool, error) {
+		if rc, ok := ctx.Value(retryContextKey{}).(retryContext); ok && resp != nil && !rc.disableRetries {
+			if resp.StatusCode == http.StatusFailedDependency {
+				return true, nil
+			}
+
+			o, err := odata.FromResponse(resp)
+			if err != nil {
+				return false, err
+			}
+
+			if f := rc.consistencyFailureFunc; f != nil && f(resp, o) {
+				return true, nil
+			}
+		}
+		return next(ctx, resp, err)
+	}
 }
 
-// NewClient returns a new Client configured with the specified API version and tenant ID.
-func NewClient(apiVersion ApiVersion) Client {
+// checkRetry is the retry policy for all requests, which additionally handles eventual consistency failures.
+var checkRetry = ConsistencyRetryPolicy(retryablehttp.DefaultRetryPolicy)
+
+// checkRetryLock guards the installation of checkRetry on retryable clients assigned by callers.
+var checkRetryLock sync.Mutex
+
+// NewClient returns a new Client configured with the specified API version, which can be overridden with options.
+func NewClient(apiVersion ApiVersion, opts ...ClientOption) Client {
 	r := retryablehttp.NewClient()
+	r.CheckRetry = checkRetry
 	r.ErrorHandler = RetryableErrorHandler
 	r.Logger = nil
 
+	env := environments.AzurePublic()
+
 	var endpoint string
-	if defaultEndpoint, _ := environments.AzurePublic().MicrosoftGraph.Endpoint(); defaultEndpoint != nil {
+	if defaultEndpoint, _ := env.MicrosoftGraph.Endpoint(); defaultEndpoint != nil {
 		endpoint = *defaultEndpoint
 	}
 
-	return Client{
+	c := Client{
 		Endpoint:        endpoint,
+		Environment:     env,
 		ApiVersion:      apiVersion,
 		UserAgent:       "Hamilton (Go-http-client/1.1)",
 		HttpClient:      r.StandardClient(),
 		RetryableClient: r,
 	}
+
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	return c
+}
+
+// copyRetryableClient returns a new retryablehttp.Client with the same configuration and underlying http.Client.
+func copyRetryableClient(r *retryablehttp.Client) *retryablehttp.Client {
+	return &retryablehttp.Client{
+		HTTPClient:      r.HTTPClient,
+		Logger:          r.Logger,
+		RetryWaitMin:    r.RetryWaitMin,
+		RetryWaitMax:    r.RetryWaitMax,
+		RetryMax:        r.RetryMax,
+		RequestLogHook:  r.RequestLogHook,
+		ResponseLogHook: r.ResponseLogHook,
+		CheckRetry:      r.CheckRetry,
+		Backoff:         r.Backoff,
+		ErrorHandler:    r.ErrorHandler,
+	}
+}
+
+// requireBeta returns an error when the client is not configured for the beta API, for operations that are only available in beta.
+func (c Client) requireBeta(operation string) error {
+	if c.ApiVersion != VersionBeta {
+		return errors.UnsupportedApiVersionError{
+			Operation:  operation,
+			ApiVersion: string(c.ApiVersion),
+			Required:   string(VersionBeta),
+		}
+	}
+	return nil
+}
+
+// validateFields returns an error when the model has populated fields that are not available in the API version configured for the client.
+// Fields that are only available in the beta API are tagged with `api:"beta"`.
+func (c Client) validateFields(operation string, model interface{}) error {
+	if c.ApiVersion == VersionBeta {
+		return nil
+	}
+	if fields := betaOnlyFields(model); len(fields) > 0 {
+		return errors.UnsupportedApiVersionError{
+			Operation:  operation,
+			ApiVersion: string(c.ApiVersion),
+			Required:   string(VersionBeta),
+			Fields:     fields,
+		}
+	}
+	return nil
 }
 
 // buildUri is used by the package to build a complete URI string for API requests.
@@ -133,6 +241,10 @@ func (c Client) buildUri(uri Uri) (string, error) {
 func (c Client) performRequest(req *http.Request, input HttpRequestInput) (*http.Response, int, *odata.OData, error) {
 	var status int
 
+	if err := c.checkFeature(); err != nil {
+		return nil, status, nil, err
+	}
+
 	query := input.GetOData()
 	req.Header = query.AppendHeaders(req.Header)
 	req.Header.Add("Content-Type", input.GetContentType())
@@ -161,23 +273,17 @@ func (c Client) performRequest(req *http.Request, input HttpRequestInput) (*http
 		}
 	}
 
-	c.RetryableClient.CheckRetry = func(ctx context.Context, resp *http.Response, err error) (bool, error) {
-		if resp != nil && !c.DisableRetries {
-			if resp.StatusCode == http.StatusFailedDependency {
-				return true, nil
-			}
-
-			o, err = odata.FromResponse(resp)
-			if err != nil {
-				return false, err
-			}
+	req = req.WithContext(context.WithValue(req.Context(), retryContextKey{}, retryContext{
+		consistencyFailureFunc: input.GetConsistencyFailureFunc(),
+		disableRetries:         c.DisableRetries,
+	}))
 
-			f := input.GetConsistencyFailureFunc()
-			if f != nil && f(resp, o) {
-				return true, nil
-			}
+	if c.RetryableClient != nil {
+		checkRetryLock.Lock()
+		if c.RetryableClient.CheckRetry == nil {
+			c.RetryableClient.CheckRetry = checkRetry
 		}
-		return retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+		checkRetryLock.Unlock()
 	}
 
 	req.Body = io.NopCloser(bytes.NewBuffer(reqBody))
@@ -192,6 +298,15 @@ func (c Client) performRequest(req *http.Request, input HttpRequestInput) (*http
 		}
 	}
 
+	if c.requestLimiter != nil {
+		select {
+		case c.requestLimiter <- struct{}{}:
+			defer func() { <-c.requestLimiter }()
+		case <-req.Context().Done():
+			return nil, status, nil, req.Context().Err()
+		}
+	}
+
 	resp, err = c.HttpClient.Do(req)
 	if err != nil {
 		return nil, status, nil, err
@@ -439,6 +554,7 @@ type PatchHttpRequestInput struct {
 	ConsistencyFailureFunc ConsistencyFailureFunc
 	Body                   []byte
 	OData                  odata.Query
+	Prefer                 string
 	ValidStatusCodes       []int
 	ValidStatusFunc        ValidStatusFunc
 	Uri                    Uri
@@ -480,6 +596,9 @@ func (c Client) Patch(ctx context.Context, input PatchHttpRequestInput) (*http.R
 	if err != nil {
 		return nil, status, nil, err
 	}
+	if input.Prefer != "" {
+		req.Header.Set("Prefer", input.Prefer)
+	}
 	resp, status, o, err := c.performRequest(req, input)
 	if err != nil {
 		return nil, status, o, err
@@ -0,0 +1,125 @@
+package msgraph
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/auth"
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+)
+
+// ClientOption configures a Client when it is constructed.
+type ClientOption func(*Client)
+
+// WithApiVersion overrides the default API version for a client.
+func WithApiVersion(apiVersion ApiVersion) ClientOption {
+	return func(c *Client) {
+		c.ApiVersion = apiVersion
+	}
+}
+
+// WithAuthorizer configures the Authorizer used to obtain access tokens for requests.
+func WithAuthorizer(authorizer auth.Authorizer) ClientOption {
+	return func(c *Client) {
+		c.Authorizer = authorizer
+	}
+}
+
+// WithEndpoint overrides the base endpoint for Microsoft Graph.
+func WithEndpoint(endpoint string) ClientOption {
+	return func(c *Client) {
+		c.Endpoint = endpoint
+	}
+}
+
+// WithEnvironment configures the Microsoft Graph endpoint for the specified national cloud environment. Operations
+// relying on features that are known to be unavailable in the environment will return an error.
+func WithEnvironment(env environments.Environment) ClientOption {
+	return func(c *Client) {
+		c.Environment = &env
+		if env.MicrosoftGraph == nil {
+			return
+		}
+		if endpoint, ok := env.MicrosoftGraph.Endpoint(); ok && endpoint != nil {
+			c.Endpoint = *endpoint
+		}
+	}
+}
+
+// WithUserAgent overrides the HTTP user agent string sent in requests.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Client) {
+		c.UserAgent = userAgent
+	}
+}
+
+// WithRetryMax sets the maximum number of retries for failed requests.
+func WithRetryMax(retryMax int) ClientOption {
+	return func(c *Client) {
+		c.RetryableClient.RetryMax = retryMax
+	}
+}
+
+// WithRetryWait sets the minimum and maximum time to wait between retries. When a request is throttled, the
+// Retry-After header returned by the API takes precedence over these values.
+func WithRetryWait(min, max time.Duration) ClientOption {
+	return func(c *Client) {
+		c.RetryableClient.RetryWaitMin = min
+		c.RetryableClient.RetryWaitMax = max
+	}
+}
+
+// WithDisableRetries prevents the client from reattempting requests that fail due to eventual consistency.
+// This does not impact handling of retries related to rate limiting, which are always performed.
+func WithDisableRetries() ClientOption {
+	return func(c *Client) {
+		c.DisableRetries = true
+	}
+}
+
+// WithMaxConcurrentRequests limits the number of requests that can be in flight at the same time, across all clients
+// sharing the same configuration. This can be used to reduce the likelihood of being throttled by the API.
+func WithMaxConcurrentRequests(n int) ClientOption {
+	return func(c *Client) {
+		if n > 0 {
+			c.requestLimiter = make(chan struct{}, n)
+		}
+	}
+}
+
+// WithRequestMiddlewares appends functions that are called in order before a request is sent.
+func WithRequestMiddlewares(middlewares ...RequestMiddleware) ClientOption {
+	return func(c *Client) {
+		m := middlewares
+		if c.RequestMiddlewares != nil {
+			m = append(append([]RequestMiddleware{}, *c.RequestMiddlewares...), middlewares...)
+		}
+		c.RequestMiddlewares = &m
+	}
+}
+
+// WithResponseMiddlewares appends functions that are called in order before a response is parsed and returned.
+func WithResponseMiddlewares(middlewares ...ResponseMiddleware) ClientOption {
+	return func(c *Client) {
+		m := middlewares
+		if c.ResponseMiddlewares != nil {
+			m = append(append([]ResponseMiddleware{}, *c.ResponseMiddlewares...), middlewares...)
+		}
+		c.ResponseMiddlewares = &m
+	}
+}
+
+// WithLogger configures a logger for the underlying retryable HTTP client, which logs requests and retries.
+// The logger should be either a retryablehttp.Logger or a retryablehttp.LeveledLogger.
+func WithLogger(logger interface{}) ClientOption {
+	return func(c *Client) {
+		c.RetryableClient.Logger = logger
+	}
+}
+
+// WithTransport overrides the HTTP transport used to send requests. Retries are still handled by the client.
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(c *Client) {
+		c.RetryableClient.HTTPClient = &http.Client{Transport: transport}
+	}
+}
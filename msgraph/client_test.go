@@ -2,6 +2,7 @@ package msgraph
 
 import (
 	"context"
+	goerrors "errors"
 	"fmt"
 	"log"
 	"net"
@@ -10,6 +11,12 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/manicminer/hamilton/errors"
+	"github.com/manicminer/hamilton/internal/utils"
 )
 
 func TestClient_GetWithError(t *testing.T) {
@@ -70,3 +77,125 @@ func TestClient_GetWithResponseAndError(t *testing.T) {
 		log.Fatalf("got %s, want message with 'stopped after 10 redirects'", msg)
 	}
 }
+
+func TestClient_CustomRetryableClient(t *testing.T) {
+	attempts := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	for _, tc := range []struct {
+		name       string
+		checkRetry retryablehttp.CheckRetry
+	}{
+		{"no policy", nil},
+		{"wrapped policy", ConsistencyRetryPolicy(retryablehttp.DefaultRetryPolicy)},
+	} {
+		attempts = 0
+
+		r := &retryablehttp.Client{
+			HTTPClient:   http.DefaultClient,
+			RetryWaitMin: time.Millisecond,
+			RetryWaitMax: time.Millisecond,
+			RetryMax:     3,
+			Backoff:      retryablehttp.DefaultBackoff,
+			CheckRetry:   tc.checkRetry,
+		}
+		hc := NewClient(VersionBeta, WithEndpoint(ts.URL))
+		hc.RetryableClient = r
+		hc.HttpClient = r.StandardClient()
+
+		_, status, _, err := hc.Get(context.Background(), GetHttpRequestInput{
+			ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+			ValidStatusCodes:       []int{http.StatusOK},
+			Uri: Uri{
+				Entity: "/users/test",
+			},
+		})
+		if err != nil {
+			t.Errorf("%s: expected the consistency failures to be retried, got: %v", tc.name, err)
+		}
+		if status != http.StatusOK || attempts != 3 {
+			t.Errorf("%s: got status %d after %d attempts, want %d after 3", tc.name, status, attempts, http.StatusOK)
+		}
+	}
+}
+
+func TestClient_WithApiVersion(t *testing.T) {
+	if c := NewUsersClient(); c.BaseClient.ApiVersion != VersionBeta {
+		t.Fatalf("got API version %q, want %q", c.BaseClient.ApiVersion, VersionBeta)
+	}
+	if c := NewUsersClient(WithApiVersion(Version10)); c.BaseClient.ApiVersion != Version10 {
+		t.Fatalf("got API version %q, want %q", c.BaseClient.ApiVersion, Version10)
+	}
+}
+
+func TestClient_BetaOnlyOperations(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request sent to %s", r.URL.Path)
+	}))
+	defer ts.Close()
+
+	reports := NewReportsClient(WithApiVersion(Version10))
+	reports.BaseClient.Endpoint = ts.URL
+
+	_, _, err := reports.GetCredentialUserRegistrationDetails(context.Background(), odata.Query{})
+	var versionErr errors.UnsupportedApiVersionError
+	if !goerrors.As(err, &versionErr) {
+		t.Fatalf("expected UnsupportedApiVersionError, got: %v", err)
+	}
+
+	users := NewUsersClient(WithApiVersion(Version10))
+	users.BaseClient.Endpoint = ts.URL
+
+	_, _, err = users.Create(context.Background(), User{
+		DisplayName:            utils.StringPtr("test-user"),
+		IsManagementRestricted: utils.BoolPtr(true),
+	})
+	if !goerrors.As(err, &versionErr) {
+		t.Fatalf("expected UnsupportedApiVersionError, got: %v", err)
+	}
+	if len(versionErr.Fields) != 1 || versionErr.Fields[0] != "isManagementRestricted" {
+		t.Fatalf("unexpected fields in error: %v", versionErr.Fields)
+	}
+
+	servicePrincipals := NewServicePrincipalsClient(WithApiVersion(Version10))
+	servicePrincipals.BaseClient.Endpoint = ts.URL
+
+	servicePrincipal := ServicePrincipal{
+		DirectoryObject:           DirectoryObject{Id: utils.StringPtr("00000000-0000-0000-0000-000000000000")},
+		PublishedPermissionScopes: &[]PermissionScope{{Value: utils.StringPtr("user_impersonation")}},
+	}
+	if _, _, err = servicePrincipals.Create(context.Background(), servicePrincipal); !goerrors.As(err, &versionErr) {
+		t.Fatalf("ServicePrincipalsClient.Create(): expected UnsupportedApiVersionError, got: %v", err)
+	}
+	if _, err = servicePrincipals.Update(context.Background(), servicePrincipal); !goerrors.As(err, &versionErr) {
+		t.Fatalf("ServicePrincipalsClient.Update(): expected UnsupportedApiVersionError, got: %v", err)
+	}
+	if len(versionErr.Fields) != 1 || versionErr.Fields[0] != "publishedPermissionScopes" {
+		t.Fatalf("unexpected fields in error: %v", versionErr.Fields)
+	}
+}
+
+func TestBetaOnlyFields(t *testing.T) {
+	group := Group{
+		DisplayName: utils.StringPtr("test-group"),
+		WritebackConfiguration: &GroupWritebackConfiguration{
+			IsEnabled: utils.BoolPtr(true),
+		},
+	}
+	if fields := betaOnlyFields(group); len(fields) != 1 || fields[0] != "writebackConfiguration" {
+		t.Fatalf("unexpected beta-only fields for group: %v", fields)
+	}
+
+	group.WritebackConfiguration = nil
+	if fields := betaOnlyFields(&group); len(fields) != 0 {
+		t.Fatalf("unexpected beta-only fields for group: %v", fields)
+	}
+}
@@ -17,9 +17,9 @@ type ConditionalAccessPoliciesClient struct {
 }
 
 // NewConditionalAccessPoliciesClient returns a new ConditionalAccessPoliciesClient
-func NewConditionalAccessPoliciesClient() *ConditionalAccessPoliciesClient {
+func NewConditionalAccessPoliciesClient(opts ...ClientOption) *ConditionalAccessPoliciesClient {
 	return &ConditionalAccessPoliciesClient{
-		BaseClient: NewClient(VersionBeta),
+		BaseClient: NewClient(VersionBeta, opts...),
 	}
 }
 
@@ -16,9 +16,9 @@ type ConnectedOrganizationClient struct {
 	BaseClient Client
 }
 
-func NewConnectedOrganizationClient() *ConnectedOrganizationClient {
+func NewConnectedOrganizationClient(opts ...ClientOption) *ConnectedOrganizationClient {
 	return &ConnectedOrganizationClient{
-		BaseClient: NewClient(Version10),
+		BaseClient: NewClient(Version10, opts...).withFeature(FeatureEntitlementManagement),
 	}
 }
 
@@ -0,0 +1,298 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+)
+
+const (
+	// DefaultRotatedPasswordDisplayName identifies the password credentials managed by RotatePassword when no
+	// DisplayName is specified.
+	DefaultRotatedPasswordDisplayName = "hamilton-rotation"
+
+	// DefaultPasswordRotationValidity is the lifetime of password credentials added by RotatePassword when no Validity
+	// is specified.
+	DefaultPasswordRotationValidity = 60 * 24 * time.Hour
+
+	// DefaultPasswordRotationOverlap is the remaining lifetime of the current password credential below which
+	// RotatePassword adds a new one, when no Overlap is specified.
+	DefaultPasswordRotationOverlap = 30 * 24 * time.Hour
+
+	// DefaultPasswordRotationGracePeriod is how long RotatePassword retains a superseded password credential after its
+	// replacement was added, when no GracePeriod is specified.
+	DefaultPasswordRotationGracePeriod = 7 * 24 * time.Hour
+)
+
+// RotatedSecret is a newly added password credential as handed to a SecretSink.
+type RotatedSecret struct {
+	ObjectId      string    `json:"objectId"`
+	KeyId         string    `json:"keyId"`
+	DisplayName   string    `json:"displayName"`
+	SecretText    string    `json:"secretText"`
+	StartDateTime time.Time `json:"startDateTime"`
+	EndDateTime   time.Time `json:"endDateTime"`
+}
+
+// SecretSink receives the secret of each password credential added by RotatePassword. The secret cannot be retrieved
+// again later, so when StoreSecret returns an error the new credential is removed and rotation is abandoned.
+type SecretSink interface {
+	StoreSecret(ctx context.Context, secret RotatedSecret) error
+}
+
+// SecretSinkFunc adapts a function to a SecretSink.
+type SecretSinkFunc func(ctx context.Context, secret RotatedSecret) error
+
+// StoreSecret calls f(ctx, secret).
+func (f SecretSinkFunc) StoreSecret(ctx context.Context, secret RotatedSecret) error {
+	return f(ctx, secret)
+}
+
+// FileSecretSink returns a SecretSink that writes each secret as JSON to the file at path, replacing any previous
+// contents. The file is created with permissions 0600.
+func FileSecretSink(path string) SecretSink {
+	return fileSecretSink{path: path}
+}
+
+type fileSecretSink struct {
+	path string
+}
+
+func (s fileSecretSink) StoreSecret(_ context.Context, secret RotatedSecret) error {
+	data, err := json.MarshalIndent(secret, "", "  ")
+	if err != nil {
+		return fmt.Errorf("json.MarshalIndent(): %v", err)
+	}
+
+	// write to a temporary file first so that an existing secret is never left truncated
+	f, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return fmt.Errorf("os.CreateTemp(): %v", err)
+	}
+	defer os.Remove(f.Name())
+
+	if err := f.Chmod(0600); err != nil {
+		f.Close()
+		return fmt.Errorf("chmod %s: %v", f.Name(), err)
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return fmt.Errorf("writing %s: %v", f.Name(), err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("closing %s: %v", f.Name(), err)
+	}
+	if err := os.Rename(f.Name(), s.path); err != nil {
+		return fmt.Errorf("os.Rename(): %v", err)
+	}
+
+	return nil
+}
+
+// PasswordRotationOptions configures RotatePassword.
+type PasswordRotationOptions struct {
+	// DisplayName identifies the password credentials managed by rotation. Credentials with any other display name are
+	// never modified. Defaults to DefaultRotatedPasswordDisplayName.
+	DisplayName string
+
+	// Validity is the lifetime of each new password credential. Defaults to DefaultPasswordRotationValidity.
+	Validity time.Duration
+
+	// Overlap is how long before the current password credential expires that a replacement is added, during which
+	// both are valid. Must be shorter than Validity. Defaults to DefaultPasswordRotationOverlap.
+	Overlap time.Duration
+
+	// GracePeriod is how long a superseded password credential is retained after its replacement was added, to allow
+	// the new secret to be distributed. Defaults to DefaultPasswordRotationGracePeriod. A credential superseded by a
+	// replacement added in the same call is never removed before it expires. Expired credentials are always removed.
+	GracePeriod time.Duration
+
+	// Sink receives the secret of any new password credential. Required.
+	Sink SecretSink
+
+	// now is overridden in tests
+	now func() time.Time
+}
+
+// PasswordRotationResult describes the changes made by RotatePassword. Current is the managed password credential
+// with the latest expiry after rotation, Added is set when a new credential was added (without its secret, which is
+// only handed to the SecretSink) and Removed lists the credentials that were removed.
+type PasswordRotationResult struct {
+	ObjectId string
+	Current  *PasswordCredential
+	Added    *PasswordCredential
+	Removed  []PasswordCredential
+}
+
+// passwordRotator is implemented by clients for objects that have password credentials.
+type passwordRotator interface {
+	listPasswordCredentials(ctx context.Context, id string) (*[]PasswordCredential, int, error)
+	AddPassword(ctx context.Context, id string, passwordCredential PasswordCredential) (*PasswordCredential, int, error)
+	RemovePassword(ctx context.Context, id string, keyId string) (int, error)
+}
+
+// RotatePassword ensures that an Application has a current password credential managed by rotation, adding a new one
+// when the current credential is due to expire within the configured overlap window and removing managed credentials
+// which have expired or been superseded for longer than the grace period. It is safe to call repeatedly, e.g. on a
+// schedule, and only makes changes when they are due.
+func (c *ApplicationsClient) RotatePassword(ctx context.Context, applicationId string, options PasswordRotationOptions) (*PasswordRotationResult, int, error) {
+	return rotatePassword(ctx, c, applicationId, options)
+}
+
+// RotatePassword ensures that a Service Principal has a current password credential managed by rotation. See
+// ApplicationsClient.RotatePassword for details.
+func (c *ServicePrincipalsClient) RotatePassword(ctx context.Context, servicePrincipalId string, options PasswordRotationOptions) (*PasswordRotationResult, int, error) {
+	return rotatePassword(ctx, c, servicePrincipalId, options)
+}
+
+func (c *ApplicationsClient) listPasswordCredentials(ctx context.Context, id string) (*[]PasswordCredential, int, error) {
+	app, status, err := c.Get(ctx, id, odata.Query{Select: []string{"id", "passwordCredentials"}})
+	if err != nil {
+		return nil, status, err
+	}
+	return app.PasswordCredentials, status, nil
+}
+
+func (c *ServicePrincipalsClient) listPasswordCredentials(ctx context.Context, id string) (*[]PasswordCredential, int, error) {
+	servicePrincipal, status, err := c.Get(ctx, id, odata.Query{Select: []string{"id", "passwordCredentials"}})
+	if err != nil {
+		return nil, status, err
+	}
+	return servicePrincipal.PasswordCredentials, status, nil
+}
+
+func rotatePassword(ctx context.Context, client passwordRotator, id string, options PasswordRotationOptions) (*PasswordRotationResult, int, error) {
+	var status int
+
+	if options.Sink == nil {
+		return nil, status, fmt.Errorf("a SecretSink must be specified")
+	}
+	if options.DisplayName == "" {
+		options.DisplayName = DefaultRotatedPasswordDisplayName
+	}
+	if options.Validity == 0 {
+		options.Validity = DefaultPasswordRotationValidity
+	}
+	if options.Overlap == 0 {
+		options.Overlap = DefaultPasswordRotationOverlap
+	}
+	if options.GracePeriod == 0 {
+		options.GracePeriod = DefaultPasswordRotationGracePeriod
+	}
+	if options.Overlap >= options.Validity {
+		return nil, status, fmt.Errorf("overlap (%s) must be shorter than validity (%s)", options.Overlap, options.Validity)
+	}
+	if options.now == nil {
+		options.now = time.Now
+	}
+	now := options.now().UTC()
+
+	credentials, status, err := client.listPasswordCredentials(ctx, id)
+	if err != nil {
+		return nil, status, err
+	}
+
+	result := PasswordRotationResult{ObjectId: id}
+
+	var managed []PasswordCredential
+	if credentials != nil {
+		for _, credential := range *credentials {
+			if credential.KeyId != nil && credential.DisplayName != nil && *credential.DisplayName == options.DisplayName {
+				managed = append(managed, credential)
+			}
+		}
+	}
+	for i := range managed {
+		if result.Current == nil || passwordExpiresAfter(managed[i], *result.Current) {
+			result.Current = &managed[i]
+		}
+	}
+
+	if result.Current == nil || (result.Current.EndDateTime != nil && result.Current.EndDateTime.Sub(now) <= options.Overlap) {
+		endDateTime := now.Add(options.Validity)
+		added, st, err := client.AddPassword(ctx, id, PasswordCredential{
+			DisplayName:   &options.DisplayName,
+			StartDateTime: &now,
+			EndDateTime:   &endDateTime,
+		})
+		status = st
+		if err != nil {
+			return &result, status, err
+		}
+		if added.KeyId == nil || added.SecretText == nil {
+			return &result, status, fmt.Errorf("new password credential was returned without a keyId or secretText")
+		}
+
+		secret := RotatedSecret{
+			ObjectId:      id,
+			KeyId:         *added.KeyId,
+			DisplayName:   options.DisplayName,
+			SecretText:    *added.SecretText,
+			StartDateTime: now,
+			EndDateTime:   endDateTime,
+		}
+		if added.StartDateTime != nil {
+			secret.StartDateTime = *added.StartDateTime
+		}
+		if added.EndDateTime != nil {
+			secret.EndDateTime = *added.EndDateTime
+		}
+		added.SecretText = nil
+
+		if err := options.Sink.StoreSecret(ctx, secret); err != nil {
+			// the secret is unrecoverable, so remove the credential to leave things as they were
+			if st, removeErr := client.RemovePassword(ctx, id, secret.KeyId); removeErr != nil {
+				return &result, st, fmt.Errorf("storing secret for new password credential %s: %v (additionally the credential could not be removed: %v)", secret.KeyId, err, removeErr)
+			}
+			return &result, status, fmt.Errorf("storing secret for new password credential %s: %v", secret.KeyId, err)
+		}
+
+		result.Added = added
+		result.Current = added
+	}
+
+	var failed, candidates int
+	var firstErr error
+	for _, credential := range managed {
+		if *credential.KeyId == *result.Current.KeyId {
+			continue
+		}
+
+		// credentials superseded in this call are retained, since consumers cannot have picked up the new secret yet
+		expired := credential.EndDateTime != nil && !credential.EndDateTime.After(now)
+		superseded := result.Added == nil && result.Current.StartDateTime != nil && !now.Before(result.Current.StartDateTime.Add(options.GracePeriod))
+		if !expired && !superseded {
+			continue
+		}
+
+		candidates++
+		st, err := client.RemovePassword(ctx, id, *credential.KeyId)
+		status = st
+		if err != nil {
+			failed++
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		result.Removed = append(result.Removed, credential)
+	}
+	if failed > 0 {
+		return &result, status, fmt.Errorf("%d of %d password credentials could not be removed: %v", failed, candidates, firstErr)
+	}
+
+	return &result, status, nil
+}
+
+// passwordExpiresAfter reports whether a expires later than b, treating a missing end date as never expiring.
+func passwordExpiresAfter(a, b PasswordCredential) bool {
+	if a.EndDateTime == nil {
+		return b.EndDateTime != nil
+	}
+	return b.EndDateTime != nil && a.EndDateTime.After(*b.EndDateTime)
+}
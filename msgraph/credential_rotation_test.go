@@ -0,0 +1,231 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/manicminer/hamilton/internal/utils"
+)
+
+type fakePasswordCredentials struct {
+	sync.Mutex
+	credentials []PasswordCredential
+	nextKeyId   int
+}
+
+func (f *fakePasswordCredentials) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/beta/applications/app-id":
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Application{PasswordCredentials: &f.credentials})
+
+	case r.Method == http.MethodPost && r.URL.Path == "/beta/applications/app-id/addPassword":
+		var body struct {
+			PasswordCredential PasswordCredential `json:"passwordCredential"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		f.nextKeyId++
+		credential := body.PasswordCredential
+		credential.KeyId = utils.StringPtr(fmt.Sprintf("key-%d", f.nextKeyId))
+		f.credentials = append(f.credentials, credential)
+		credential.SecretText = utils.StringPtr(fmt.Sprintf("secret-%d", f.nextKeyId))
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(credential)
+
+	case r.Method == http.MethodPost && r.URL.Path == "/beta/applications/app-id/removePassword":
+		var body struct {
+			KeyId string `json:"keyId"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		for i, credential := range f.credentials {
+			if *credential.KeyId == body.KeyId {
+				f.credentials = append(f.credentials[:i], f.credentials[i+1:]...)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (f *fakePasswordCredentials) keyIds() (ids []string) {
+	f.Lock()
+	defer f.Unlock()
+	for _, credential := range f.credentials {
+		ids = append(ids, *credential.KeyId)
+	}
+	return
+}
+
+func TestApplicationsClient_RotatePassword(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	unmanagedEnd := start.Add(-time.Hour)
+	fake := &fakePasswordCredentials{credentials: []PasswordCredential{{
+		DisplayName: utils.StringPtr("manual"),
+		KeyId:       utils.StringPtr("manual-key"),
+		EndDateTime: &unmanagedEnd,
+	}}}
+	ts := httptest.NewServer(fake)
+	defer ts.Close()
+
+	var secrets []RotatedSecret
+	now := start
+	options := PasswordRotationOptions{
+		Validity:    60 * 24 * time.Hour,
+		Overlap:     30 * 24 * time.Hour,
+		GracePeriod: 7 * 24 * time.Hour,
+		Sink: SecretSinkFunc(func(_ context.Context, secret RotatedSecret) error {
+			secrets = append(secrets, secret)
+			return nil
+		}),
+		now: func() time.Time { return now },
+	}
+	c := NewApplicationsClient(WithEndpoint(ts.URL), WithRetryMax(0))
+
+	rotate := func() *PasswordRotationResult {
+		result, _, err := c.RotatePassword(context.Background(), "app-id", options)
+		if err != nil {
+			t.Fatalf("ApplicationsClient.RotatePassword(): %v", err)
+		}
+		return result
+	}
+	expectKeys := func(expected ...string) {
+		if actual := fake.keyIds(); fmt.Sprint(actual) != fmt.Sprint(expected) {
+			t.Fatalf("expected credentials %v, got %v", expected, actual)
+		}
+	}
+
+	// no managed credential, so one is added
+	result := rotate()
+	if result.Added == nil || *result.Added.KeyId != "key-1" || result.Added.SecretText != nil {
+		t.Fatalf("unexpected added credential: %+v", result.Added)
+	}
+	if len(secrets) != 1 || secrets[0].SecretText != "secret-1" || !secrets[0].EndDateTime.Equal(start.Add(options.Validity)) {
+		t.Fatalf("unexpected secrets: %+v", secrets)
+	}
+	expectKeys("manual-key", "key-1")
+
+	// re-running makes no changes
+	now = start.Add(24 * time.Hour)
+	if result = rotate(); result.Added != nil || len(result.Removed) != 0 || *result.Current.KeyId != "key-1" {
+		t.Fatalf("expected no changes, got %+v", result)
+	}
+
+	// within the overlap window a replacement is added, and the previous credential is retained for the grace period
+	now = start.Add(31 * 24 * time.Hour)
+	if result = rotate(); result.Added == nil || *result.Added.KeyId != "key-2" || len(result.Removed) != 0 {
+		t.Fatalf("expected a new credential, got %+v", result)
+	}
+	now = now.Add(24 * time.Hour)
+	if result = rotate(); result.Added != nil || len(result.Removed) != 0 {
+		t.Fatalf("expected no changes during grace period, got %+v", result)
+	}
+	expectKeys("manual-key", "key-1", "key-2")
+
+	// after the grace period the superseded credential is removed
+	now = now.Add(7 * 24 * time.Hour)
+	if result = rotate(); len(result.Removed) != 1 || *result.Removed[0].KeyId != "key-1" {
+		t.Fatalf("expected key-1 to be removed, got %+v", result)
+	}
+	expectKeys("manual-key", "key-2")
+
+	// when the secret cannot be stored the new credential is removed again
+	now = now.Add(30 * 24 * time.Hour)
+	options.Sink = SecretSinkFunc(func(context.Context, RotatedSecret) error { return fmt.Errorf("vault unavailable") })
+	if _, _, err := c.RotatePassword(context.Background(), "app-id", options); err == nil {
+		t.Fatalf("expected an error when the secret cannot be stored")
+	}
+	expectKeys("manual-key", "key-2")
+}
+
+func TestApplicationsClient_RotatePasswordDefaults(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	fake := &fakePasswordCredentials{}
+	ts := httptest.NewServer(fake)
+	defer ts.Close()
+
+	now := start
+	options := PasswordRotationOptions{
+		Sink: SecretSinkFunc(func(context.Context, RotatedSecret) error { return nil }),
+		now:  func() time.Time { return now },
+	}
+	c := NewApplicationsClient(WithEndpoint(ts.URL), WithRetryMax(0))
+
+	rotate := func() *PasswordRotationResult {
+		result, _, err := c.RotatePassword(context.Background(), "app-id", options)
+		if err != nil {
+			t.Fatalf("ApplicationsClient.RotatePassword(): %v", err)
+		}
+		return result
+	}
+
+	rotate()
+
+	// the previous credential must not be removed by the same call that replaced it
+	now = start.Add(DefaultPasswordRotationValidity - DefaultPasswordRotationOverlap + time.Hour)
+	if result := rotate(); result.Added == nil || len(result.Removed) != 0 {
+		t.Fatalf("expected a new credential and no removals, got %+v", result)
+	}
+	if keys := fake.keyIds(); len(keys) != 2 {
+		t.Fatalf("expected both credentials to be retained, got %v", keys)
+	}
+
+	now = now.Add(DefaultPasswordRotationGracePeriod - time.Hour)
+	if result := rotate(); result.Added != nil || len(result.Removed) != 0 {
+		t.Fatalf("expected no changes during the default grace period, got %+v", result)
+	}
+
+	now = now.Add(time.Hour)
+	if result := rotate(); len(result.Removed) != 1 || *result.Removed[0].KeyId != "key-1" {
+		t.Fatalf("expected key-1 to be removed after the default grace period, got %+v", result)
+	}
+}
+
+func TestFileSecretSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secret.json")
+	sink := FileSecretSink(path)
+
+	for _, keyId := range []string{"key-1", "key-2"} {
+		if err := sink.StoreSecret(context.Background(), RotatedSecret{KeyId: keyId, SecretText: "s3cr3t"}); err != nil {
+			t.Fatalf("StoreSecret(): %v", err)
+		}
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("os.Stat(): %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("expected permissions 0600, got %o", perm)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("os.ReadFile(): %v", err)
+	}
+	var secret RotatedSecret
+	if err := json.Unmarshal(data, &secret); err != nil {
+		t.Fatalf("json.Unmarshal(): %v", err)
+	}
+	if secret.KeyId != "key-2" || secret.SecretText != "s3cr3t" {
+		t.Errorf("unexpected secret: %+v", secret)
+	}
+
+	if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 1 {
+		t.Errorf("expected temporary files to be cleaned up, found %d entries", len(entries))
+	}
+}
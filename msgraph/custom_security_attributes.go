@@ -25,9 +25,9 @@ type CustomSecurityAttributeDefinitionClient struct {
 
 // NewCustomSecurityAttributeDefinitionClient returns a new instance of
 // CustomSecurityAttributeDefinitionClient
-func NewCustomSecurityAttributeDefinitionClient() *CustomSecurityAttributeDefinitionClient {
+func NewCustomSecurityAttributeDefinitionClient(opts ...ClientOption) *CustomSecurityAttributeDefinitionClient {
 	return &CustomSecurityAttributeDefinitionClient{
-		BaseClient: NewClient(Version10),
+		BaseClient: NewClient(Version10, opts...),
 	}
 }
 
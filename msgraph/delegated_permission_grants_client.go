@@ -17,9 +17,9 @@ type DelegatedPermissionGrantsClient struct {
 }
 
 // NewDelegatedPermissionGrantsClient returns a new DelegatedPermissionGrantsClient
-func NewDelegatedPermissionGrantsClient() *DelegatedPermissionGrantsClient {
+func NewDelegatedPermissionGrantsClient(opts ...ClientOption) *DelegatedPermissionGrantsClient {
 	return &DelegatedPermissionGrantsClient{
-		BaseClient: NewClient(Version10),
+		BaseClient: NewClient(Version10, opts...),
 	}
 }
 
@@ -16,9 +16,9 @@ type DirectoryAuditReportsClient struct {
 }
 
 // NewDirectoryAuditReportsClient returns a new DirectoryAuditReportsClient.
-func NewDirectoryAuditReportsClient() *DirectoryAuditReportsClient {
+func NewDirectoryAuditReportsClient(opts ...ClientOption) *DirectoryAuditReportsClient {
 	return &DirectoryAuditReportsClient{
-		BaseClient: NewClient(VersionBeta),
+		BaseClient: NewClient(VersionBeta, opts...),
 	}
 }
 
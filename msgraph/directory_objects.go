@@ -17,9 +17,9 @@ type DirectoryObjectsClient struct {
 }
 
 // NewDirectoryObjectsClient returns a new DirectoryObjectsClient.
-func NewDirectoryObjectsClient() *DirectoryObjectsClient {
+func NewDirectoryObjectsClient(opts ...ClientOption) *DirectoryObjectsClient {
 	return &DirectoryObjectsClient{
-		BaseClient: NewClient(Version10),
+		BaseClient: NewClient(Version10, opts...),
 	}
 }
 
@@ -14,9 +14,9 @@ type DirectoryRoleTemplatesClient struct {
 }
 
 // NewDirectoryRoleTemplatesClient returns a new DirectoryRoleTemplatesClient
-func NewDirectoryRoleTemplatesClient() *DirectoryRoleTemplatesClient {
+func NewDirectoryRoleTemplatesClient(opts ...ClientOption) *DirectoryRoleTemplatesClient {
 	return &DirectoryRoleTemplatesClient{
-		BaseClient: NewClient(Version10),
+		BaseClient: NewClient(Version10, opts...),
 	}
 }
 
@@ -17,9 +17,9 @@ type DirectoryRolesClient struct {
 }
 
 // NewDirectoryRolesClient returns a new DirectoryRolesClient
-func NewDirectoryRolesClient() *DirectoryRolesClient {
+func NewDirectoryRolesClient(opts ...ClientOption) *DirectoryRolesClient {
 	return &DirectoryRolesClient{
-		BaseClient: NewClient(Version10),
+		BaseClient: NewClient(Version10, opts...),
 	}
 }
 
@@ -144,45 +144,37 @@ func (c *DirectoryRolesClient) ListMembers(ctx context.Context, id string) (*[]s
 }
 
 // AddMembers adds new members to a Directory Role.
-// First populate the `members` field, then call this method
-func (c *DirectoryRolesClient) AddMembers(ctx context.Context, directoryRole *DirectoryRole) (int, error) {
+// First populate the `members` field, then call this method.
+// Directory roles do not support batched additions, so each member is added with its own request and the outcome
+// for each member is returned.
+func (c *DirectoryRolesClient) AddMembers(ctx context.Context, directoryRole *DirectoryRole) (*[]MemberResult, int, error) {
 	var status int
 
 	if directoryRole.ID() == nil {
-		return status, errors.New("cannot update directory role with nil ID")
+		return nil, status, errors.New("cannot update directory role with nil ID")
 	}
 	if directoryRole.Members == nil {
-		return status, errors.New("cannot update directory role with nil Owners")
+		return nil, status, errors.New("cannot update directory role with nil Members")
 	}
 
+	entity := fmt.Sprintf("/directoryRoles/%s", *directoryRole.ID())
+	results := make([]MemberResult, 0, len(*directoryRole.Members))
 	for _, member := range *directoryRole.Members {
-		// don't fail if a member already exists
-		checkMemberAlreadyExists := func(resp *http.Response, o *odata.OData) bool {
-			if resp != nil && resp.StatusCode == http.StatusBadRequest && o != nil && o.Error != nil {
-				return o.Error.Match(odata.ErrorAddedObjectReferencesAlreadyExist)
-			}
-			return false
-		}
-
-		body, err := json.Marshal(DirectoryObject{ODataId: member.ODataId})
-		if err != nil {
-			return status, fmt.Errorf("json.Marshal(): %v", err)
+		if member.ODataId == nil {
+			results = append(results, MemberResult{
+				Member: member,
+				Status: MemberResultStatusFailed,
+				Err:    fmt.Errorf("member has nil ODataId"),
+			})
+			continue
 		}
 
-		_, status, _, err = c.BaseClient.Post(ctx, PostHttpRequestInput{
-			Body:             body,
-			ValidStatusCodes: []int{http.StatusNoContent},
-			ValidStatusFunc:  checkMemberAlreadyExists,
-			Uri: Uri{
-				Entity: fmt.Sprintf("/directoryRoles/%s/members/$ref", *directoryRole.ID()),
-			},
-		})
-		if err != nil {
-			return status, fmt.Errorf("DirectoryRolesClient.BaseClient.Post(): %v", err)
-		}
+		var result MemberResult
+		result, status = c.BaseClient.addReference(ctx, "DirectoryRolesClient", entity, "members", member)
+		results = append(results, result)
 	}
 
-	return status, nil
+	return &results, status, memberResultsError(results)
 }
 
 // RemoveMembers removes members from a Directory Role.
@@ -125,7 +125,7 @@ func testDirectoryRolesClient_RemoveMembers(t *testing.T, c *test.Test, dirRoleI
 }
 
 func testDirectoryRolesClient_AddMembers(t *testing.T, c *test.Test, dirRole *msgraph.DirectoryRole) {
-	status, err := c.DirectoryRolesClient.AddMembers(c.Context, dirRole)
+	_, status, err := c.DirectoryRolesClient.AddMembers(c.Context, dirRole)
 	if err != nil {
 		t.Fatalf("DirectoryRolesClient.AddMembers(): %v", err)
 	}
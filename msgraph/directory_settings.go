@@ -0,0 +1,274 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+)
+
+// DirectorySettingsClient performs operations on group setting templates, and on group settings at the tenant and group level.
+type DirectorySettingsClient struct {
+	BaseClient Client
+}
+
+// NewDirectorySettingsClient returns a new DirectorySettingsClient.
+func NewDirectorySettingsClient(opts ...ClientOption) *DirectorySettingsClient {
+	return &DirectorySettingsClient{
+		BaseClient: NewClient(Version10, opts...),
+	}
+}
+
+// ListTemplates returns a list of GroupSettingTemplates, optionally queried using OData.
+func (c *DirectorySettingsClient) ListTemplates(ctx context.Context, query odata.Query) (*[]GroupSettingTemplate, int, error) {
+	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+		DisablePaging:    query.Top > 0,
+		OData:            query,
+		ValidStatusCodes: []int{http.StatusOK},
+		Uri: Uri{
+			Entity: "/groupSettingTemplates",
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("DirectorySettingsClient.BaseClient.Get(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var data struct {
+		GroupSettingTemplates []GroupSettingTemplate `json:"value"`
+	}
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &data.GroupSettingTemplates, status, nil
+}
+
+// GetTemplate retrieves a GroupSettingTemplate.
+func (c *DirectorySettingsClient) GetTemplate(ctx context.Context, id string, query odata.Query) (*GroupSettingTemplate, int, error) {
+	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+		OData:            query,
+		ValidStatusCodes: []int{http.StatusOK},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/groupSettingTemplates/%s", id),
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("DirectorySettingsClient.BaseClient.Get(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var template GroupSettingTemplate
+	if err := json.Unmarshal(respBody, &template); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &template, status, nil
+}
+
+// List returns a list of tenant-wide GroupSettings, optionally queried using OData.
+func (c *DirectorySettingsClient) List(ctx context.Context, query odata.Query) (*[]GroupSetting, int, error) {
+	return c.list(ctx, "/groupSettings", query)
+}
+
+// Get retrieves a tenant-wide GroupSetting.
+func (c *DirectorySettingsClient) Get(ctx context.Context, id string, query odata.Query) (*GroupSetting, int, error) {
+	return c.get(ctx, fmt.Sprintf("/groupSettings/%s", id), query)
+}
+
+// Create creates a new tenant-wide GroupSetting from a template. Values that are not specified take the default value
+// defined by the template.
+func (c *DirectorySettingsClient) Create(ctx context.Context, setting GroupSetting) (*GroupSetting, int, error) {
+	return c.create(ctx, "/groupSettings", setting)
+}
+
+// Update amends an existing tenant-wide GroupSetting.
+func (c *DirectorySettingsClient) Update(ctx context.Context, setting GroupSetting) (int, error) {
+	if setting.ID == nil {
+		return 0, fmt.Errorf("cannot update GroupSetting with nil ID")
+	}
+	return c.update(ctx, fmt.Sprintf("/groupSettings/%s", *setting.ID), setting)
+}
+
+// Delete removes a tenant-wide GroupSetting, restoring the default values for all settings in its template.
+func (c *DirectorySettingsClient) Delete(ctx context.Context, id string) (int, error) {
+	return c.delete(ctx, fmt.Sprintf("/groupSettings/%s", id))
+}
+
+// ListForGroup returns a list of GroupSettings for the specified Group.
+func (c *DirectorySettingsClient) ListForGroup(ctx context.Context, groupId string) (*[]GroupSetting, int, error) {
+	return c.list(ctx, fmt.Sprintf("/groups/%s/settings", groupId), odata.Query{})
+}
+
+// GetForGroup retrieves a GroupSetting for the specified Group.
+func (c *DirectorySettingsClient) GetForGroup(ctx context.Context, groupId, id string) (*GroupSetting, int, error) {
+	return c.get(ctx, fmt.Sprintf("/groups/%s/settings/%s", groupId, id), odata.Query{})
+}
+
+// CreateForGroup creates a new GroupSetting for the specified Group from a template, i.e. Group.Unified.Guest.
+func (c *DirectorySettingsClient) CreateForGroup(ctx context.Context, groupId string, setting GroupSetting) (*GroupSetting, int, error) {
+	return c.create(ctx, fmt.Sprintf("/groups/%s/settings", groupId), setting)
+}
+
+// UpdateForGroup amends an existing GroupSetting for the specified Group.
+func (c *DirectorySettingsClient) UpdateForGroup(ctx context.Context, groupId string, setting GroupSetting) (int, error) {
+	if setting.ID == nil {
+		return 0, fmt.Errorf("cannot update GroupSetting with nil ID")
+	}
+	return c.update(ctx, fmt.Sprintf("/groups/%s/settings/%s", groupId, *setting.ID), setting)
+}
+
+// DeleteForGroup removes a GroupSetting for the specified Group.
+func (c *DirectorySettingsClient) DeleteForGroup(ctx context.Context, groupId, id string) (int, error) {
+	return c.delete(ctx, fmt.Sprintf("/groups/%s/settings/%s", groupId, id))
+}
+
+func (c *DirectorySettingsClient) list(ctx context.Context, entity string, query odata.Query) (*[]GroupSetting, int, error) {
+	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		DisablePaging:          query.Top > 0,
+		OData:                  query,
+		ValidStatusCodes:       []int{http.StatusOK},
+		Uri: Uri{
+			Entity: entity,
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("DirectorySettingsClient.BaseClient.Get(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var data struct {
+		GroupSettings []GroupSetting `json:"value"`
+	}
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &data.GroupSettings, status, nil
+}
+
+func (c *DirectorySettingsClient) get(ctx context.Context, entity string, query odata.Query) (*GroupSetting, int, error) {
+	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		OData:                  query,
+		ValidStatusCodes:       []int{http.StatusOK},
+		Uri: Uri{
+			Entity: entity,
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("DirectorySettingsClient.BaseClient.Get(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var setting GroupSetting
+	if err := json.Unmarshal(respBody, &setting); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &setting, status, nil
+}
+
+func (c *DirectorySettingsClient) create(ctx context.Context, entity string, setting GroupSetting) (*GroupSetting, int, error) {
+	var status int
+
+	if setting.TemplateId == nil {
+		return nil, status, fmt.Errorf("cannot create GroupSetting with nil TemplateId")
+	}
+
+	body, err := json.Marshal(setting)
+	if err != nil {
+		return nil, status, fmt.Errorf("json.Marshal(): %v", err)
+	}
+
+	resp, status, _, err := c.BaseClient.Post(ctx, PostHttpRequestInput{
+		Body:                   body,
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusCreated},
+		Uri: Uri{
+			Entity: entity,
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("DirectorySettingsClient.BaseClient.Post(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var newSetting GroupSetting
+	if err := json.Unmarshal(respBody, &newSetting); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &newSetting, status, nil
+}
+
+func (c *DirectorySettingsClient) update(ctx context.Context, entity string, setting GroupSetting) (int, error) {
+	var status int
+
+	// only values can be updated
+	body, err := json.Marshal(GroupSetting{Values: setting.Values})
+	if err != nil {
+		return status, fmt.Errorf("json.Marshal(): %v", err)
+	}
+
+	_, status, _, err = c.BaseClient.Patch(ctx, PatchHttpRequestInput{
+		Body:                   body,
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes: []int{
+			http.StatusOK,
+			http.StatusNoContent,
+		},
+		Uri: Uri{
+			Entity: entity,
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("DirectorySettingsClient.BaseClient.Patch(): %v", err)
+	}
+
+	return status, nil
+}
+
+func (c *DirectorySettingsClient) delete(ctx context.Context, entity string) (int, error) {
+	_, status, _, err := c.BaseClient.Delete(ctx, DeleteHttpRequestInput{
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusNoContent},
+		Uri: Uri{
+			Entity: entity,
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("DirectorySettingsClient.BaseClient.Delete(): %v", err)
+	}
+
+	return status, nil
+}
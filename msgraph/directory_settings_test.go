@@ -0,0 +1,146 @@
+package msgraph_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+	"github.com/manicminer/hamilton/internal/test"
+	"github.com/manicminer/hamilton/internal/utils"
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+func TestDirectorySettingsClient(t *testing.T) {
+	c := test.NewTest(t)
+	defer c.CancelFunc()
+
+	testDirectorySettingsClient_ListTemplates(t, c)
+	testDirectorySettingsClient_GetTemplate(t, c, msgraph.GroupSettingTemplateIdUnified)
+	testDirectorySettingsClient_List(t, c)
+
+	group := testGroupsClient_Create(t, c, msgraph.Group{
+		DisplayName:     utils.StringPtr("test-group-settings"),
+		GroupTypes:      &[]msgraph.GroupType{msgraph.GroupTypeUnified},
+		MailEnabled:     utils.BoolPtr(true),
+		MailNickname:    utils.StringPtr(fmt.Sprintf("test-group-settings-%s", c.RandomString)),
+		SecurityEnabled: utils.BoolPtr(false),
+	})
+
+	setting := testDirectorySettingsClient_CreateForGroup(t, c, *group.ID(), msgraph.GroupUnifiedGuestSettings{
+		AllowToAddGuests: utils.BoolPtr(false),
+	}.GroupSetting())
+	testDirectorySettingsClient_ListForGroup(t, c, *group.ID())
+
+	msgraph.GroupUnifiedGuestSettings{AllowToAddGuests: utils.BoolPtr(true)}.Apply(setting)
+	testDirectorySettingsClient_UpdateForGroup(t, c, *group.ID(), *setting)
+
+	setting = testDirectorySettingsClient_GetForGroup(t, c, *group.ID(), *setting.ID)
+	guestSettings, err := msgraph.ParseGroupUnifiedGuestSettings(*setting)
+	if err != nil {
+		t.Fatalf("ParseGroupUnifiedGuestSettings(): %v", err)
+	}
+	if guestSettings.AllowToAddGuests == nil || !*guestSettings.AllowToAddGuests {
+		t.Fatal("ParseGroupUnifiedGuestSettings(): expected AllowToAddGuests to be true")
+	}
+
+	testDirectorySettingsClient_DeleteForGroup(t, c, *group.ID(), *setting.ID)
+	testGroupsClient_Delete(t, c, *group.ID())
+}
+
+func testDirectorySettingsClient_ListTemplates(t *testing.T, c *test.Test) (templates *[]msgraph.GroupSettingTemplate) {
+	templates, _, err := c.DirectorySettingsClient.ListTemplates(c.Context, odata.Query{})
+	if err != nil {
+		t.Fatalf("DirectorySettingsClient.ListTemplates(): %v", err)
+	}
+	if templates == nil {
+		t.Fatal("DirectorySettingsClient.ListTemplates(): templates was nil")
+	}
+	return
+}
+
+func testDirectorySettingsClient_GetTemplate(t *testing.T, c *test.Test, id string) (template *msgraph.GroupSettingTemplate) {
+	template, status, err := c.DirectorySettingsClient.GetTemplate(c.Context, id, odata.Query{})
+	if err != nil {
+		t.Fatalf("DirectorySettingsClient.GetTemplate(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("DirectorySettingsClient.GetTemplate(): invalid status: %d", status)
+	}
+	if template == nil {
+		t.Fatal("DirectorySettingsClient.GetTemplate(): template was nil")
+	}
+	return
+}
+
+func testDirectorySettingsClient_List(t *testing.T, c *test.Test) (settings *[]msgraph.GroupSetting) {
+	settings, _, err := c.DirectorySettingsClient.List(c.Context, odata.Query{})
+	if err != nil {
+		t.Fatalf("DirectorySettingsClient.List(): %v", err)
+	}
+	if settings == nil {
+		t.Fatal("DirectorySettingsClient.List(): settings was nil")
+	}
+	return
+}
+
+func testDirectorySettingsClient_CreateForGroup(t *testing.T, c *test.Test, groupId string, s msgraph.GroupSetting) (setting *msgraph.GroupSetting) {
+	setting, status, err := c.DirectorySettingsClient.CreateForGroup(c.Context, groupId, s)
+	if err != nil {
+		t.Fatalf("DirectorySettingsClient.CreateForGroup(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("DirectorySettingsClient.CreateForGroup(): invalid status: %d", status)
+	}
+	if setting == nil {
+		t.Fatal("DirectorySettingsClient.CreateForGroup(): setting was nil")
+	}
+	if setting.ID == nil {
+		t.Fatal("DirectorySettingsClient.CreateForGroup(): setting.ID was nil")
+	}
+	return
+}
+
+func testDirectorySettingsClient_ListForGroup(t *testing.T, c *test.Test, groupId string) (settings *[]msgraph.GroupSetting) {
+	settings, _, err := c.DirectorySettingsClient.ListForGroup(c.Context, groupId)
+	if err != nil {
+		t.Fatalf("DirectorySettingsClient.ListForGroup(): %v", err)
+	}
+	if settings == nil || len(*settings) == 0 {
+		t.Fatal("DirectorySettingsClient.ListForGroup(): expected the group to have settings")
+	}
+	return
+}
+
+func testDirectorySettingsClient_GetForGroup(t *testing.T, c *test.Test, groupId, id string) (setting *msgraph.GroupSetting) {
+	setting, status, err := c.DirectorySettingsClient.GetForGroup(c.Context, groupId, id)
+	if err != nil {
+		t.Fatalf("DirectorySettingsClient.GetForGroup(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("DirectorySettingsClient.GetForGroup(): invalid status: %d", status)
+	}
+	if setting == nil {
+		t.Fatal("DirectorySettingsClient.GetForGroup(): setting was nil")
+	}
+	return
+}
+
+func testDirectorySettingsClient_UpdateForGroup(t *testing.T, c *test.Test, groupId string, s msgraph.GroupSetting) {
+	status, err := c.DirectorySettingsClient.UpdateForGroup(c.Context, groupId, s)
+	if err != nil {
+		t.Fatalf("DirectorySettingsClient.UpdateForGroup(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("DirectorySettingsClient.UpdateForGroup(): invalid status: %d", status)
+	}
+}
+
+func testDirectorySettingsClient_DeleteForGroup(t *testing.T, c *test.Test, groupId, id string) {
+	status, err := c.DirectorySettingsClient.DeleteForGroup(c.Context, groupId, id)
+	if err != nil {
+		t.Fatalf("DirectorySettingsClient.DeleteForGroup(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("DirectorySettingsClient.DeleteForGroup(): invalid status: %d", status)
+	}
+}
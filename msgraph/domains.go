@@ -16,9 +16,9 @@ type DomainsClient struct {
 }
 
 // NewDomainsClient returns a new DomainsClient.
-func NewDomainsClient() *DomainsClient {
+func NewDomainsClient(opts ...ClientOption) *DomainsClient {
 	return &DomainsClient{
-		BaseClient: NewClient(Version10),
+		BaseClient: NewClient(Version10, opts...),
 	}
 }
 
@@ -16,9 +16,9 @@ type EntitlementRoleAssignmentsClient struct {
 }
 
 // NewEntitlementRoleAssignmentsClient returns a new EntitlementRoleAssignmentsClient
-func NewEntitlementRoleAssignmentsClient() *EntitlementRoleAssignmentsClient {
+func NewEntitlementRoleAssignmentsClient(opts ...ClientOption) *EntitlementRoleAssignmentsClient {
 	return &EntitlementRoleAssignmentsClient{
-		BaseClient: NewClient(Version10),
+		BaseClient: NewClient(Version10, opts...).withFeature(FeatureEntitlementManagement),
 	}
 }
 
@@ -16,9 +16,9 @@ type EntitlementRoleDefinitionsClient struct {
 }
 
 // NewEntitlementRoleDefinitionsClient returns a new EntitlementRoleDefinitionsClient
-func NewEntitlementRoleDefinitionsClient() *EntitlementRoleDefinitionsClient {
+func NewEntitlementRoleDefinitionsClient(opts ...ClientOption) *EntitlementRoleDefinitionsClient {
 	return &EntitlementRoleDefinitionsClient{
-		BaseClient: NewClient(Version10),
+		BaseClient: NewClient(Version10, opts...).withFeature(FeatureEntitlementManagement),
 	}
 }
 
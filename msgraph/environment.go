@@ -0,0 +1,60 @@
+package msgraph
+
+import (
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+	"github.com/manicminer/hamilton/errors"
+)
+
+// Feature is a set of related Microsoft Graph capabilities which may not be available in every national cloud.
+type Feature string
+
+const (
+	FeatureB2C                   Feature = "Azure AD B2C"
+	FeatureEntitlementManagement Feature = "Entitlement Management"
+	FeatureWindowsAutopilot      Feature = "Windows Autopilot"
+)
+
+// unsupportedFeatures lists the features known to be unavailable in national cloud environments, keyed by environment name.
+var unsupportedFeatures = map[string][]Feature{
+	environments.AzureChinaCloud: {
+		FeatureEntitlementManagement,
+		FeatureWindowsAutopilot,
+	},
+	environments.AzureUSGovernmentCloud: {
+		FeatureB2C,
+	},
+	"USGovernmentL5": {
+		FeatureB2C,
+	},
+}
+
+// FeatureSupported returns whether the specified feature is known to be available in the environment.
+func FeatureSupported(env environments.Environment, feature Feature) bool {
+	for _, f := range unsupportedFeatures[env.Name] {
+		if f == feature {
+			return false
+		}
+	}
+	return true
+}
+
+// withFeature returns a copy of the client which checks that the specified feature is available in the configured
+// environment before sending requests.
+func (c Client) withFeature(feature Feature) Client {
+	c.feature = feature
+	return c
+}
+
+// checkFeature returns an error when the client relies on a feature that is unavailable in the configured environment.
+func (c Client) checkFeature() error {
+	if c.feature == "" || c.Environment == nil {
+		return nil
+	}
+	if !FeatureSupported(*c.Environment, c.feature) {
+		return errors.UnsupportedFeatureError{
+			Feature:     string(c.feature),
+			Environment: c.Environment.Name,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package msgraph
+
+import (
+	"context"
+	goerrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+	"github.com/manicminer/hamilton/errors"
+)
+
+func TestClient_WithEnvironment(t *testing.T) {
+	c := NewUsersClient(WithEnvironment(*environments.AzureUSGovernment()))
+	if c.BaseClient.Endpoint != "https://graph.microsoft.us" {
+		t.Fatalf("got endpoint %q, want %q", c.BaseClient.Endpoint, "https://graph.microsoft.us")
+	}
+	if c.BaseClient.Environment == nil || c.BaseClient.Environment.Name != environments.AzureUSGovernmentCloud {
+		t.Fatalf("expected environment to be %q", environments.AzureUSGovernmentCloud)
+	}
+}
+
+func TestClient_UnsupportedFeature(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request sent to %s", r.URL.Path)
+	}))
+	defer ts.Close()
+
+	c := NewAccessPackageCatalogClient(WithEnvironment(*environments.AzureChina()), WithEndpoint(ts.URL))
+	_, _, err := c.List(context.Background(), odata.Query{})
+	if err == nil {
+		t.Fatal("expected an error for an unsupported feature, got nil")
+	}
+
+	if FeatureSupported(*environments.AzureChina(), FeatureEntitlementManagement) {
+		t.Fatalf("expected %s to be unsupported in China", FeatureEntitlementManagement)
+	}
+	if !FeatureSupported(*environments.AzurePublic(), FeatureEntitlementManagement) {
+		t.Fatalf("expected %s to be supported in the public cloud", FeatureEntitlementManagement)
+	}
+
+	b2c := NewB2CUserFlowClient(WithEnvironment(*environments.AzureUSGovernment()))
+	var featureErr errors.UnsupportedFeatureError
+	if err := b2c.BaseClient.checkFeature(); !goerrors.As(err, &featureErr) {
+		t.Fatalf("expected UnsupportedFeatureError, got: %v", err)
+	}
+}
@@ -0,0 +1,141 @@
+package msgraph
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/auth"
+)
+
+// Tenant identifies a tenant and the Authorizer used to obtain access tokens for it.
+type Tenant struct {
+	TenantId   string
+	Authorizer auth.Authorizer
+}
+
+// TenantFunc is a function that is run against a single tenant, using a ServiceClient configured for that tenant.
+type TenantFunc func(ctx context.Context, tenantId string, svc *ServiceClient) (interface{}, error)
+
+// TenantResult holds the outcome of running a TenantFunc against a single tenant.
+type TenantResult struct {
+	TenantId string
+	Result   interface{}
+	Err      error
+	Duration time.Duration
+}
+
+// TenantResults holds the outcomes for all tenants, in the order the tenants were specified.
+type TenantResults []TenantResult
+
+// Errors returns the errors for tenants where the function failed, keyed by tenant ID.
+func (r TenantResults) Errors() map[string]error {
+	errs := make(map[string]error)
+	for _, result := range r {
+		if result.Err != nil {
+			errs[result.TenantId] = result.Err
+		}
+	}
+	return errs
+}
+
+// FanOutProgress is reported each time a tenant has completed.
+type FanOutProgress struct {
+	TenantId  string
+	Err       error
+	Completed int
+	Total     int
+}
+
+// FanOutInput configures a FanOut operation.
+type FanOutInput struct {
+	// Tenants are the tenants to run against.
+	Tenants []Tenant
+
+	// Concurrency is the maximum number of tenants to process at the same time. Defaults to 10.
+	Concurrency int
+
+	// Options are applied to the ServiceClient for each tenant. Each tenant receives its own ServiceClient and
+	// HTTP transport, so that throttling or request limits for one tenant do not affect other tenants.
+	Options []ClientOption
+
+	// Progress, when set, is called each time a tenant has completed. Calls are not made concurrently.
+	Progress func(FanOutProgress)
+}
+
+// FanOut runs the function against each tenant with bounded concurrency and returns the results for every tenant.
+// When the context is cancelled, tenants which have not yet started are not processed and their results contain the
+// context error.
+func FanOut(ctx context.Context, input FanOutInput, f TenantFunc) TenantResults {
+	concurrency := input.Concurrency
+	if concurrency <= 0 {
+		concurrency = 10
+	}
+
+	results := make(TenantResults, len(input.Tenants))
+	sem := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	completed := 0
+
+	report := func(result TenantResult) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		completed++
+		if input.Progress != nil {
+			input.Progress(FanOutProgress{
+				TenantId:  result.TenantId,
+				Err:       result.Err,
+				Completed: completed,
+				Total:     len(input.Tenants),
+			})
+		}
+	}
+
+	for i, tenant := range input.Tenants {
+		results[i].TenantId = tenant.TenantId
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			results[i].Err = ctx.Err()
+			report(results[i])
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, tenant Tenant) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			start := time.Now()
+			results[i].Result, results[i].Err = runTenantFunc(ctx, tenant, input.Options, f)
+			results[i].Duration = time.Since(start)
+			report(results[i])
+		}(i, tenant)
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+// runTenantFunc runs the function for a single tenant, recovering from any panic so that other tenants are unaffected.
+func runTenantFunc(ctx context.Context, tenant Tenant, options []ClientOption, f TenantFunc) (result interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic for tenant %q: %v", tenant.TenantId, r)
+		}
+	}()
+
+	if err = ctx.Err(); err != nil {
+		return
+	}
+
+	opts := append(append([]ClientOption{}, options...), WithAuthorizer(tenant.Authorizer))
+	svc := NewServiceClient(opts...)
+
+	return f(ctx, tenant.TenantId, svc)
+}
@@ -0,0 +1,92 @@
+package msgraph
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+)
+
+func TestFanOut(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"value":[{"id":"00000000-0000-0000-0000-000000000001"}]}`))
+	}))
+	defer ts.Close()
+
+	tenants := make([]Tenant, 0)
+	for i := 0; i < 5; i++ {
+		tenants = append(tenants, Tenant{TenantId: fmt.Sprintf("tenant-%d", i)})
+	}
+
+	var running, maxRunning int32
+	progress := 0
+
+	results := FanOut(context.Background(), FanOutInput{
+		Tenants:     tenants,
+		Concurrency: 2,
+		Options:     []ClientOption{WithEndpoint(ts.URL)},
+		Progress: func(p FanOutProgress) {
+			progress++
+			if p.Completed != progress || p.Total != len(tenants) {
+				t.Errorf("unexpected progress: %+v", p)
+			}
+		},
+	}, func(ctx context.Context, tenantId string, svc *ServiceClient) (interface{}, error) {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+
+		if tenantId == "tenant-3" {
+			return nil, fmt.Errorf("failed")
+		}
+		users, _, err := svc.Users().List(ctx, odata.Query{})
+		if err != nil {
+			return nil, err
+		}
+		return len(*users), nil
+	})
+
+	if maxRunning > 2 {
+		t.Fatalf("expected at most 2 tenants to be processed concurrently, got %d", maxRunning)
+	}
+	if progress != len(tenants) {
+		t.Fatalf("expected %d progress reports, got %d", len(tenants), progress)
+	}
+	for i, result := range results {
+		if result.TenantId != tenants[i].TenantId {
+			t.Fatalf("expected results in tenant order, got %q at index %d", result.TenantId, i)
+		}
+	}
+	if errs := results.Errors(); len(errs) != 1 || errs["tenant-3"] == nil {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if results[0].Result != 1 {
+		t.Fatalf("unexpected result for tenant-0: %v", results[0].Result)
+	}
+}
+
+func TestFanOut_Cancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results := FanOut(ctx, FanOutInput{
+		Tenants: []Tenant{{TenantId: "tenant-0"}, {TenantId: "tenant-1"}},
+	}, func(ctx context.Context, tenantId string, svc *ServiceClient) (interface{}, error) {
+		t.Errorf("unexpected call for tenant %q", tenantId)
+		return nil, nil
+	})
+
+	if errs := results.Errors(); len(errs) != 2 {
+		t.Fatalf("expected all tenants to return an error, got: %v", errs)
+	}
+}
@@ -0,0 +1,224 @@
+package msgraph
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/manicminer/hamilton/internal/utils"
+)
+
+const (
+	// FederatedIdentityCredentialAudience is the audience recommended for federated identity credentials, which the
+	// external identity provider must include in the tokens it issues.
+	FederatedIdentityCredentialAudience = "api://AzureADTokenExchange"
+
+	// FederatedIdentityCredentialsLimit is the maximum number of federated identity credentials that can be added to
+	// an application or user-assigned managed identity.
+	FederatedIdentityCredentialsLimit = 20
+
+	// FederatedIdentityExpressionLanguageVersion is the language version of claims matching expressions.
+	FederatedIdentityExpressionLanguageVersion = 1
+
+	GitHubActionsIssuer  = "https://token.actions.githubusercontent.com"
+	GitLabIssuer         = "https://gitlab.com"
+	TerraformCloudIssuer = "https://app.terraform.io"
+)
+
+// claimsMatchingExpressionIssuers are the issuers for which claims matching expressions are supported.
+var claimsMatchingExpressionIssuers = []string{GitHubActionsIssuer, GitLabIssuer, TerraformCloudIssuer}
+
+var federatedIdentityCredentialNameRegexp = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]{2,119}$`)
+
+// NewGitHubActionsCredential returns a federated identity credential trusting GitHub Actions workflows in the specified
+// repository, given as "owner/repo". The value is the name of the environment, branch or tag, and is ignored for
+// pull requests.
+func NewGitHubActionsCredential(name, repository string, entityType GitHubActionsEntityType, value string) (*FederatedIdentityCredential, error) {
+	if !strings.Contains(repository, "/") {
+		return nil, fmt.Errorf("repository %q must be in the form owner/repo", repository)
+	}
+
+	var subject string
+	switch entityType {
+	case GitHubActionsEntityTypeEnvironment:
+		subject = fmt.Sprintf("repo:%s:environment:%s", repository, value)
+	case GitHubActionsEntityTypeBranch:
+		subject = fmt.Sprintf("repo:%s:ref:refs/heads/%s", repository, value)
+	case GitHubActionsEntityTypeTag:
+		subject = fmt.Sprintf("repo:%s:ref:refs/tags/%s", repository, value)
+	case GitHubActionsEntityTypePullRequest:
+		subject = fmt.Sprintf("repo:%s:pull_request", repository)
+	default:
+		return nil, fmt.Errorf("unsupported GitHub Actions entity type %q", entityType)
+	}
+	if entityType != GitHubActionsEntityTypePullRequest && value == "" {
+		return nil, fmt.Errorf("a %s name is required", entityType)
+	}
+
+	return newFederatedIdentityCredential(name, GitHubActionsIssuer, subject), nil
+}
+
+// NewKubernetesServiceAccountCredential returns a federated identity credential trusting a Kubernetes service account.
+// The issuer is the OIDC issuer URL of the cluster, e.g. the oidcIssuerProfile.issuerURL of an AKS cluster.
+func NewKubernetesServiceAccountCredential(name, issuer, namespace, serviceAccount string) (*FederatedIdentityCredential, error) {
+	if namespace == "" || serviceAccount == "" {
+		return nil, errors.New("namespace and service account are required")
+	}
+	subject := fmt.Sprintf("system:serviceaccount:%s:%s", namespace, serviceAccount)
+	return newFederatedIdentityCredential(name, issuer, subject), nil
+}
+
+// NewTerraformCloudCredential returns a federated identity credential trusting Terraform Cloud runs for a workspace.
+// Plan and apply phases present different subjects, so a separate credential is needed for each.
+func NewTerraformCloudCredential(name, organization, project, workspace string, runPhase TerraformCloudRunPhase) (*FederatedIdentityCredential, error) {
+	if organization == "" || project == "" || workspace == "" {
+		return nil, errors.New("organization, project and workspace are required")
+	}
+	if runPhase != TerraformCloudRunPhasePlan && runPhase != TerraformCloudRunPhaseApply {
+		return nil, fmt.Errorf("unsupported Terraform Cloud run phase %q", runPhase)
+	}
+	subject := fmt.Sprintf("organization:%s:project:%s:workspace:%s:run_phase:%s", organization, project, workspace, runPhase)
+	return newFederatedIdentityCredential(name, TerraformCloudIssuer, subject), nil
+}
+
+// NewGitLabCredential returns a federated identity credential trusting GitLab CI/CD jobs on gitlab.com for a branch or
+// tag of a project. The project path includes its group, e.g. "my-group/my-project". For self-managed GitLab
+// instances, set the Issuer of the returned credential to the URL of the instance.
+func NewGitLabCredential(name, projectPath string, refType GitLabRefType, ref string) (*FederatedIdentityCredential, error) {
+	if !strings.Contains(projectPath, "/") {
+		return nil, fmt.Errorf("project path %q must include the group", projectPath)
+	}
+	if refType != GitLabRefTypeBranch && refType != GitLabRefTypeTag {
+		return nil, fmt.Errorf("unsupported GitLab ref type %q", refType)
+	}
+	if ref == "" {
+		return nil, errors.New("ref is required")
+	}
+	subject := fmt.Sprintf("project_path:%s:ref_type:%s:ref:%s", projectPath, refType, ref)
+	return newFederatedIdentityCredential(name, GitLabIssuer, subject), nil
+}
+
+// NewClaimsMatchingCredential returns a flexible federated identity credential, which matches tokens using a claims
+// matching expression instead of an exact subject. Flexible credentials are only available in the beta API, and only
+// for GitHub Actions, GitLab and Terraform Cloud issuers.
+func NewClaimsMatchingCredential(name, issuer, expression string) (*FederatedIdentityCredential, error) {
+	if expression == "" {
+		return nil, errors.New("expression is required")
+	}
+	credential := newFederatedIdentityCredential(name, issuer, "")
+	credential.Subject = nil
+	credential.ClaimsMatchingExpression = &FederatedIdentityExpression{
+		LanguageVersion: utils.IntPtr(FederatedIdentityExpressionLanguageVersion),
+		Value:           utils.StringPtr(expression),
+	}
+	return credential, nil
+}
+
+// ClaimMatches returns a claims matching expression comparing a claim against a pattern, in which "*" matches any
+// sequence of characters, e.g. ClaimMatches("sub", "repo:contoso/*:ref:refs/heads/*"). Expressions can be combined
+// with "and".
+func ClaimMatches(claim, pattern string) string {
+	return fmt.Sprintf("claims['%s'] matches '%s'", claim, strings.ReplaceAll(pattern, "'", "\\'"))
+}
+
+func newFederatedIdentityCredential(name, issuer, subject string) *FederatedIdentityCredential {
+	return &FederatedIdentityCredential{
+		Audiences: &[]string{FederatedIdentityCredentialAudience},
+		Issuer:    utils.StringPtr(issuer),
+		Name:      utils.StringPtr(name),
+		Subject:   utils.StringPtr(subject),
+	}
+}
+
+// ValidateFederatedIdentityCredential checks a federated identity credential against the constraints enforced by the
+// API, so that mistakes are reported before making any requests.
+func ValidateFederatedIdentityCredential(credential FederatedIdentityCredential) error {
+	if credential.Name == nil {
+		return errors.New("federated identity credential has no name")
+	}
+	name := *credential.Name
+	if !federatedIdentityCredentialNameRegexp.MatchString(name) {
+		return fmt.Errorf("federated identity credential %q: name must be 3-120 characters long, start with a letter or number, and contain only letters, numbers, hyphens and underscores", name)
+	}
+
+	if credential.Issuer == nil || *credential.Issuer == "" {
+		return fmt.Errorf("federated identity credential %q: issuer is required", name)
+	}
+	if u, err := url.Parse(*credential.Issuer); err != nil || u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("federated identity credential %q: issuer %q must be an https URL", name, *credential.Issuer)
+	}
+
+	if credential.Audiences == nil || len(*credential.Audiences) != 1 || (*credential.Audiences)[0] == "" {
+		return fmt.Errorf("federated identity credential %q: exactly one audience is required", name)
+	}
+	if len((*credential.Audiences)[0]) > 600 {
+		return fmt.Errorf("federated identity credential %q: audience must not exceed 600 characters", name)
+	}
+
+	hasSubject := credential.Subject != nil && *credential.Subject != ""
+	hasExpression := credential.ClaimsMatchingExpression != nil
+	switch {
+	case hasSubject && hasExpression:
+		return fmt.Errorf("federated identity credential %q: subject and claimsMatchingExpression cannot both be set", name)
+	case hasSubject:
+		if len(*credential.Subject) > 600 {
+			return fmt.Errorf("federated identity credential %q: subject must not exceed 600 characters", name)
+		}
+	case hasExpression:
+		expression := credential.ClaimsMatchingExpression
+		if expression.Value == nil || *expression.Value == "" {
+			return fmt.Errorf("federated identity credential %q: claimsMatchingExpression has no value", name)
+		}
+		if expression.LanguageVersion == nil || *expression.LanguageVersion != FederatedIdentityExpressionLanguageVersion {
+			return fmt.Errorf("federated identity credential %q: claimsMatchingExpression languageVersion must be %d", name, FederatedIdentityExpressionLanguageVersion)
+		}
+		supported := false
+		for _, issuer := range claimsMatchingExpressionIssuers {
+			if strings.EqualFold(strings.TrimSuffix(*credential.Issuer, "/"), issuer) {
+				supported = true
+			}
+		}
+		if !supported {
+			return fmt.Errorf("federated identity credential %q: claimsMatchingExpression is not supported for issuer %q", name, *credential.Issuer)
+		}
+	default:
+		return fmt.Errorf("federated identity credential %q: either subject or claimsMatchingExpression is required", name)
+	}
+
+	return nil
+}
+
+// ValidateFederatedIdentityCredentials validates each of the federated identity credentials for an application, and
+// checks that together they do not exceed FederatedIdentityCredentialsLimit, and that no two credentials share a name
+// or an issuer and subject.
+func ValidateFederatedIdentityCredentials(credentials []FederatedIdentityCredential) error {
+	if len(credentials) > FederatedIdentityCredentialsLimit {
+		return fmt.Errorf("%d federated identity credentials exceeds the limit of %d", len(credentials), FederatedIdentityCredentialsLimit)
+	}
+
+	names := make(map[string]bool)
+	subjects := make(map[string]string)
+	for _, credential := range credentials {
+		if err := ValidateFederatedIdentityCredential(credential); err != nil {
+			return err
+		}
+
+		name := strings.ToLower(*credential.Name)
+		if names[name] {
+			return fmt.Errorf("duplicate federated identity credential name %q", *credential.Name)
+		}
+		names[name] = true
+
+		if credential.Subject != nil {
+			key := *credential.Issuer + "|" + *credential.Subject
+			if existing, ok := subjects[key]; ok {
+				return fmt.Errorf("federated identity credentials %q and %q have the same issuer and subject", existing, *credential.Name)
+			}
+			subjects[key] = *credential.Name
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,237 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	goerrors "errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+
+	"github.com/manicminer/hamilton/errors"
+	"github.com/manicminer/hamilton/internal/utils"
+)
+
+func TestFederatedIdentityCredentialBuilders(t *testing.T) {
+	mustBuild := func(credential *FederatedIdentityCredential, err error) *FederatedIdentityCredential {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := ValidateFederatedIdentityCredential(*credential); err != nil {
+			t.Fatalf("ValidateFederatedIdentityCredential(): %v", err)
+		}
+		return credential
+	}
+
+	for _, tc := range []struct {
+		credential *FederatedIdentityCredential
+		issuer     string
+		subject    string
+	}{
+		{
+			credential: mustBuild(NewGitHubActionsCredential("gh-prod", "contoso/payroll", GitHubActionsEntityTypeEnvironment, "prod")),
+			issuer:     GitHubActionsIssuer,
+			subject:    "repo:contoso/payroll:environment:prod",
+		},
+		{
+			credential: mustBuild(NewGitHubActionsCredential("gh-main", "contoso/payroll", GitHubActionsEntityTypeBranch, "main")),
+			issuer:     GitHubActionsIssuer,
+			subject:    "repo:contoso/payroll:ref:refs/heads/main",
+		},
+		{
+			credential: mustBuild(NewGitHubActionsCredential("gh-release", "contoso/payroll", GitHubActionsEntityTypeTag, "v1.0.0")),
+			issuer:     GitHubActionsIssuer,
+			subject:    "repo:contoso/payroll:ref:refs/tags/v1.0.0",
+		},
+		{
+			credential: mustBuild(NewGitHubActionsCredential("gh-pr", "contoso/payroll", GitHubActionsEntityTypePullRequest, "")),
+			issuer:     GitHubActionsIssuer,
+			subject:    "repo:contoso/payroll:pull_request",
+		},
+		{
+			credential: mustBuild(NewKubernetesServiceAccountCredential("aks-worker", "https://oidc.example.com/tenant/", "payroll", "worker")),
+			issuer:     "https://oidc.example.com/tenant/",
+			subject:    "system:serviceaccount:payroll:worker",
+		},
+		{
+			credential: mustBuild(NewTerraformCloudCredential("tfc-apply", "contoso", "platform", "payroll", TerraformCloudRunPhaseApply)),
+			issuer:     TerraformCloudIssuer,
+			subject:    "organization:contoso:project:platform:workspace:payroll:run_phase:apply",
+		},
+		{
+			credential: mustBuild(NewGitLabCredential("gitlab-main", "contoso/payroll", GitLabRefTypeBranch, "main")),
+			issuer:     GitLabIssuer,
+			subject:    "project_path:contoso/payroll:ref_type:branch:ref:main",
+		},
+	} {
+		if *tc.credential.Issuer != tc.issuer || *tc.credential.Subject != tc.subject {
+			t.Errorf("%s: expected issuer %q and subject %q, got %q and %q", *tc.credential.Name, tc.issuer, tc.subject, *tc.credential.Issuer, *tc.credential.Subject)
+		}
+		if len(*tc.credential.Audiences) != 1 || (*tc.credential.Audiences)[0] != FederatedIdentityCredentialAudience {
+			t.Errorf("%s: unexpected audiences %v", *tc.credential.Name, *tc.credential.Audiences)
+		}
+	}
+
+	if _, err := NewGitHubActionsCredential("gh", "payroll", GitHubActionsEntityTypeBranch, "main"); err == nil {
+		t.Errorf("expected an error for a repository without an owner")
+	}
+	if _, err := NewGitHubActionsCredential("gh", "contoso/payroll", GitHubActionsEntityTypeEnvironment, ""); err == nil {
+		t.Errorf("expected an error for an environment without a name")
+	}
+	if _, err := NewTerraformCloudCredential("tfc", "contoso", "platform", "payroll", "destroy"); err == nil {
+		t.Errorf("expected an error for an unsupported run phase")
+	}
+}
+
+func TestNewClaimsMatchingCredential(t *testing.T) {
+	credential, err := NewClaimsMatchingCredential("gh-any-branch", GitHubActionsIssuer, ClaimMatches("sub", "repo:contoso/*:ref:refs/heads/*"))
+	if err != nil {
+		t.Fatalf("NewClaimsMatchingCredential(): %v", err)
+	}
+	if err := ValidateFederatedIdentityCredential(*credential); err != nil {
+		t.Fatalf("ValidateFederatedIdentityCredential(): %v", err)
+	}
+
+	body, err := json.Marshal(credential)
+	if err != nil {
+		t.Fatalf("json.Marshal(): %v", err)
+	}
+	expected := `{"audiences":["api://AzureADTokenExchange"],"claimsMatchingExpression":{"languageVersion":1,"value":"claims['sub'] matches 'repo:contoso/*:ref:refs/heads/*'"},"issuer":"https://token.actions.githubusercontent.com","name":"gh-any-branch"}`
+	if string(body) != expected {
+		t.Errorf("unexpected JSON:\n%s\nexpected:\n%s", body, expected)
+	}
+
+	credential.Issuer = utils.StringPtr("https://oidc.example.com")
+	if err := ValidateFederatedIdentityCredential(*credential); err == nil {
+		t.Errorf("expected an error for a claims matching expression with an unsupported issuer")
+	}
+
+	credential.Issuer = utils.StringPtr(GitHubActionsIssuer)
+	credential.Subject = utils.StringPtr("repo:contoso/payroll:pull_request")
+	if err := ValidateFederatedIdentityCredential(*credential); err == nil {
+		t.Errorf("expected an error for a credential with both a subject and a claims matching expression")
+	}
+}
+
+func TestValidateFederatedIdentityCredentials(t *testing.T) {
+	credentials := make([]FederatedIdentityCredential, 0)
+	for i := 0; i < FederatedIdentityCredentialsLimit; i++ {
+		credential, err := NewGitHubActionsCredential(fmt.Sprintf("env-%d", i), "contoso/payroll", GitHubActionsEntityTypeEnvironment, fmt.Sprintf("env-%d", i))
+		if err != nil {
+			t.Fatalf("NewGitHubActionsCredential(): %v", err)
+		}
+		credentials = append(credentials, *credential)
+	}
+	if err := ValidateFederatedIdentityCredentials(credentials); err != nil {
+		t.Fatalf("ValidateFederatedIdentityCredentials(): %v", err)
+	}
+
+	credential, _ := NewGitHubActionsCredential("one-too-many", "contoso/payroll", GitHubActionsEntityTypeBranch, "main")
+	if err := ValidateFederatedIdentityCredentials(append(credentials, *credential)); err == nil || !strings.Contains(err.Error(), "limit") {
+		t.Errorf("expected an error when exceeding the limit, got %v", err)
+	}
+
+	duplicate := credentials[0]
+	duplicate.Name = utils.StringPtr("duplicate-subject")
+	if err := ValidateFederatedIdentityCredentials([]FederatedIdentityCredential{credentials[0], duplicate}); err == nil {
+		t.Errorf("expected an error for credentials with the same issuer and subject")
+	}
+
+	invalid := credentials[0]
+	invalid.Name = utils.StringPtr("-x")
+	if err := ValidateFederatedIdentityCredentials([]FederatedIdentityCredential{invalid}); err == nil {
+		t.Errorf("expected an error for an invalid name")
+	}
+}
+
+func TestApplicationsClient_FederatedIdentityCredentialApiVersion(t *testing.T) {
+	requests := make([]string, 0)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method {
+		case http.MethodPost:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":"credential-id"}`))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	c := NewApplicationsClient(WithEndpoint(ts.URL), WithRetryMax(0), WithApiVersion(Version10))
+
+	flexible, err := NewClaimsMatchingCredential("any-branch", GitHubActionsIssuer, ClaimMatches("sub", "repo:contoso/payroll:ref:refs/heads/*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var versionErr errors.UnsupportedApiVersionError
+	if _, _, err := c.CreateFederatedIdentityCredential(ctx, "app-id", *flexible); !goerrors.As(err, &versionErr) || versionErr.Fields[0] != "claimsMatchingExpression" {
+		t.Errorf("CreateFederatedIdentityCredential(): expected UnsupportedApiVersionError, got: %v", err)
+	}
+	flexible.ID = utils.StringPtr("credential-id")
+	if _, err := c.UpdateFederatedIdentityCredential(ctx, "app-id", *flexible); !goerrors.As(err, &versionErr) {
+		t.Errorf("UpdateFederatedIdentityCredential(): expected UnsupportedApiVersionError, got: %v", err)
+	}
+	if len(requests) != 0 {
+		t.Fatalf("expected no requests to be sent, got: %v", requests)
+	}
+
+	credential, err := NewGitHubActionsCredential("deploy", "contoso/payroll", GitHubActionsEntityTypeEnvironment, "prod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := c.CreateFederatedIdentityCredential(ctx, "app-id", *credential); err != nil {
+		t.Errorf("CreateFederatedIdentityCredential(): %v", err)
+	}
+	if len(requests) != 1 || requests[0] != "POST /v1.0/applications/app-id/federatedIdentityCredentials" {
+		t.Errorf("unexpected requests: %v", requests)
+	}
+}
+
+func TestApplicationsClient_UpdateExpandedFederatedIdentityCredentials(t *testing.T) {
+	var patched map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/beta/applications/app-id":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id":"app-id","displayName":"payroll","federatedIdentityCredentials":[{"id":"credential-id","name":"deploy"}]}`))
+		case r.Method == http.MethodPatch && r.URL.Path == "/beta/applications/app-id":
+			if err := json.NewDecoder(r.Body).Decode(&patched); err != nil {
+				t.Errorf("json.Decode(): %v", err)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	c := NewApplicationsClient(WithEndpoint(ts.URL), WithRetryMax(0))
+
+	app, _, err := c.Get(ctx, "app-id", odata.Query{Expand: odata.Expand{Relationship: "federatedIdentityCredentials"}})
+	if err != nil {
+		t.Fatalf("Get(): %v", err)
+	}
+	if app.FederatedIdentityCredentials == nil || len(*app.FederatedIdentityCredentials) != 1 {
+		t.Fatalf("expected expanded federated identity credentials, got: %v", app.FederatedIdentityCredentials)
+	}
+
+	if _, err := c.Update(ctx, *app); err != nil {
+		t.Fatalf("Update(): %v", err)
+	}
+	if _, ok := patched["federatedIdentityCredentials"]; ok {
+		t.Errorf("expected federatedIdentityCredentials not to be sent, got: %v", patched)
+	}
+	if patched["displayName"] != "payroll" {
+		t.Errorf("expected displayName to be sent, got: %v", patched)
+	}
+}
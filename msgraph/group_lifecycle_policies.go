@@ -0,0 +1,263 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+)
+
+// GroupLifecyclePoliciesClient performs operations on GroupLifecyclePolicies, which configure expiration for Microsoft 365 groups.
+type GroupLifecyclePoliciesClient struct {
+	BaseClient Client
+}
+
+// NewGroupLifecyclePoliciesClient returns a new GroupLifecyclePoliciesClient.
+func NewGroupLifecyclePoliciesClient(opts ...ClientOption) *GroupLifecyclePoliciesClient {
+	return &GroupLifecyclePoliciesClient{
+		BaseClient: NewClient(Version10, opts...),
+	}
+}
+
+// List returns a list of GroupLifecyclePolicies, optionally queried using OData.
+func (c *GroupLifecyclePoliciesClient) List(ctx context.Context, query odata.Query) (*[]GroupLifecyclePolicy, int, error) {
+	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+		DisablePaging:    query.Top > 0,
+		OData:            query,
+		ValidStatusCodes: []int{http.StatusOK},
+		Uri: Uri{
+			Entity: "/groupLifecyclePolicies",
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("GroupLifecyclePoliciesClient.BaseClient.Get(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var data struct {
+		GroupLifecyclePolicies []GroupLifecyclePolicy `json:"value"`
+	}
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &data.GroupLifecyclePolicies, status, nil
+}
+
+// ListForGroup returns the GroupLifecyclePolicies that apply to the specified Group.
+func (c *GroupLifecyclePoliciesClient) ListForGroup(ctx context.Context, groupId string) (*[]GroupLifecyclePolicy, int, error) {
+	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusOK},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/groups/%s/groupLifecyclePolicies", groupId),
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("GroupLifecyclePoliciesClient.BaseClient.Get(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var data struct {
+		GroupLifecyclePolicies []GroupLifecyclePolicy `json:"value"`
+	}
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &data.GroupLifecyclePolicies, status, nil
+}
+
+// Create creates a new GroupLifecyclePolicy.
+func (c *GroupLifecyclePoliciesClient) Create(ctx context.Context, policy GroupLifecyclePolicy) (*GroupLifecyclePolicy, int, error) {
+	var status int
+
+	body, err := json.Marshal(policy)
+	if err != nil {
+		return nil, status, fmt.Errorf("json.Marshal(): %v", err)
+	}
+
+	resp, status, _, err := c.BaseClient.Post(ctx, PostHttpRequestInput{
+		Body:             body,
+		ValidStatusCodes: []int{http.StatusCreated},
+		Uri: Uri{
+			Entity: "/groupLifecyclePolicies",
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("GroupLifecyclePoliciesClient.BaseClient.Post(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var newPolicy GroupLifecyclePolicy
+	if err := json.Unmarshal(respBody, &newPolicy); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &newPolicy, status, nil
+}
+
+// Get retrieves a GroupLifecyclePolicy.
+func (c *GroupLifecyclePoliciesClient) Get(ctx context.Context, id string, query odata.Query) (*GroupLifecyclePolicy, int, error) {
+	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		OData:                  query,
+		ValidStatusCodes:       []int{http.StatusOK},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/groupLifecyclePolicies/%s", id),
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("GroupLifecyclePoliciesClient.BaseClient.Get(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var policy GroupLifecyclePolicy
+	if err := json.Unmarshal(respBody, &policy); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &policy, status, nil
+}
+
+// Update amends an existing GroupLifecyclePolicy.
+func (c *GroupLifecyclePoliciesClient) Update(ctx context.Context, policy GroupLifecyclePolicy) (int, error) {
+	var status int
+
+	if policy.ID == nil {
+		return status, fmt.Errorf("cannot update GroupLifecyclePolicy with nil ID")
+	}
+
+	policyId := *policy.ID
+	policy.ID = nil
+
+	body, err := json.Marshal(policy)
+	if err != nil {
+		return status, fmt.Errorf("json.Marshal(): %v", err)
+	}
+
+	_, status, _, err = c.BaseClient.Patch(ctx, PatchHttpRequestInput{
+		Body:                   body,
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes: []int{
+			http.StatusOK,
+			http.StatusNoContent,
+		},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/groupLifecyclePolicies/%s", policyId),
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("GroupLifecyclePoliciesClient.BaseClient.Patch(): %v", err)
+	}
+
+	return status, nil
+}
+
+// Delete removes a GroupLifecyclePolicy.
+func (c *GroupLifecyclePoliciesClient) Delete(ctx context.Context, id string) (int, error) {
+	_, status, _, err := c.BaseClient.Delete(ctx, DeleteHttpRequestInput{
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusNoContent},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/groupLifecyclePolicies/%s", id),
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("GroupLifecyclePoliciesClient.BaseClient.Delete(): %v", err)
+	}
+
+	return status, nil
+}
+
+// AddGroup adds a Group to a GroupLifecyclePolicy. This is only applicable when the policy applies to selected groups.
+func (c *GroupLifecyclePoliciesClient) AddGroup(ctx context.Context, id, groupId string) (int, error) {
+	return c.groupAction(ctx, id, groupId, "addGroup")
+}
+
+// RemoveGroup removes a Group from a GroupLifecyclePolicy. This is only applicable when the policy applies to selected groups.
+func (c *GroupLifecyclePoliciesClient) RemoveGroup(ctx context.Context, id, groupId string) (int, error) {
+	return c.groupAction(ctx, id, groupId, "removeGroup")
+}
+
+func (c *GroupLifecyclePoliciesClient) groupAction(ctx context.Context, id, groupId, action string) (int, error) {
+	var status int
+
+	body, err := json.Marshal(struct {
+		GroupId string `json:"groupId"`
+	}{
+		GroupId: groupId,
+	})
+	if err != nil {
+		return status, fmt.Errorf("json.Marshal(): %v", err)
+	}
+
+	resp, status, _, err := c.BaseClient.Post(ctx, PostHttpRequestInput{
+		Body:                   body,
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusOK},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/groupLifecyclePolicies/%s/%s", id, action),
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("GroupLifecyclePoliciesClient.BaseClient.Post(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var data struct {
+		Value bool `json:"value"`
+	}
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+	if !data.Value {
+		return status, fmt.Errorf("GroupLifecyclePoliciesClient.%s(): group %q was not updated", action, groupId)
+	}
+
+	return status, nil
+}
+
+// RenewGroup renews a Group's expiration, extending it by the number of days defined in the applicable policy.
+func (c *GroupLifecyclePoliciesClient) RenewGroup(ctx context.Context, groupId string) (int, error) {
+	_, status, _, err := c.BaseClient.Post(ctx, PostHttpRequestInput{
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusNoContent},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/groups/%s/renew", groupId),
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("GroupLifecyclePoliciesClient.BaseClient.Post(): %v", err)
+	}
+
+	return status, nil
+}
@@ -0,0 +1,150 @@
+package msgraph_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+	"github.com/manicminer/hamilton/internal/test"
+	"github.com/manicminer/hamilton/internal/utils"
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+func TestGroupLifecyclePoliciesClient(t *testing.T) {
+	c := test.NewTest(t)
+	defer c.CancelFunc()
+
+	// a tenant can only have a single group lifecycle policy
+	if policies := testGroupLifecyclePoliciesClient_List(t, c); len(*policies) > 0 {
+		t.Skip("skipping as a group lifecycle policy already exists")
+	}
+
+	group := testGroupsClient_Create(t, c, msgraph.Group{
+		DisplayName:     utils.StringPtr("test-group-lifecycle"),
+		GroupTypes:      &[]msgraph.GroupType{msgraph.GroupTypeUnified},
+		MailEnabled:     utils.BoolPtr(true),
+		MailNickname:    utils.StringPtr(fmt.Sprintf("test-group-lifecycle-%s", c.RandomString)),
+		SecurityEnabled: utils.BoolPtr(false),
+	})
+
+	policy := testGroupLifecyclePoliciesClient_Create(t, c, msgraph.GroupLifecyclePolicy{
+		AlternateNotificationEmails: utils.StringPtr(fmt.Sprintf("admin-%s@%s", c.RandomString, c.Connections["default"].DomainName)),
+		GroupLifetimeInDays:         utils.Int32Ptr(180),
+		ManagedGroupTypes:           utils.StringPtr(msgraph.GroupLifecyclePolicyManagedGroupTypesSelected),
+	})
+	testGroupLifecyclePoliciesClient_Get(t, c, *policy.ID)
+
+	testGroupLifecyclePoliciesClient_AddGroup(t, c, *policy.ID, *group.ID())
+	testGroupLifecyclePoliciesClient_ListForGroup(t, c, *group.ID())
+	testGroupLifecyclePoliciesClient_RenewGroup(t, c, *group.ID())
+	testGroupLifecyclePoliciesClient_RemoveGroup(t, c, *policy.ID, *group.ID())
+
+	policy.GroupLifetimeInDays = utils.Int32Ptr(365)
+	testGroupLifecyclePoliciesClient_Update(t, c, *policy)
+
+	testGroupLifecyclePoliciesClient_Delete(t, c, *policy.ID)
+	testGroupsClient_Delete(t, c, *group.ID())
+}
+
+func testGroupLifecyclePoliciesClient_Create(t *testing.T, c *test.Test, p msgraph.GroupLifecyclePolicy) (policy *msgraph.GroupLifecyclePolicy) {
+	policy, status, err := c.GroupLifecyclePoliciesClient.Create(c.Context, p)
+	if err != nil {
+		t.Fatalf("GroupLifecyclePoliciesClient.Create(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("GroupLifecyclePoliciesClient.Create(): invalid status: %d", status)
+	}
+	if policy == nil {
+		t.Fatal("GroupLifecyclePoliciesClient.Create(): policy was nil")
+	}
+	if policy.ID == nil {
+		t.Fatal("GroupLifecyclePoliciesClient.Create(): policy.ID was nil")
+	}
+	return
+}
+
+func testGroupLifecyclePoliciesClient_List(t *testing.T, c *test.Test) (policies *[]msgraph.GroupLifecyclePolicy) {
+	policies, _, err := c.GroupLifecyclePoliciesClient.List(c.Context, odata.Query{})
+	if err != nil {
+		t.Fatalf("GroupLifecyclePoliciesClient.List(): %v", err)
+	}
+	if policies == nil {
+		t.Fatal("GroupLifecyclePoliciesClient.List(): policies was nil")
+	}
+	return
+}
+
+func testGroupLifecyclePoliciesClient_ListForGroup(t *testing.T, c *test.Test, groupId string) (policies *[]msgraph.GroupLifecyclePolicy) {
+	policies, _, err := c.GroupLifecyclePoliciesClient.ListForGroup(c.Context, groupId)
+	if err != nil {
+		t.Fatalf("GroupLifecyclePoliciesClient.ListForGroup(): %v", err)
+	}
+	if policies == nil || len(*policies) == 0 {
+		t.Fatal("GroupLifecyclePoliciesClient.ListForGroup(): expected the group to have a policy")
+	}
+	return
+}
+
+func testGroupLifecyclePoliciesClient_Get(t *testing.T, c *test.Test, id string) (policy *msgraph.GroupLifecyclePolicy) {
+	policy, status, err := c.GroupLifecyclePoliciesClient.Get(c.Context, id, odata.Query{})
+	if err != nil {
+		t.Fatalf("GroupLifecyclePoliciesClient.Get(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("GroupLifecyclePoliciesClient.Get(): invalid status: %d", status)
+	}
+	if policy == nil {
+		t.Fatal("GroupLifecyclePoliciesClient.Get(): policy was nil")
+	}
+	return
+}
+
+func testGroupLifecyclePoliciesClient_Update(t *testing.T, c *test.Test, p msgraph.GroupLifecyclePolicy) {
+	status, err := c.GroupLifecyclePoliciesClient.Update(c.Context, p)
+	if err != nil {
+		t.Fatalf("GroupLifecyclePoliciesClient.Update(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("GroupLifecyclePoliciesClient.Update(): invalid status: %d", status)
+	}
+}
+
+func testGroupLifecyclePoliciesClient_Delete(t *testing.T, c *test.Test, id string) {
+	status, err := c.GroupLifecyclePoliciesClient.Delete(c.Context, id)
+	if err != nil {
+		t.Fatalf("GroupLifecyclePoliciesClient.Delete(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("GroupLifecyclePoliciesClient.Delete(): invalid status: %d", status)
+	}
+}
+
+func testGroupLifecyclePoliciesClient_AddGroup(t *testing.T, c *test.Test, id, groupId string) {
+	status, err := c.GroupLifecyclePoliciesClient.AddGroup(c.Context, id, groupId)
+	if err != nil {
+		t.Fatalf("GroupLifecyclePoliciesClient.AddGroup(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("GroupLifecyclePoliciesClient.AddGroup(): invalid status: %d", status)
+	}
+}
+
+func testGroupLifecyclePoliciesClient_RemoveGroup(t *testing.T, c *test.Test, id, groupId string) {
+	status, err := c.GroupLifecyclePoliciesClient.RemoveGroup(c.Context, id, groupId)
+	if err != nil {
+		t.Fatalf("GroupLifecyclePoliciesClient.RemoveGroup(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("GroupLifecyclePoliciesClient.RemoveGroup(): invalid status: %d", status)
+	}
+}
+
+func testGroupLifecyclePoliciesClient_RenewGroup(t *testing.T, c *test.Test, groupId string) {
+	status, err := c.GroupLifecyclePoliciesClient.RenewGroup(c.Context, groupId)
+	if err != nil {
+		t.Fatalf("GroupLifecyclePoliciesClient.RenewGroup(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("GroupLifecyclePoliciesClient.RenewGroup(): invalid status: %d", status)
+	}
+}
@@ -0,0 +1,242 @@
+package msgraph
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	// GroupSettingTemplateIdUnified is the ID of the Group.Unified template, which configures Microsoft 365 groups for the tenant.
+	GroupSettingTemplateIdUnified = "62375ab9-6b52-47ed-826b-58e47e0e304b"
+
+	// GroupSettingTemplateIdUnifiedGuest is the ID of the Group.Unified.Guest template, which configures guest access for a single group.
+	GroupSettingTemplateIdUnifiedGuest = "08d542b9-071f-4e16-94b0-74abb372e3d9"
+)
+
+// GroupUnifiedSettings holds the values of the Group.Unified setting template. Fields which are nil are not set,
+// and take the default value from the template when a setting is created.
+type GroupUnifiedSettings struct {
+	AllowGuestsToAccessGroups       *bool                             `setting:"AllowGuestsToAccessGroups"`
+	AllowGuestsToBeGroupOwner       *bool                             `setting:"AllowGuestsToBeGroupOwner"`
+	AllowToAddGuests                *bool                             `setting:"AllowToAddGuests"`
+	ClassificationDescriptions      *[]GroupClassificationDescription `setting:"ClassificationDescriptions"`
+	ClassificationList              *[]string                         `setting:"ClassificationList"`
+	CustomBlockedWordsList          *[]string                         `setting:"CustomBlockedWordsList"`
+	DefaultClassification           *string                           `setting:"DefaultClassification"`
+	EnableGroupCreation             *bool                             `setting:"EnableGroupCreation"`
+	EnableMIPLabels                 *bool                             `setting:"EnableMIPLabels"`
+	EnableMSStandardBlockedWords    *bool                             `setting:"EnableMSStandardBlockedWords"`
+	GroupCreationAllowedGroupId     *string                           `setting:"GroupCreationAllowedGroupId"`
+	GuestUsageGuidelinesUrl         *string                           `setting:"GuestUsageGuidelinesUrl"`
+	NewUnifiedGroupWritebackDefault *bool                             `setting:"NewUnifiedGroupWritebackDefault"`
+	PrefixSuffixNamingRequirement   *GroupNamingRequirement           `setting:"PrefixSuffixNamingRequirement"`
+	UsageGuidelinesUrl              *string                           `setting:"UsageGuidelinesUrl"`
+}
+
+// GroupSetting returns a new GroupSetting for the Group.Unified template, containing the values which are set.
+func (s GroupUnifiedSettings) GroupSetting() GroupSetting {
+	return newGroupSetting(GroupSettingTemplateIdUnified, s)
+}
+
+// Apply sets the values which are set in an existing GroupSetting, retaining any other values.
+func (s GroupUnifiedSettings) Apply(setting *GroupSetting) {
+	applyGroupSettingValues(setting, s)
+}
+
+// ParseGroupUnifiedSettings reads the values of a GroupSetting created from the Group.Unified template.
+func ParseGroupUnifiedSettings(setting GroupSetting) (*GroupUnifiedSettings, error) {
+	var s GroupUnifiedSettings
+	if err := parseGroupSettingValues(setting, GroupSettingTemplateIdUnified, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// GroupUnifiedGuestSettings holds the values of the Group.Unified.Guest setting template, which is applied to a single group.
+type GroupUnifiedGuestSettings struct {
+	AllowToAddGuests *bool `setting:"AllowToAddGuests"`
+}
+
+// GroupSetting returns a new GroupSetting for the Group.Unified.Guest template, containing the values which are set.
+func (s GroupUnifiedGuestSettings) GroupSetting() GroupSetting {
+	return newGroupSetting(GroupSettingTemplateIdUnifiedGuest, s)
+}
+
+// Apply sets the values which are set in an existing GroupSetting, retaining any other values.
+func (s GroupUnifiedGuestSettings) Apply(setting *GroupSetting) {
+	applyGroupSettingValues(setting, s)
+}
+
+// ParseGroupUnifiedGuestSettings reads the values of a GroupSetting created from the Group.Unified.Guest template.
+func ParseGroupUnifiedGuestSettings(setting GroupSetting) (*GroupUnifiedGuestSettings, error) {
+	var s GroupUnifiedGuestSettings
+	if err := parseGroupSettingValues(setting, GroupSettingTemplateIdUnifiedGuest, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// GroupClassificationDescription describes a classification from the ClassificationList setting.
+type GroupClassificationDescription struct {
+	Classification string
+	Description    string
+}
+
+// GroupNamingRequirement is the prefix and suffix applied to the names of Microsoft 365 groups. Each may contain fixed
+// strings and user attribute placeholders such as [Department], [Company], [Office], [StateOrProvince],
+// [CountryOrRegion] and [Title].
+type GroupNamingRequirement struct {
+	Prefix string
+	Suffix string
+}
+
+const groupNamingRequirementGroupName = "[GroupName]"
+
+// String returns the value of the PrefixSuffixNamingRequirement setting, i.e. "GRP_[GroupName]_[Department]".
+func (r GroupNamingRequirement) String() string {
+	return r.Prefix + groupNamingRequirementGroupName + r.Suffix
+}
+
+// ParseGroupNamingRequirement parses the value of the PrefixSuffixNamingRequirement setting.
+func ParseGroupNamingRequirement(value string) (*GroupNamingRequirement, error) {
+	if strings.Count(value, groupNamingRequirementGroupName) != 1 {
+		return nil, fmt.Errorf("naming requirement %q must contain %s exactly once", value, groupNamingRequirementGroupName)
+	}
+	prefix, suffix, _ := strings.Cut(value, groupNamingRequirementGroupName)
+	return &GroupNamingRequirement{Prefix: prefix, Suffix: suffix}, nil
+}
+
+func newGroupSetting(templateId string, settings interface{}) GroupSetting {
+	values := make([]SettingValue, 0)
+	setting := GroupSetting{TemplateId: &templateId, Values: &values}
+	applyGroupSettingValues(&setting, settings)
+	return setting
+}
+
+func applyGroupSettingValues(setting *GroupSetting, settings interface{}) {
+	if setting.Values == nil {
+		setting.Values = &[]SettingValue{}
+	}
+
+	v := reflect.ValueOf(settings)
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := v.Field(i)
+		if field.IsNil() {
+			continue
+		}
+		name := t.Field(i).Tag.Get("setting")
+		value := encodeGroupSettingValue(field.Elem())
+
+		found := false
+		for j, existing := range *setting.Values {
+			if existing.Name != nil && strings.EqualFold(*existing.Name, name) {
+				(*setting.Values)[j].Value = &value
+				found = true
+			}
+		}
+		if !found {
+			n := name
+			*setting.Values = append(*setting.Values, SettingValue{Name: &n, Value: &value})
+		}
+	}
+}
+
+func encodeGroupSettingValue(v reflect.Value) string {
+	switch value := v.Interface().(type) {
+	case bool:
+		return strconv.FormatBool(value)
+	case []string:
+		return strings.Join(value, ",")
+	case []GroupClassificationDescription:
+		descriptions := make([]string, len(value))
+		for i, d := range value {
+			descriptions[i] = fmt.Sprintf("%s:%s", d.Classification, d.Description)
+		}
+		return strings.Join(descriptions, ",")
+	case GroupNamingRequirement:
+		return value.String()
+	}
+	return v.String()
+}
+
+func parseGroupSettingValues(setting GroupSetting, templateId string, settings interface{}) error {
+	if setting.TemplateId != nil && !strings.EqualFold(*setting.TemplateId, templateId) {
+		return fmt.Errorf("setting was created from template %q, expected %q", *setting.TemplateId, templateId)
+	}
+	if setting.Values == nil {
+		return nil
+	}
+
+	v := reflect.ValueOf(settings).Elem()
+	t := v.Type()
+	for _, sv := range *setting.Values {
+		if sv.Name == nil || sv.Value == nil {
+			continue
+		}
+		for i := 0; i < t.NumField(); i++ {
+			if !strings.EqualFold(t.Field(i).Tag.Get("setting"), *sv.Name) {
+				continue
+			}
+			value, err := decodeGroupSettingValue(t.Field(i).Type.Elem(), *sv.Value)
+			if err != nil {
+				return fmt.Errorf("parsing value for %q: %v", *sv.Name, err)
+			}
+			if value.IsValid() {
+				ptr := reflect.New(value.Type())
+				ptr.Elem().Set(value)
+				v.Field(i).Set(ptr)
+			}
+		}
+	}
+
+	return nil
+}
+
+func decodeGroupSettingValue(t reflect.Type, value string) (reflect.Value, error) {
+	switch t {
+	case reflect.TypeOf(true):
+		if value == "" {
+			return reflect.Value{}, nil
+		}
+		b, err := strconv.ParseBool(value)
+		return reflect.ValueOf(b), err
+
+	case reflect.TypeOf([]string{}):
+		values := make([]string, 0)
+		for _, s := range strings.Split(value, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				values = append(values, s)
+			}
+		}
+		return reflect.ValueOf(values), nil
+
+	case reflect.TypeOf([]GroupClassificationDescription{}):
+		descriptions := make([]GroupClassificationDescription, 0)
+		for _, s := range strings.Split(value, ",") {
+			if s = strings.TrimSpace(s); s == "" {
+				continue
+			}
+			classification, description, _ := strings.Cut(s, ":")
+			descriptions = append(descriptions, GroupClassificationDescription{
+				Classification: strings.TrimSpace(classification),
+				Description:    strings.TrimSpace(description),
+			})
+		}
+		return reflect.ValueOf(descriptions), nil
+
+	case reflect.TypeOf(GroupNamingRequirement{}):
+		if value == "" {
+			return reflect.Value{}, nil
+		}
+		r, err := ParseGroupNamingRequirement(value)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(*r), nil
+	}
+
+	return reflect.ValueOf(value), nil
+}
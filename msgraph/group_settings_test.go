@@ -0,0 +1,71 @@
+package msgraph
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/manicminer/hamilton/internal/utils"
+)
+
+func TestGroupUnifiedSettings(t *testing.T) {
+	settings := GroupUnifiedSettings{
+		AllowToAddGuests: utils.BoolPtr(false),
+		ClassificationDescriptions: &[]GroupClassificationDescription{
+			{Classification: "Low", Description: "Public information"},
+			{Classification: "High", Description: "Confidential"},
+		},
+		ClassificationList:            &[]string{"Low", "High"},
+		PrefixSuffixNamingRequirement: &GroupNamingRequirement{Prefix: "GRP_", Suffix: "_[Department]"},
+	}
+
+	setting := settings.GroupSetting()
+	if setting.TemplateId == nil || *setting.TemplateId != GroupSettingTemplateIdUnified {
+		t.Fatalf("unexpected template ID: %v", setting.TemplateId)
+	}
+
+	values := make(map[string]string)
+	for _, v := range *setting.Values {
+		values[*v.Name] = *v.Value
+	}
+	expected := map[string]string{
+		"AllowToAddGuests":              "false",
+		"ClassificationDescriptions":    "Low:Public information,High:Confidential",
+		"ClassificationList":            "Low,High",
+		"PrefixSuffixNamingRequirement": "GRP_[GroupName]_[Department]",
+	}
+	if !reflect.DeepEqual(values, expected) {
+		t.Fatalf("unexpected values: %v", values)
+	}
+
+	// values not set in the settings are retained when applied
+	GroupUnifiedSettings{AllowToAddGuests: utils.BoolPtr(true)}.Apply(&setting)
+	parsed, err := ParseGroupUnifiedSettings(setting)
+	if err != nil {
+		t.Fatalf("ParseGroupUnifiedSettings(): %v", err)
+	}
+	settings.AllowToAddGuests = utils.BoolPtr(true)
+	if !reflect.DeepEqual(*parsed, settings) {
+		t.Fatalf("unexpected settings: %+v", *parsed)
+	}
+
+	if _, err := ParseGroupUnifiedGuestSettings(setting); err == nil {
+		t.Fatal("expected an error parsing a setting from a different template")
+	}
+}
+
+func TestParseGroupNamingRequirement(t *testing.T) {
+	for _, value := range []string{"GRP_[GroupName]", "[GroupName]_[Department]", "[GroupName]"} {
+		r, err := ParseGroupNamingRequirement(value)
+		if err != nil {
+			t.Fatalf("ParseGroupNamingRequirement(%q): %v", value, err)
+		}
+		if r.String() != value {
+			t.Fatalf("ParseGroupNamingRequirement(%q): round trip returned %q", value, r.String())
+		}
+	}
+	for _, value := range []string{"GRP_", "[GroupName]_[GroupName]"} {
+		if _, err := ParseGroupNamingRequirement(value); err == nil {
+			t.Fatalf("ParseGroupNamingRequirement(%q): expected an error", value)
+		}
+	}
+}
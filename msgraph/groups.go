@@ -16,9 +16,9 @@ type GroupsClient struct {
 }
 
 // NewGroupsClient returns a new GroupsClient.
-func NewGroupsClient() *GroupsClient {
+func NewGroupsClient(opts ...ClientOption) *GroupsClient {
 	return &GroupsClient{
-		BaseClient: NewClient(VersionBeta),
+		BaseClient: NewClient(VersionBeta, opts...),
 	}
 }
 
@@ -56,6 +56,10 @@ func (c *GroupsClient) List(ctx context.Context, query odata.Query) (*[]Group, i
 func (c *GroupsClient) Create(ctx context.Context, group Group) (*Group, int, error) {
 	var status int
 
+	if err := c.BaseClient.validateFields("GroupsClient.Create()", group); err != nil {
+		return nil, status, err
+	}
+
 	body, err := json.Marshal(group)
 	if err != nil {
 		return nil, status, fmt.Errorf("json.Marshal(): %v", err)
@@ -209,6 +213,13 @@ func (c *GroupsClient) Update(ctx context.Context, group Group) (int, error) {
 	group.Id = nil
 	group.ObjectId = nil
 
+	// assignedLicenses is read-only, licenses are assigned with GroupsClient.AssignLicense
+	group.AssignedLicenses = nil
+
+	if err := c.BaseClient.validateFields("GroupsClient.Update()", group); err != nil {
+		return status, err
+	}
+
 	body, err := json.Marshal(group)
 	if err != nil {
 		return status, fmt.Errorf("json.Marshal(): %v", err)
@@ -232,6 +243,92 @@ func (c *GroupsClient) Update(ctx context.Context, group Group) (int, error) {
 	return status, nil
 }
 
+// GetByUniqueName retrieves a Group using its uniqueName alternate key.
+func (c *GroupsClient) GetByUniqueName(ctx context.Context, uniqueName string, query odata.Query) (*Group, int, error) {
+	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		OData:                  query,
+		ValidStatusCodes:       []int{http.StatusOK},
+		Uri: Uri{
+			Entity: alternateKey("groups", "uniqueName", uniqueName),
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("GroupsClient.BaseClient.Get(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var group Group
+	if err := json.Unmarshal(respBody, &group); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &group, status, nil
+}
+
+// Upsert creates or updates the Group with the uniqueName of the provided group. The returned bool is true when a new
+// group was created, and false when an existing group was updated. Either way, the returned Group is the group as it
+// exists after the upsert.
+func (c *GroupsClient) Upsert(ctx context.Context, group Group) (*Group, bool, int, error) {
+	var status int
+
+	if group.UniqueName == nil || *group.UniqueName == "" {
+		return nil, false, status, fmt.Errorf("cannot upsert group with nil or empty uniqueName")
+	}
+
+	uniqueName := *group.UniqueName
+	group.DirectoryObject = DirectoryObject{}
+	group.UniqueName = nil
+	group.AssignedLicenses = nil
+
+	if err := c.BaseClient.validateFields("GroupsClient.Upsert()", group); err != nil {
+		return nil, false, status, err
+	}
+
+	body, err := json.Marshal(group)
+	if err != nil {
+		return nil, false, status, fmt.Errorf("json.Marshal(): %v", err)
+	}
+
+	resp, status, _, err := c.BaseClient.Patch(ctx, PatchHttpRequestInput{
+		Body:             body,
+		Prefer:           preferCreateIfMissing,
+		ValidStatusCodes: []int{http.StatusCreated, http.StatusNoContent},
+		Uri: Uri{
+			Entity: alternateKey("groups", "uniqueName", uniqueName),
+		},
+	})
+	if err != nil {
+		return nil, false, status, fmt.Errorf("GroupsClient.BaseClient.Patch(): %v", err)
+	}
+
+	if status == http.StatusNoContent {
+		updated, status, err := c.GetByUniqueName(ctx, uniqueName, odata.Query{})
+		if err != nil {
+			return nil, false, status, fmt.Errorf("retrieving updated group: %v", err)
+		}
+		return updated, false, status, nil
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, true, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var newGroup Group
+	if err := json.Unmarshal(respBody, &newGroup); err != nil {
+		return nil, true, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &newGroup, true, status, nil
+}
+
 // Delete removes a Group.
 func (c *GroupsClient) Delete(ctx context.Context, id string) (int, error) {
 	_, status, _, err := c.BaseClient.Delete(ctx, DeleteHttpRequestInput{
@@ -473,43 +570,17 @@ func (c *GroupsClient) GetMembers(ctx context.Context, groupId string, query oda
 }
 
 // AddMembers adds new members to a Group.
-// First populate the `members` field, then call this method
-func (c *GroupsClient) AddMembers(ctx context.Context, group *Group) (int, error) {
-	var status int
-
-	if group.Members == nil || len(*group.Members) == 0 {
-		return status, fmt.Errorf("no members specified")
+// First populate the `members` field, then call this method.
+// Members are added in batches of up to 20 per request, and the outcome for each member is returned.
+func (c *GroupsClient) AddMembers(ctx context.Context, group *Group) (*[]MemberResult, int, error) {
+	if group.ID() == nil {
+		return nil, 0, fmt.Errorf("cannot update group with nil ID")
 	}
-
-	for _, member := range *group.Members {
-		// don't fail if an member already exists
-		checkMemberAlreadyExists := func(resp *http.Response, o *odata.OData) bool {
-			if resp != nil && resp.StatusCode == http.StatusBadRequest && o != nil && o.Error != nil {
-				return o.Error.Match(odata.ErrorAddedObjectReferencesAlreadyExist)
-			}
-			return false
-		}
-
-		body, err := json.Marshal(DirectoryObject{ODataId: member.ODataId})
-		if err != nil {
-			return status, fmt.Errorf("json.Marshal(): %v", err)
-		}
-
-		_, status, _, err = c.BaseClient.Post(ctx, PostHttpRequestInput{
-			Body:                   body,
-			ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
-			ValidStatusCodes:       []int{http.StatusNoContent},
-			ValidStatusFunc:        checkMemberAlreadyExists,
-			Uri: Uri{
-				Entity: fmt.Sprintf("/groups/%s/members/$ref", *group.ID()),
-			},
-		})
-		if err != nil {
-			return status, fmt.Errorf("GroupsClient.BaseClient.Post(): %v", err)
-		}
+	if group.Members == nil || len(*group.Members) == 0 {
+		return nil, 0, fmt.Errorf("no members specified")
 	}
 
-	return status, nil
+	return c.BaseClient.addMembers(ctx, "GroupsClient", fmt.Sprintf("/groups/%s", *group.ID()), *group.Members)
 }
 
 // RemoveMembers removes members from a Group.
@@ -718,6 +789,30 @@ func (c *GroupsClient) RemoveOwners(ctx context.Context, id string, ownerIds *[]
 	return status, nil
 }
 
+// SetMembers reconciles the members of a Group with the desired object IDs, adding and removing members as needed.
+// id is the object ID of the group.
+// A report is returned describing the outcome for each member that was added or removed.
+func (c *GroupsClient) SetMembers(ctx context.Context, id string, memberIds []string, options SetMembershipOptions) (*MembershipChangeReport, int, error) {
+	current, status, err := c.ListMembers(ctx, id)
+	if err != nil {
+		return nil, status, err
+	}
+
+	return c.BaseClient.reconcileReferences(ctx, "GroupsClient", fmt.Sprintf("/groups/%s", id), "members", *current, memberIds, options)
+}
+
+// SetOwners reconciles the owners of a Group with the desired object IDs, adding and removing owners as needed.
+// id is the object ID of the group.
+// A report is returned describing the outcome for each owner that was added or removed.
+func (c *GroupsClient) SetOwners(ctx context.Context, id string, ownerIds []string, options SetMembershipOptions) (*MembershipChangeReport, int, error) {
+	current, status, err := c.ListOwners(ctx, id)
+	if err != nil {
+		return nil, status, err
+	}
+
+	return c.BaseClient.reconcileReferences(ctx, "GroupsClient", fmt.Sprintf("/groups/%s", id), "owners", *current, ownerIds, options)
+}
+
 func (c *GroupsClient) ListAdministrativeUnitMemberships(ctx context.Context, id string) (*[]AdministrativeUnit, int, error) {
 	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
 		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
@@ -747,3 +842,138 @@ func (c *GroupsClient) ListAdministrativeUnitMemberships(ctx context.Context, id
 
 	return &data.Members, status, nil
 }
+
+// EvaluateDynamicMembership evaluates whether the specified user or device satisfies the membership rule of a Group.
+// groupId is the object ID of a group with dynamic membership.
+// memberId is the object ID of the user or device to evaluate.
+// This operation is only available in the beta API.
+func (c *GroupsClient) EvaluateDynamicMembership(ctx context.Context, groupId, memberId string) (*EvaluateDynamicMembershipResult, int, error) {
+	return c.evaluateDynamicMembership(ctx, "GroupsClient.EvaluateDynamicMembership()", fmt.Sprintf("/groups/%s/evaluateDynamicMembership", groupId), memberId, "")
+}
+
+// EvaluateDynamicMembershipRule evaluates whether the specified user or device would satisfy a membership rule, without
+// the rule being assigned to a group.
+// memberId is the object ID of the user or device to evaluate.
+// This operation is only available in the beta API.
+func (c *GroupsClient) EvaluateDynamicMembershipRule(ctx context.Context, memberId, membershipRule string) (*EvaluateDynamicMembershipResult, int, error) {
+	return c.evaluateDynamicMembership(ctx, "GroupsClient.EvaluateDynamicMembershipRule()", "/groups/evaluateDynamicMembership", memberId, membershipRule)
+}
+
+func (c *GroupsClient) evaluateDynamicMembership(ctx context.Context, operation, entity, memberId, membershipRule string) (*EvaluateDynamicMembershipResult, int, error) {
+	var status int
+
+	if err := c.BaseClient.requireBeta(operation); err != nil {
+		return nil, status, err
+	}
+
+	body, err := json.Marshal(struct {
+		MemberId       string `json:"memberId"`
+		MembershipRule string `json:"membershipRule,omitempty"`
+	}{
+		MemberId:       memberId,
+		MembershipRule: membershipRule,
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("json.Marshal(): %v", err)
+	}
+
+	resp, status, _, err := c.BaseClient.Post(ctx, PostHttpRequestInput{
+		Body:                   body,
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusOK},
+		Uri: Uri{
+			Entity: entity,
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("GroupsClient.BaseClient.Post(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var result EvaluateDynamicMembershipResult
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &result, status, nil
+}
+
+// AssignLicense adds and removes license assignments for the specified group. Group members inherit the licenses
+// assigned to the group, which are processed asynchronously; the LicenseProcessingState of the group indicates when
+// processing has completed. Removed licenses are specified by SKU ID.
+func (c *GroupsClient) AssignLicense(ctx context.Context, id string, addLicenses []AssignedLicense, removeLicenses []string) (*Group, int, error) {
+	var status int
+
+	if addLicenses == nil {
+		addLicenses = []AssignedLicense{}
+	}
+	if removeLicenses == nil {
+		removeLicenses = []string{}
+	}
+
+	body, err := json.Marshal(struct {
+		AddLicenses    []AssignedLicense `json:"addLicenses"`
+		RemoveLicenses []string          `json:"removeLicenses"`
+	}{
+		AddLicenses:    addLicenses,
+		RemoveLicenses: removeLicenses,
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("json.Marshal(): %v", err)
+	}
+
+	resp, status, _, err := c.BaseClient.Post(ctx, PostHttpRequestInput{
+		Body:                   body,
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes: []int{
+			http.StatusOK,
+			http.StatusAccepted,
+		},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/groups/%s/assignLicense", id),
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("GroupsClient.BaseClient.Post(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var group Group
+	if err := json.Unmarshal(respBody, &group); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &group, status, nil
+}
+
+// GetPhoto retrieves the photo of the specified group. When size is empty, the largest available photo is returned.
+// The photo is streamed from the response, and the caller must close the returned reader.
+func (c *GroupsClient) GetPhoto(ctx context.Context, id string, size ProfilePhotoSize) (io.ReadCloser, int, error) {
+	return c.BaseClient.getPhoto(ctx, "GroupsClient", photoEntity(fmt.Sprintf("/groups/%s", id), size))
+}
+
+// GetPhotoMetadata retrieves the metadata for the photo of the specified group, including its dimensions and content
+// type. When size is empty, the metadata for the largest available photo is returned.
+func (c *GroupsClient) GetPhotoMetadata(ctx context.Context, id string, size ProfilePhotoSize) (*ProfilePhoto, int, error) {
+	return c.BaseClient.getPhotoMetadata(ctx, "GroupsClient", photoEntity(fmt.Sprintf("/groups/%s", id), size))
+}
+
+// ListPhotos returns the sizes in which the photo of the specified group is available.
+func (c *GroupsClient) ListPhotos(ctx context.Context, id string) (*[]ProfilePhoto, int, error) {
+	return c.BaseClient.listPhotos(ctx, "GroupsClient", fmt.Sprintf("/groups/%s", id))
+}
+
+// UploadPhoto uploads a photo for the specified group, which should be a gif, jpeg or png image.
+func (c *GroupsClient) UploadPhoto(ctx context.Context, id, contentType string, photoData []byte) (int, error) {
+	return c.BaseClient.uploadPhoto(ctx, "GroupsClient", fmt.Sprintf("/groups/%s", id), contentType, photoData)
+}
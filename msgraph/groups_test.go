@@ -57,6 +57,8 @@ func TestGroupsClient(t *testing.T) {
 		},
 	})
 
+	testGroupsClient_EvaluateDynamicMembershipRule(t, c, *user.ID(), fmt.Sprintf(`user.mailNickName -eq "test-user-%s"`, c.RandomString))
+
 	group.Owners = &msgraph.Owners{user.DirectoryObject}
 	testGroupsClient_AddOwners(t, c, group)
 	testGroupsClient_RemoveOwners(t, c, *group.ID(), &([]string{c.Claims.ObjectId}))
@@ -288,7 +290,7 @@ func testGroupsClient_GetMembers(t *testing.T, c *test.Test, groupId string, que
 }
 
 func testGroupsClient_AddMembers(t *testing.T, c *test.Test, g *msgraph.Group) {
-	status, err := c.GroupsClient.AddMembers(c.Context, g)
+	_, status, err := c.GroupsClient.AddMembers(c.Context, g)
 	if err != nil {
 		t.Fatalf("GroupsClient.AddMembers(): %v", err)
 	}
@@ -369,3 +371,19 @@ func testGroupsClient_RestoreDeleted(t *testing.T, c *test.Test, id string) {
 		t.Fatal("GroupsClient.RestoreDeleted(): group IDs do not match")
 	}
 }
+
+func testGroupsClient_EvaluateDynamicMembershipRule(t *testing.T, c *test.Test, memberId, membershipRule string) {
+	if _, err := msgraph.ParseMembershipRule(membershipRule); err != nil {
+		t.Fatalf("ParseMembershipRule(): %v", err)
+	}
+	result, status, err := c.GroupsClient.EvaluateDynamicMembershipRule(c.Context, memberId, membershipRule)
+	if err != nil {
+		t.Fatalf("GroupsClient.EvaluateDynamicMembershipRule(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("GroupsClient.EvaluateDynamicMembershipRule(): invalid status: %d", status)
+	}
+	if result == nil || result.MembershipRuleEvaluationResult == nil || !*result.MembershipRuleEvaluationResult {
+		t.Fatalf("GroupsClient.EvaluateDynamicMembershipRule(): expected member to satisfy rule %q", membershipRule)
+	}
+}
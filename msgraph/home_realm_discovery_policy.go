@@ -0,0 +1,201 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+)
+
+// HomeRealmDiscoveryPolicyClient performs operations on HomeRealmDiscoveryPolicies.
+type HomeRealmDiscoveryPolicyClient struct {
+	BaseClient Client
+}
+
+// NewHomeRealmDiscoveryPolicyClient returns a new HomeRealmDiscoveryPolicyClient
+func NewHomeRealmDiscoveryPolicyClient(opts ...ClientOption) *HomeRealmDiscoveryPolicyClient {
+	return &HomeRealmDiscoveryPolicyClient{
+		BaseClient: NewClient(Version10, opts...),
+	}
+}
+
+// Create creates a new HomeRealmDiscoveryPolicy.
+func (c *HomeRealmDiscoveryPolicyClient) Create(ctx context.Context, policy HomeRealmDiscoveryPolicy) (*HomeRealmDiscoveryPolicy, int, error) {
+	var status int
+
+	body, err := json.Marshal(policy)
+	if err != nil {
+		return nil, status, fmt.Errorf("json.Marshal(): %v", err)
+	}
+
+	resp, status, _, err := c.BaseClient.Post(ctx, PostHttpRequestInput{
+		Body:             body,
+		OData:            odata.Query{Metadata: odata.MetadataFull},
+		ValidStatusCodes: []int{http.StatusCreated},
+		Uri: Uri{
+			Entity: "/policies/homeRealmDiscoveryPolicies",
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("HomeRealmDiscoveryPolicyClient.BaseClient.Post(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var newPolicy HomeRealmDiscoveryPolicy
+	if err := json.Unmarshal(respBody, &newPolicy); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &newPolicy, status, nil
+}
+
+// List returns a list of HomeRealmDiscoveryPolicy, optionally queried using OData.
+func (c *HomeRealmDiscoveryPolicyClient) List(ctx context.Context, query odata.Query) (*[]HomeRealmDiscoveryPolicy, int, error) {
+	query.Metadata = odata.MetadataFull
+
+	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+		DisablePaging:    query.Top > 0,
+		OData:            query,
+		ValidStatusCodes: []int{http.StatusOK},
+		Uri: Uri{
+			Entity: "/policies/homeRealmDiscoveryPolicies",
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("HomeRealmDiscoveryPolicyClient.BaseClient.Get(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var data struct {
+		HomeRealmDiscoveryPolicies []HomeRealmDiscoveryPolicy `json:"value"`
+	}
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &data.HomeRealmDiscoveryPolicies, status, nil
+}
+
+// Get retrieves a HomeRealmDiscoveryPolicy.
+func (c *HomeRealmDiscoveryPolicyClient) Get(ctx context.Context, id string, query odata.Query) (*HomeRealmDiscoveryPolicy, int, error) {
+	query.Metadata = odata.MetadataFull
+
+	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		OData:                  query,
+		ValidStatusCodes:       []int{http.StatusOK},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/policies/homeRealmDiscoveryPolicies/%s", id),
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("HomeRealmDiscoveryPolicyClient.BaseClient.Get(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var homeRealmDiscoveryPolicy HomeRealmDiscoveryPolicy
+	if err := json.Unmarshal(respBody, &homeRealmDiscoveryPolicy); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &homeRealmDiscoveryPolicy, status, nil
+}
+
+// Update amends an existing HomeRealmDiscoveryPolicy.
+func (c *HomeRealmDiscoveryPolicyClient) Update(ctx context.Context, homeRealmDiscoveryPolicy HomeRealmDiscoveryPolicy) (int, error) {
+	var status int
+
+	if homeRealmDiscoveryPolicy.ID() == nil {
+		return status, fmt.Errorf("cannot update HomeRealmDiscoveryPolicy with nil ID")
+	}
+
+	homeRealmDiscoveryPolicyId := *homeRealmDiscoveryPolicy.ID()
+	homeRealmDiscoveryPolicy.Id = nil
+	homeRealmDiscoveryPolicy.ObjectId = nil
+
+	body, err := json.Marshal(homeRealmDiscoveryPolicy)
+	if err != nil {
+		return status, fmt.Errorf("json.Marshal(): %v", err)
+	}
+
+	_, status, _, err = c.BaseClient.Patch(ctx, PatchHttpRequestInput{
+		Body:                   body,
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes: []int{
+			http.StatusOK,
+			http.StatusNoContent,
+		},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/policies/homeRealmDiscoveryPolicies/%s", homeRealmDiscoveryPolicyId),
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("HomeRealmDiscoveryPolicyClient.BaseClient.Patch(): %v", err)
+	}
+
+	return status, nil
+}
+
+// Delete removes a HomeRealmDiscoveryPolicy.
+func (c *HomeRealmDiscoveryPolicyClient) Delete(ctx context.Context, id string) (int, error) {
+	_, status, _, err := c.BaseClient.Delete(ctx, DeleteHttpRequestInput{
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusNoContent},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/policies/homeRealmDiscoveryPolicies/%s", id),
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("HomeRealmDiscoveryPolicyClient.BaseClient.Delete(): %v", err)
+	}
+
+	return status, nil
+}
+
+// ListAppliesTo returns the directory objects, such as Applications and Service Principals, that a HomeRealmDiscoveryPolicy is
+// assigned to.
+func (c *HomeRealmDiscoveryPolicyClient) ListAppliesTo(ctx context.Context, id string) (*[]DirectoryObject, int, error) {
+	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusOK},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/policies/homeRealmDiscoveryPolicies/%s/appliesTo", id),
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("HomeRealmDiscoveryPolicyClient.BaseClient.Get(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var data struct {
+		DirectoryObjects []DirectoryObject `json:"value"`
+	}
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &data.DirectoryObjects, status, nil
+}
@@ -0,0 +1,159 @@
+package msgraph_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+	"github.com/manicminer/hamilton/internal/test"
+	"github.com/manicminer/hamilton/internal/utils"
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+func TestHomeRealmDiscoveryPolicyClient(t *testing.T) {
+	c := test.NewTest(t)
+	defer c.CancelFunc()
+
+	definition, err := msgraph.HomeRealmDiscoveryPolicyDefinition{AllowCloudPasswordValidation: true}.Definition()
+	if err != nil {
+		t.Fatalf("HomeRealmDiscoveryPolicyDefinition.Definition(): %v", err)
+	}
+	policy := testHomeRealmDiscoveryPolicyClient_Create(t, c, msgraph.HomeRealmDiscoveryPolicy{
+		DisplayName: utils.StringPtr(fmt.Sprintf("test-home-realm-discovery-policy-%s", c.RandomString)),
+		Definition:  definition,
+	})
+	testHomeRealmDiscoveryPolicyClient_List(t, c)
+	testHomeRealmDiscoveryPolicyClient_Get(t, c, *policy.ID())
+	policy.DisplayName = utils.StringPtr(fmt.Sprintf("test-home-realm-discovery-policy-updated-%s", c.RandomString))
+	testHomeRealmDiscoveryPolicyClient_Update(t, c, *policy)
+
+	app := testApplicationsClient_Create(t, c, msgraph.Application{
+		DisplayName: utils.StringPtr(fmt.Sprintf("test-home-realm-discovery-policy-%s", c.RandomString)),
+	})
+	sp := testServicePrincipalsClient_Create(t, c, msgraph.ServicePrincipal{
+		AccountEnabled: utils.BoolPtr(true),
+		AppId:          app.AppId,
+		DisplayName:    app.DisplayName,
+	})
+
+	testServicePrincipalsClient_AssignHomeRealmDiscoveryPolicy(t, c, *sp.ID(), *policy)
+	testServicePrincipalsClient_ListHomeRealmDiscoveryPolicy(t, c, *sp.ID(), []string{*policy.ID()})
+	testHomeRealmDiscoveryPolicyClient_ListAppliesTo(t, c, *policy.ID(), []string{*sp.ID()})
+
+	testServicePrincipalsClient_RemoveHomeRealmDiscoveryPolicy(t, c, *sp.ID(), []string{*policy.ID()})
+	// A second call tests that a remove call on an empty assignment list returns ok
+	testServicePrincipalsClient_RemoveHomeRealmDiscoveryPolicy(t, c, *sp.ID(), []string{*policy.ID()})
+	testServicePrincipalsClient_ListHomeRealmDiscoveryPolicy(t, c, *sp.ID(), []string{})
+
+	testServicePrincipalsClient_Delete(t, c, *sp.ID())
+	testApplicationsClient_Delete(t, c, *app.ID())
+	testHomeRealmDiscoveryPolicyClient_Delete(t, c, *policy.ID())
+}
+
+func testHomeRealmDiscoveryPolicyClient_Create(t *testing.T, c *test.Test, p msgraph.HomeRealmDiscoveryPolicy) (policy *msgraph.HomeRealmDiscoveryPolicy) {
+	policy, status, err := c.HomeRealmDiscoveryPolicyClient.Create(c.Context, p)
+	if err != nil {
+		t.Fatalf("HomeRealmDiscoveryPolicyClient.Create(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("HomeRealmDiscoveryPolicyClient.Create(): invalid status: %d", status)
+	}
+	if policy == nil {
+		t.Fatal("HomeRealmDiscoveryPolicyClient.Create(): policy was nil")
+	}
+	if policy.ID() == nil {
+		t.Fatal("HomeRealmDiscoveryPolicyClient.Create(): policy.ID was nil")
+	}
+	return
+}
+
+func testHomeRealmDiscoveryPolicyClient_List(t *testing.T, c *test.Test) (policies *[]msgraph.HomeRealmDiscoveryPolicy) {
+	policies, _, err := c.HomeRealmDiscoveryPolicyClient.List(c.Context, odata.Query{Top: 10})
+	if err != nil {
+		t.Fatalf("HomeRealmDiscoveryPolicyClient.List(): %v", err)
+	}
+	if policies == nil {
+		t.Fatal("HomeRealmDiscoveryPolicyClient.List(): policies was nil")
+	}
+	return
+}
+
+func testHomeRealmDiscoveryPolicyClient_Get(t *testing.T, c *test.Test, id string) (policy *msgraph.HomeRealmDiscoveryPolicy) {
+	policy, status, err := c.HomeRealmDiscoveryPolicyClient.Get(c.Context, id, odata.Query{})
+	if err != nil {
+		t.Fatalf("HomeRealmDiscoveryPolicyClient.Get(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("HomeRealmDiscoveryPolicyClient.Get(): invalid status: %d", status)
+	}
+	if policy == nil {
+		t.Fatal("HomeRealmDiscoveryPolicyClient.Get(): policy was nil")
+	}
+	return
+}
+
+func testHomeRealmDiscoveryPolicyClient_Update(t *testing.T, c *test.Test, p msgraph.HomeRealmDiscoveryPolicy) {
+	status, err := c.HomeRealmDiscoveryPolicyClient.Update(c.Context, p)
+	if err != nil {
+		t.Fatalf("HomeRealmDiscoveryPolicyClient.Update(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("HomeRealmDiscoveryPolicyClient.Update(): invalid status: %d", status)
+	}
+}
+
+func testHomeRealmDiscoveryPolicyClient_ListAppliesTo(t *testing.T, c *test.Test, id string, expected []string) {
+	objects, _, err := c.HomeRealmDiscoveryPolicyClient.ListAppliesTo(c.Context, id)
+	if err != nil {
+		t.Fatalf("HomeRealmDiscoveryPolicyClient.ListAppliesTo(): %v", err)
+	}
+	if objects == nil {
+		t.Fatal("HomeRealmDiscoveryPolicyClient.ListAppliesTo(): objects was nil")
+	}
+	testPolicyObjectIds(t, "HomeRealmDiscoveryPolicyClient.ListAppliesTo()", *objects, expected)
+}
+
+func testHomeRealmDiscoveryPolicyClient_Delete(t *testing.T, c *test.Test, id string) {
+	status, err := c.HomeRealmDiscoveryPolicyClient.Delete(c.Context, id)
+	if err != nil {
+		t.Fatalf("HomeRealmDiscoveryPolicyClient.Delete(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("HomeRealmDiscoveryPolicyClient.Delete(): invalid status: %d", status)
+	}
+}
+
+func testServicePrincipalsClient_AssignHomeRealmDiscoveryPolicy(t *testing.T, c *test.Test, id string, policy msgraph.HomeRealmDiscoveryPolicy) {
+	status, err := c.ServicePrincipalsClient.AssignHomeRealmDiscoveryPolicy(c.Context, id, &[]msgraph.HomeRealmDiscoveryPolicy{policy})
+	if err != nil {
+		t.Fatalf("ServicePrincipalsClient.AssignHomeRealmDiscoveryPolicy(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("ServicePrincipalsClient.AssignHomeRealmDiscoveryPolicy(): invalid status: %d", status)
+	}
+}
+
+func testServicePrincipalsClient_ListHomeRealmDiscoveryPolicy(t *testing.T, c *test.Test, id string, expected []string) {
+	policies, _, err := c.ServicePrincipalsClient.ListHomeRealmDiscoveryPolicy(c.Context, id)
+	if err != nil {
+		t.Fatalf("ServicePrincipalsClient.ListHomeRealmDiscoveryPolicy(): %v", err)
+	}
+	if policies == nil {
+		t.Fatal("ServicePrincipalsClient.ListHomeRealmDiscoveryPolicy(): policies was nil")
+	}
+	objects := make([]msgraph.DirectoryObject, 0, len(*policies))
+	for _, p := range *policies {
+		objects = append(objects, p.DirectoryObject)
+	}
+	testPolicyObjectIds(t, "ServicePrincipalsClient.ListHomeRealmDiscoveryPolicy()", objects, expected)
+}
+
+func testServicePrincipalsClient_RemoveHomeRealmDiscoveryPolicy(t *testing.T, c *test.Test, id string, policyIds []string) {
+	status, err := c.ServicePrincipalsClient.RemoveHomeRealmDiscoveryPolicy(c.Context, id, &policyIds)
+	if err != nil {
+		t.Fatalf("ServicePrincipalsClient.RemoveHomeRealmDiscoveryPolicy(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("ServicePrincipalsClient.RemoveHomeRealmDiscoveryPolicy(): invalid status: %d", status)
+	}
+}
@@ -15,9 +15,9 @@ type IdentityProvidersClient struct {
 }
 
 // NewIdentityProvidersClient returns a new IdentityProvidersClient
-func NewIdentityProvidersClient() *IdentityProvidersClient {
+func NewIdentityProvidersClient(opts ...ClientOption) *IdentityProvidersClient {
 	return &IdentityProvidersClient{
-		BaseClient: NewClient(VersionBeta),
+		BaseClient: NewClient(VersionBeta, opts...),
 	}
 }
 
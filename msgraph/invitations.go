@@ -14,9 +14,9 @@ type InvitationsClient struct {
 }
 
 // NewInvitationsClient returns a new InvitationsClient.
-func NewInvitationsClient() *InvitationsClient {
+func NewInvitationsClient(opts ...ClientOption) *InvitationsClient {
 	return &InvitationsClient{
-		BaseClient: NewClient(VersionBeta),
+		BaseClient: NewClient(VersionBeta, opts...),
 	}
 }
 
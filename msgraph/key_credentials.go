@@ -0,0 +1,118 @@
+package msgraph
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/manicminer/hamilton/internal/utils"
+)
+
+// ProofOfPossessionAudience is the audience required by the addKey and removeKey actions when validating proof tokens.
+const ProofOfPossessionAudience = "00000002-0000-0000-c000-000000000000"
+
+// ProofOfPossessionMaxLifetime is the longest validity period accepted by the API for a proof token.
+const ProofOfPossessionMaxLifetime = 10 * time.Minute
+
+// NewProofOfPossessionToken builds and signs a proof token for use with the addKey and removeKey actions of an
+// Application or Service Principal. The token must be signed using the private key of a certificate already present
+// in the keyCredentials of the object, and objectId is the object ID (not the app ID) of that Application or Service
+// Principal. The token is valid for ProofOfPossessionMaxLifetime from the current time. Only RSA keys are supported.
+func NewProofOfPossessionToken(signer crypto.Signer, cert *x509.Certificate, objectId string) (string, error) {
+	if signer == nil {
+		return "", errors.New("signer was nil")
+	}
+	if cert == nil {
+		return "", errors.New("certificate was nil")
+	}
+	if objectId == "" {
+		return "", errors.New("objectId was empty")
+	}
+	if _, ok := signer.Public().(*rsa.PublicKey); !ok {
+		return "", fmt.Errorf("unsupported key type %T, only RSA keys can be used to sign proof tokens", signer.Public())
+	}
+
+	thumbprint := sha1.Sum(cert.Raw)
+	header, err := json.Marshal(map[string]string{
+		"alg": "RS256",
+		"typ": "JWT",
+		"x5t": base64.RawURLEncoding.EncodeToString(thumbprint[:]),
+	})
+	if err != nil {
+		return "", fmt.Errorf("json.Marshal(): %v", err)
+	}
+
+	now := time.Now()
+	claims, err := json.Marshal(map[string]interface{}{
+		"aud": ProofOfPossessionAudience,
+		"iss": objectId,
+		"nbf": now.Unix(),
+		"exp": now.Add(ProofOfPossessionMaxLifetime).Unix(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("json.Marshal(): %v", err)
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	if err != nil {
+		return "", fmt.Errorf("signing proof token: %v", err)
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// NewKeyCredentialFromDER builds a KeyCredential for the provided DER encoded X.509 certificate. The
+// customKeyIdentifier is set to the SHA-1 thumbprint of the certificate and the start and end dates are taken from
+// its validity period. The returned credential has type AsymmetricX509Cert and usage Verify, the caller may override
+// these along with the DisplayName.
+func NewKeyCredentialFromDER(der []byte) (*KeyCredential, error) {
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("x509.ParseCertificate(): %v", err)
+	}
+
+	return NewKeyCredentialFromCertificate(cert), nil
+}
+
+// NewKeyCredentialFromPEM builds a KeyCredential for the first certificate found in the provided PEM data. See
+// NewKeyCredentialFromDER for details of the populated fields.
+func NewKeyCredentialFromPEM(data []byte) (*KeyCredential, error) {
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil, errors.New("no CERTIFICATE block found in PEM data")
+		}
+		if block.Type == "CERTIFICATE" {
+			return NewKeyCredentialFromDER(block.Bytes)
+		}
+	}
+}
+
+// NewKeyCredentialFromCertificate builds a KeyCredential for the provided parsed certificate. See
+// NewKeyCredentialFromDER for details of the populated fields.
+func NewKeyCredentialFromCertificate(cert *x509.Certificate) *KeyCredential {
+	thumbprint := sha1.Sum(cert.Raw)
+	startDate := cert.NotBefore.UTC()
+	endDate := cert.NotAfter.UTC()
+
+	return &KeyCredential{
+		CustomKeyIdentifier: utils.StringPtr(base64.StdEncoding.EncodeToString(thumbprint[:])),
+		EndDateTime:         &endDate,
+		Key:                 utils.StringPtr(base64.StdEncoding.EncodeToString(cert.Raw)),
+		StartDateTime:       &startDate,
+		Type:                KeyCredentialTypeAsymmetricX509Cert,
+		Usage:               KeyCredentialUsageVerify,
+	}
+}
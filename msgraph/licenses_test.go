@@ -0,0 +1,136 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+
+	"github.com/manicminer/hamilton/internal/utils"
+)
+
+func TestSubscribedSkusClient_ResolveSkuIds(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/v1.0/subscribedSkus" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"value":[
+			{"id":"tenant_sku-1","skuId":"sku-1","skuPartNumber":"ENTERPRISEPACK","consumedUnits":20,"prepaidUnits":{"enabled":25}},
+			{"id":"tenant_sku-2","skuId":"sku-2","skuPartNumber":"EMS","consumedUnits":5,"prepaidUnits":{"enabled":5}}
+		]}`))
+	}))
+	defer ts.Close()
+
+	c := NewSubscribedSkusClient(WithEndpoint(ts.URL), WithRetryMax(0))
+
+	skuIds, _, err := c.ResolveSkuIds(context.Background(), "enterprisepack", "EMS")
+	if err != nil {
+		t.Fatalf("ResolveSkuIds(): %v", err)
+	}
+	if skuIds["enterprisepack"] != "sku-1" || skuIds["EMS"] != "sku-2" {
+		t.Fatalf("unexpected SKU IDs: %v", skuIds)
+	}
+
+	skuIds, _, err = c.ResolveSkuIds(context.Background(), "EMS", "SPE_E5")
+	if err == nil {
+		t.Fatal("expected an error for an unknown part number")
+	}
+	if skuIds["EMS"] != "sku-2" {
+		t.Fatalf("expected known part numbers to be resolved, got %v", skuIds)
+	}
+
+	skus, _, err := c.List(context.Background(), odata.Query{})
+	if err != nil {
+		t.Fatalf("List(): %v", err)
+	}
+	if available := (*skus)[0].AvailableUnits(); available != 5 {
+		t.Fatalf("AvailableUnits(): got %d, want 5", available)
+	}
+}
+
+func TestUsersClient_AssignLicense(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/beta/users/user-id/assignLicense" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		var req map[string]json.RawMessage
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Errorf("json.Unmarshal(): %v", err)
+		}
+		// both properties are required by the API, even when empty
+		if string(req["addLicenses"]) != `[{"disabledPlans":["plan-1"],"skuId":"sku-1"}]` {
+			t.Errorf("unexpected addLicenses: %s", req["addLicenses"])
+		}
+		if string(req["removeLicenses"]) != `[]` {
+			t.Errorf("unexpected removeLicenses: %s", req["removeLicenses"])
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"user-id","assignedLicenses":[{"disabledPlans":["plan-1"],"skuId":"sku-1"}]}`))
+	}))
+	defer ts.Close()
+
+	c := NewUsersClient(WithEndpoint(ts.URL), WithRetryMax(0))
+	user, _, err := c.AssignLicense(context.Background(), "user-id", []AssignedLicense{{
+		DisabledPlans: &[]string{"plan-1"},
+		SkuId:         utils.StringPtr("sku-1"),
+	}}, nil)
+	if err != nil {
+		t.Fatalf("AssignLicense(): %v", err)
+	}
+	if user.AssignedLicenses == nil || len(*user.AssignedLicenses) != 1 {
+		t.Fatalf("unexpected assigned licenses: %v", user.AssignedLicenses)
+	}
+}
+
+func TestGroupsClient_UpdateAssignedLicenses(t *testing.T) {
+	var patched map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/beta/groups/group-id":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id":"group-id","displayName":"payroll","assignedLicenses":[{"disabledPlans":[],"skuId":"sku-1"}]}`))
+		case r.Method == http.MethodPatch && r.URL.Path == "/beta/groups/group-id":
+			if err := json.NewDecoder(r.Body).Decode(&patched); err != nil {
+				t.Errorf("json.Decode(): %v", err)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	c := NewGroupsClient(WithEndpoint(ts.URL), WithRetryMax(0))
+
+	group, _, err := c.Get(ctx, "group-id", odata.Query{})
+	if err != nil {
+		t.Fatalf("Get(): %v", err)
+	}
+	if group.AssignedLicenses == nil || len(*group.AssignedLicenses) != 1 {
+		t.Fatalf("unexpected assigned licenses: %v", group.AssignedLicenses)
+	}
+
+	if _, err := c.Update(ctx, *group); err != nil {
+		t.Fatalf("Update(): %v", err)
+	}
+	if _, ok := patched["assignedLicenses"]; ok {
+		t.Errorf("expected assignedLicenses not to be sent, got: %v", patched)
+	}
+	if patched["displayName"] != "payroll" {
+		t.Errorf("expected displayName to be sent, got: %v", patched)
+	}
+}
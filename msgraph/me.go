@@ -16,9 +16,9 @@ type MeClient struct {
 }
 
 // NewMeClient returns a new MeClient.
-func NewMeClient() *MeClient {
+func NewMeClient(opts ...ClientOption) *MeClient {
 	return &MeClient{
-		BaseClient: NewClient(VersionBeta),
+		BaseClient: NewClient(VersionBeta, opts...),
 	}
 }
 
@@ -0,0 +1,350 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+
+	"github.com/manicminer/hamilton/errors"
+	"github.com/manicminer/hamilton/internal/utils"
+)
+
+// membersBindBatchSize is the maximum number of members that can be added in a single request using members@odata.bind.
+const membersBindBatchSize = 20
+
+// addMembers adds members to the directory object at the specified entity path, i.e. "/groups/{id}". Members are added
+// in batches using members@odata.bind. When a batch fails, for example because one of the members is already present,
+// each member in the batch is added individually so that an accurate result can be reported for every member.
+func (c Client) addMembers(ctx context.Context, clientName, entity string, members Members) (*[]MemberResult, int, error) {
+	var status int
+
+	results := make([]MemberResult, 0, len(members))
+	pending := make(Members, 0, len(members))
+	for _, member := range members {
+		if member.ODataId == nil {
+			results = append(results, MemberResult{
+				Member: member,
+				Status: MemberResultStatusFailed,
+				Err:    fmt.Errorf("member has nil ODataId"),
+			})
+			continue
+		}
+		pending = append(pending, member)
+	}
+
+	for start := 0; start < len(pending); start += membersBindBatchSize {
+		end := start + membersBindBatchSize
+		if end > len(pending) {
+			end = len(pending)
+		}
+		batch := pending[start:end]
+
+		body, err := json.Marshal(struct {
+			Members Members `json:"members@odata.bind"`
+		}{Members: batch})
+		if err != nil {
+			return nil, status, fmt.Errorf("json.Marshal(): %v", err)
+		}
+
+		_, status, _, err = c.Patch(ctx, PatchHttpRequestInput{
+			Body:                   body,
+			ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+			ValidStatusCodes: []int{
+				http.StatusOK,
+				http.StatusNoContent,
+			},
+			Uri: Uri{
+				Entity: entity,
+			},
+		})
+		if err == nil {
+			for _, member := range batch {
+				results = append(results, MemberResult{
+					Member: member,
+					Status: MemberResultStatusAdded,
+				})
+			}
+			continue
+		}
+
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			// the remaining members will not be attempted, so report them as failed
+			for _, member := range pending[start:] {
+				results = append(results, MemberResult{
+					Member: member,
+					Status: MemberResultStatusFailed,
+					Err:    fmt.Errorf("%s.BaseClient.Patch(): %v", clientName, err),
+				})
+			}
+			break
+		}
+
+		// retry members in the failed batch individually
+		for _, member := range batch {
+			var result MemberResult
+			result, status = c.addReference(ctx, clientName, entity, "members", member)
+			results = append(results, result)
+		}
+	}
+
+	return &results, status, memberResultsError(results)
+}
+
+// memberResultsError summarises any failed additions in results, returning nil when all members were added.
+func memberResultsError(results []MemberResult) error {
+	failed := 0
+	var firstErr error
+	for _, result := range results {
+		if result.Status == MemberResultStatusFailed {
+			if firstErr == nil {
+				firstErr = result.Err
+			}
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d members could not be added: %v", failed, len(results), firstErr)
+	}
+
+	return nil
+}
+
+// addReference adds a single reference to a relationship, i.e. "members" or "owners", of the directory object at the
+// specified entity path.
+func (c Client) addReference(ctx context.Context, clientName, entity, relationship string, member DirectoryObject) (MemberResult, int) {
+	result := MemberResult{Member: member}
+
+	// don't fail if a reference already exists
+	alreadyExists := false
+	checkReferenceAlreadyExists := func(resp *http.Response, o *odata.OData) bool {
+		if resp != nil && resp.StatusCode == http.StatusBadRequest && o != nil && o.Error != nil {
+			alreadyExists = o.Error.Match(odata.ErrorAddedObjectReferencesAlreadyExist)
+		}
+		return alreadyExists
+	}
+
+	body, err := json.Marshal(DirectoryObject{ODataId: member.ODataId})
+	if err != nil {
+		result.Status = MemberResultStatusFailed
+		result.Err = fmt.Errorf("json.Marshal(): %v", err)
+		return result, 0
+	}
+
+	_, status, _, err := c.Post(ctx, PostHttpRequestInput{
+		Body:                   body,
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusNoContent},
+		ValidStatusFunc:        checkReferenceAlreadyExists,
+		Uri: Uri{
+			Entity: fmt.Sprintf("%s/%s/$ref", entity, relationship),
+		},
+	})
+	switch {
+	case err != nil:
+		result.Status = MemberResultStatusFailed
+		result.Err = fmt.Errorf("%s.BaseClient.Post(): %v", clientName, err)
+	case alreadyExists:
+		result.Status = MemberResultStatusAlreadyPresent
+	default:
+		result.Status = MemberResultStatusAdded
+	}
+
+	return result, status
+}
+
+// removeReference removes a single reference from a relationship, i.e. "members" or "owners", of the directory object
+// at the specified entity path. It returns true when the reference had already been removed.
+func (c Client) removeReference(ctx context.Context, clientName, entity, relationship, id string) (bool, int, error) {
+	// don't fail if a reference is already gone
+	gone := false
+	checkReferenceGone := func(resp *http.Response, o *odata.OData) bool {
+		if resp != nil && resp.StatusCode == http.StatusBadRequest && o != nil && o.Error != nil {
+			gone = o.Error.Match(odata.ErrorRemovedObjectReferencesDoNotExist)
+		}
+		return gone
+	}
+
+	_, status, _, err := c.Delete(ctx, DeleteHttpRequestInput{
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusNoContent},
+		ValidStatusFunc:        checkReferenceGone,
+		Uri: Uri{
+			Entity: fmt.Sprintf("%s/%s/%s/$ref", entity, relationship, id),
+		},
+	})
+	if err != nil {
+		return false, status, fmt.Errorf("%s.BaseClient.Delete(): %v", clientName, err)
+	}
+
+	return gone, status, nil
+}
+
+// SetMembershipOptions configures how a relationship is reconciled with a desired state.
+type SetMembershipOptions struct {
+	// MaxRemovalPercentage is the maximum percentage of the current references that may be removed. When the
+	// required removals exceed this, no changes are made and an errors.RemovalLimitExceededError is returned.
+	// When zero, no limit is applied.
+	MaxRemovalPercentage int
+
+	// AllowRemoveAll permits an empty desired state, which removes every current reference. Otherwise no changes are
+	// made and an errors.RemovalLimitExceededError is returned, which protects against emptying a relationship due to
+	// a failed or empty lookup of the desired state.
+	AllowRemoveAll bool
+
+	// DryRun computes the changes without applying them. Changes in the report will have a status of Planned.
+	DryRun bool
+}
+
+// reconcileReferences adds and removes references for a relationship, i.e. "members" or "owners", so that it matches
+// the desired object IDs. Additions are made before removals, so that a relationship is never left empty mid-way
+// through, which matters for owners.
+func (c Client) reconcileReferences(ctx context.Context, clientName, entity, relationship string, current, desired []string, options SetMembershipOptions) (*MembershipChangeReport, int, error) {
+	var status int
+
+	currentIds := make(map[string]bool, len(current))
+	for _, id := range current {
+		currentIds[id] = true
+	}
+
+	report := MembershipChangeReport{
+		Unchanged: make([]string, 0),
+		Changes:   make([]MembershipChange, 0),
+	}
+
+	desiredIds := make(map[string]bool, len(desired))
+	additions := make(Members, 0)
+	for _, id := range desired {
+		if desiredIds[id] {
+			continue
+		}
+		desiredIds[id] = true
+
+		if currentIds[id] {
+			report.Unchanged = append(report.Unchanged, id)
+			continue
+		}
+
+		member := DirectoryObject{Id: utils.StringPtr(id)}
+		odataId := odata.Id(member.Uri(c.Endpoint, c.ApiVersion))
+		member.ODataId = &odataId
+		additions = append(additions, member)
+	}
+
+	removals := make([]string, 0)
+	for _, id := range current {
+		if !desiredIds[id] {
+			removals = append(removals, id)
+		}
+	}
+
+	if len(desiredIds) == 0 && len(removals) > 0 && !options.AllowRemoveAll {
+		return &report, status, errors.RemovalLimitExceededError{
+			Relationship: relationship,
+			Current:      len(current),
+			Removals:     len(removals),
+		}
+	}
+
+	if options.MaxRemovalPercentage > 0 && len(removals) > 0 && len(removals)*100 > len(current)*options.MaxRemovalPercentage {
+		return &report, status, errors.RemovalLimitExceededError{
+			Relationship:  relationship,
+			Current:       len(current),
+			Removals:      len(removals),
+			MaxPercentage: options.MaxRemovalPercentage,
+		}
+	}
+
+	if options.DryRun {
+		for _, member := range additions {
+			report.Changes = append(report.Changes, MembershipChange{Id: *member.Id, Action: MembershipChangeActionAdd, Status: MembershipChangeStatusPlanned})
+		}
+		for _, id := range removals {
+			report.Changes = append(report.Changes, MembershipChange{Id: id, Action: MembershipChangeActionRemove, Status: MembershipChangeStatusPlanned})
+		}
+		return &report, status, nil
+	}
+
+	if len(additions) > 0 {
+		var results []MemberResult
+		var addErr error
+		if relationship == "members" {
+			// members can be added in batches, any errors are reported for each member
+			var r *[]MemberResult
+			r, status, addErr = c.addMembers(ctx, clientName, entity, additions)
+			if r != nil {
+				results = *r
+			}
+		} else {
+			for _, member := range additions {
+				var result MemberResult
+				result, status = c.addReference(ctx, clientName, entity, relationship, member)
+				results = append(results, result)
+			}
+		}
+
+		attempted := make(map[string]bool, len(results))
+		for _, result := range results {
+			attempted[*result.Member.Id] = true
+			change := MembershipChange{Id: *result.Member.Id, Action: MembershipChangeActionAdd, Err: result.Err}
+			switch result.Status {
+			case MemberResultStatusAdded:
+				change.Status = MembershipChangeStatusApplied
+			case MemberResultStatusAlreadyPresent:
+				change.Status = MembershipChangeStatusAlreadyApplied
+			default:
+				change.Status = MembershipChangeStatusFailed
+			}
+			report.Changes = append(report.Changes, change)
+		}
+
+		// additions without a result were never attempted, e.g. because the context was cancelled
+		for _, member := range additions {
+			if attempted[*member.Id] {
+				continue
+			}
+			err := addErr
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				err = ctxErr
+			}
+			if err == nil {
+				err = fmt.Errorf("member was not added")
+			}
+			report.Changes = append(report.Changes, MembershipChange{Id: *member.Id, Action: MembershipChangeActionAdd, Status: MembershipChangeStatusFailed, Err: err})
+		}
+	}
+
+	for _, id := range removals {
+		change := MembershipChange{Id: id, Action: MembershipChangeActionRemove, Status: MembershipChangeStatusApplied}
+
+		var gone bool
+		var err error
+		gone, status, err = c.removeReference(ctx, clientName, entity, relationship, id)
+		switch {
+		case err != nil:
+			change.Status = MembershipChangeStatusFailed
+			change.Err = err
+		case gone:
+			change.Status = MembershipChangeStatusAlreadyApplied
+		}
+		report.Changes = append(report.Changes, change)
+	}
+
+	failed := 0
+	var firstErr error
+	for _, change := range report.Changes {
+		if change.Status == MembershipChangeStatusFailed {
+			if firstErr == nil {
+				firstErr = change.Err
+			}
+			failed++
+		}
+	}
+	if failed > 0 {
+		return &report, status, fmt.Errorf("%d of %d changes to %s could not be applied: %v", failed, len(report.Changes), relationship, firstErr)
+	}
+
+	return &report, status, nil
+}
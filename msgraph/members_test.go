@@ -0,0 +1,311 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	goerrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+
+	"github.com/manicminer/hamilton/errors"
+	"github.com/manicminer/hamilton/internal/utils"
+)
+
+func TestGroupsClient_AddMembers(t *testing.T) {
+	const (
+		duplicate = "00000000-0000-0000-0000-000000000003"
+		forbidden = "00000000-0000-0000-0000-000000000007"
+	)
+
+	var mutex sync.Mutex
+	patches := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		body, _ := io.ReadAll(r.Body)
+
+		switch {
+		case r.Method == http.MethodPatch && r.URL.Path == "/beta/groups/group-id":
+			patches++
+			var req struct {
+				Members []string `json:"members@odata.bind"`
+			}
+			if err := json.Unmarshal(body, &req); err != nil {
+				t.Errorf("json.Unmarshal(): %v", err)
+			}
+			if len(req.Members) > membersBindBatchSize {
+				t.Errorf("got batch of %d members, want at most %d", len(req.Members), membersBindBatchSize)
+			}
+			if strings.Contains(string(body), duplicate) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error":{"code":"Request_BadRequest","message":"One or more added object references already exist for the following modified properties: 'members'."}}`))
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+
+		case r.Method == http.MethodPost && r.URL.Path == "/beta/groups/group-id/members/$ref":
+			switch {
+			case strings.Contains(string(body), duplicate):
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error":{"code":"Request_BadRequest","message":"One or more added object references already exist for the following modified properties: 'members'."}}`))
+			case strings.Contains(string(body), forbidden):
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusForbidden)
+				w.Write([]byte(`{"error":{"code":"Authorization_RequestDenied","message":"Insufficient privileges to complete the operation."}}`))
+			default:
+				w.WriteHeader(http.StatusNoContent)
+			}
+
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	members := make(Members, 0)
+	for i := 0; i < 25; i++ {
+		id := odata.Id(fmt.Sprintf("https://graph.microsoft.com/v1.0/directoryObjects/00000000-0000-0000-0000-%012d", i))
+		members = append(members, DirectoryObject{ODataId: &id})
+	}
+
+	group := Group{DirectoryObject: DirectoryObject{Id: utils.StringPtr("group-id")}, Members: &members}
+
+	c := NewGroupsClient(WithEndpoint(ts.URL), WithRetryMax(0))
+	results, _, err := c.AddMembers(context.Background(), &group)
+	if err == nil {
+		t.Fatal("expected an error for the failed member")
+	}
+	if results == nil || len(*results) != len(members) {
+		t.Fatalf("expected a result for each member, got %v", results)
+	}
+	if patches != 2 {
+		t.Fatalf("got %d batch requests, want 2", patches)
+	}
+
+	for _, result := range *results {
+		want := MemberResultStatusAdded
+		switch {
+		case strings.HasSuffix(string(*result.Member.ODataId), duplicate):
+			want = MemberResultStatusAlreadyPresent
+		case strings.HasSuffix(string(*result.Member.ODataId), forbidden):
+			want = MemberResultStatusFailed
+		}
+		if result.Status != want {
+			t.Errorf("member %s: got status %q, want %q (%v)", *result.Member.ODataId, result.Status, want, result.Err)
+		}
+	}
+}
+
+func TestDirectoryRolesClient_AddMembers(t *testing.T) {
+	var mutex sync.Mutex
+	posts := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		// directory roles don't accept members@odata.bind, so each member must be added by reference
+		if r.Method != http.MethodPost || r.URL.Path != "/v1.0/directoryRoles/role-id/members/$ref" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		posts++
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	members := make(Members, 0)
+	for i := 0; i < 3; i++ {
+		id := odata.Id(fmt.Sprintf("https://graph.microsoft.com/v1.0/directoryObjects/00000000-0000-0000-0000-%012d", i))
+		members = append(members, DirectoryObject{ODataId: &id})
+	}
+	members = append(members, DirectoryObject{})
+
+	directoryRole := DirectoryRole{DirectoryObject: DirectoryObject{Id: utils.StringPtr("role-id")}, Members: &members}
+
+	results, _, err := NewDirectoryRolesClient(WithEndpoint(ts.URL), WithRetryMax(0)).AddMembers(context.Background(), &directoryRole)
+	if err == nil {
+		t.Fatal("expected an error for the member with nil ODataId")
+	}
+	if results == nil || len(*results) != len(members) {
+		t.Fatalf("expected a result for each member, got %v", results)
+	}
+	if posts != 3 {
+		t.Fatalf("got %d reference requests, want 3", posts)
+	}
+	for i, result := range *results {
+		want := MemberResultStatusAdded
+		if i == len(members)-1 {
+			want = MemberResultStatusFailed
+		}
+		if result.Status != want {
+			t.Errorf("member %d: got status %q, want %q (%v)", i, result.Status, want, result.Err)
+		}
+	}
+}
+
+func TestGroupsClient_SetMembers(t *testing.T) {
+	var mutex sync.Mutex
+	requests := make([]string, 0)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if r.Method == http.MethodGet && r.URL.Path == "/beta/groups/group-id/members" {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"value":[{"id":"a"},{"id":"b"},{"id":"c"},{"id":"d"}]}`))
+			return
+		}
+
+		requests = append(requests, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	c := NewGroupsClient(WithEndpoint(ts.URL), WithRetryMax(0))
+	desired := []string{"b", "c", "e", "f", "e"}
+
+	_, _, err := c.SetMembers(context.Background(), "group-id", desired, SetMembershipOptions{MaxRemovalPercentage: 25})
+	var limitErr errors.RemovalLimitExceededError
+	if !goerrors.As(err, &limitErr) || limitErr.Removals != 2 || limitErr.Current != 4 {
+		t.Fatalf("expected RemovalLimitExceededError, got: %v", err)
+	}
+
+	report, _, err := c.SetMembers(context.Background(), "group-id", desired, SetMembershipOptions{MaxRemovalPercentage: 50, DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Changes) != 4 || report.Changes[0].Status != MembershipChangeStatusPlanned {
+		t.Fatalf("unexpected dry run report: %+v", report)
+	}
+	if len(requests) != 0 {
+		t.Fatalf("expected no changes to be made, got: %v", requests)
+	}
+
+	report, _, err = c.SetMembers(context.Background(), "group-id", desired, SetMembershipOptions{MaxRemovalPercentage: 50})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Unchanged) != 2 {
+		t.Fatalf("got unchanged %v, want [b c]", report.Unchanged)
+	}
+
+	want := []MembershipChange{
+		{Id: "e", Action: MembershipChangeActionAdd, Status: MembershipChangeStatusApplied},
+		{Id: "f", Action: MembershipChangeActionAdd, Status: MembershipChangeStatusApplied},
+		{Id: "a", Action: MembershipChangeActionRemove, Status: MembershipChangeStatusApplied},
+		{Id: "d", Action: MembershipChangeActionRemove, Status: MembershipChangeStatusApplied},
+	}
+	if len(report.Changes) != len(want) {
+		t.Fatalf("got %d changes, want %d", len(report.Changes), len(want))
+	}
+	for i, change := range report.Changes {
+		if change != want[i] {
+			t.Errorf("change %d: got %+v, want %+v", i, change, want[i])
+		}
+	}
+
+	wantRequests := []string{
+		"PATCH /beta/groups/group-id",
+		"DELETE /beta/groups/group-id/members/a/$ref",
+		"DELETE /beta/groups/group-id/members/d/$ref",
+	}
+	if strings.Join(requests, ",") != strings.Join(wantRequests, ",") {
+		t.Fatalf("got requests %v, want %v", requests, wantRequests)
+	}
+}
+
+func TestGroupsClient_SetMembersEmpty(t *testing.T) {
+	var mutex sync.Mutex
+	requests := make([]string, 0)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if r.Method == http.MethodGet && r.URL.Path == "/beta/groups/group-id/members" {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"value":[{"id":"a"},{"id":"b"}]}`))
+			return
+		}
+
+		requests = append(requests, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	c := NewGroupsClient(WithEndpoint(ts.URL), WithRetryMax(0))
+
+	// the zero value options must not empty the group
+	_, _, err := c.SetMembers(context.Background(), "group-id", []string{}, SetMembershipOptions{})
+	var limitErr errors.RemovalLimitExceededError
+	if !goerrors.As(err, &limitErr) || limitErr.Removals != 2 || limitErr.MaxPercentage != 0 {
+		t.Fatalf("expected RemovalLimitExceededError, got: %v", err)
+	}
+	if len(requests) != 0 {
+		t.Fatalf("expected no changes to be made, got: %v", requests)
+	}
+
+	report, _, err := c.SetMembers(context.Background(), "group-id", nil, SetMembershipOptions{AllowRemoveAll: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Changes) != 2 || len(requests) != 2 {
+		t.Fatalf("expected both members to be removed, got changes %+v and requests %v", report.Changes, requests)
+	}
+}
+
+func TestGroupsClient_SetMembersCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var mutex sync.Mutex
+	requests := make([]string, 0)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if r.Method == http.MethodGet && r.URL.Path == "/beta/groups/group-id/members" {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"value":[{"id":"a"}]}`))
+			return
+		}
+
+		// the caller gives up while the first batch is in flight
+		requests = append(requests, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+		cancel()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	desired := []string{"a"}
+	for i := 0; i < membersBindBatchSize+5; i++ {
+		desired = append(desired, fmt.Sprintf("member-%d", i))
+	}
+
+	report, _, err := NewGroupsClient(WithEndpoint(ts.URL), WithRetryMax(0)).SetMembers(ctx, "group-id", desired, SetMembershipOptions{})
+	if err == nil {
+		t.Fatal("expected an error when the context is cancelled")
+	}
+	if report == nil || len(report.Changes) != len(desired)-1 {
+		t.Fatalf("expected every addition to be reported, got: %+v", report)
+	}
+	for _, change := range report.Changes {
+		if change.Action != MembershipChangeActionAdd || change.Status != MembershipChangeStatusFailed || change.Err == nil {
+			t.Errorf("expected a failed addition with an error, got %+v", change)
+		}
+	}
+	if len(requests) != 1 {
+		t.Errorf("expected only the first batch to be attempted, got: %v", requests)
+	}
+}
@@ -0,0 +1,303 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+)
+
+type MembershipGraphDirection = string
+
+const (
+	// MembershipGraphDirectionMembers traverses from a group down through its nested members.
+	MembershipGraphDirectionMembers MembershipGraphDirection = "members"
+
+	// MembershipGraphDirectionMemberOf traverses from a principal up through the groups it is nested within.
+	MembershipGraphDirectionMemberOf MembershipGraphDirection = "memberOf"
+)
+
+// MembershipGraphNode is a directory object in a membership graph. Depth is the shortest distance from the root.
+type MembershipGraphNode struct {
+	Id          string     `json:"id"`
+	DisplayName string     `json:"displayName,omitempty"`
+	Type        odata.Type `json:"type,omitempty"`
+	Depth       int        `json:"depth"`
+}
+
+// MembershipGraphEdge records that Member is a direct member of Group.
+type MembershipGraphEdge struct {
+	Group  string `json:"group"`
+	Member string `json:"member"`
+}
+
+// MembershipGraph describes nested group memberships, starting from a root object.
+type MembershipGraph struct {
+	Root      string                   `json:"root"`
+	Direction MembershipGraphDirection `json:"direction"`
+	Nodes     []MembershipGraphNode    `json:"nodes"`
+	Edges     []MembershipGraphEdge    `json:"edges"`
+	Cycles    [][]string               `json:"cycles,omitempty"`
+	MaxDepth  int                      `json:"maxDepth"`
+}
+
+// MembershipGraphOptions configures the traversal when building a MembershipGraph.
+type MembershipGraphOptions struct {
+	// MaxDepth limits how many levels of nesting are traversed. When zero, all levels are traversed.
+	MaxDepth int
+}
+
+// MembersGraph builds the graph of nested members for the specified Group, traversing each member that is itself a group.
+// groupId is the object ID of the group.
+func (c *GroupsClient) MembersGraph(ctx context.Context, groupId string, options MembershipGraphOptions) (*MembershipGraph, int, error) {
+	return buildMembershipGraph(ctx, groupId, MembershipGraphDirectionMembers, options, func(ctx context.Context, id string) (*[]DirectoryObject, int, error) {
+		return c.BaseClient.listDirectoryObjects(ctx, "GroupsClient", fmt.Sprintf("/groups/%s/members", id))
+	})
+}
+
+// MemberOfGraph builds the graph of groups that the specified directory object is a member of, either directly or
+// through nested groups. Unlike UsersClient.ListGroupMemberships, the graph shows which groups confer each membership.
+// id is the object ID of a user, group, service principal, device or other directory object.
+func (c *GroupsClient) MemberOfGraph(ctx context.Context, id string, options MembershipGraphOptions) (*MembershipGraph, int, error) {
+	return buildMembershipGraph(ctx, id, MembershipGraphDirectionMemberOf, options, func(ctx context.Context, id string) (*[]DirectoryObject, int, error) {
+		objects, status, err := c.BaseClient.listDirectoryObjects(ctx, "GroupsClient", fmt.Sprintf("/directoryObjects/%s/memberOf", id))
+		if err != nil {
+			return nil, status, err
+		}
+
+		// only groups confer nested memberships, directory roles and administrative units are ignored
+		groups := make([]DirectoryObject, 0, len(*objects))
+		for _, o := range *objects {
+			if o.ODataType != nil && *o.ODataType == odata.TypeGroup {
+				groups = append(groups, o)
+			}
+		}
+		return &groups, status, nil
+	})
+}
+
+// listDirectoryObjects retrieves the ID, display name and type of the directory objects in a collection.
+func (c Client) listDirectoryObjects(ctx context.Context, clientName, entity string) (*[]DirectoryObject, int, error) {
+	resp, status, _, err := c.Get(ctx, GetHttpRequestInput{
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		OData: odata.Query{
+			Select: []string{"id", "displayName"},
+		},
+		ValidStatusCodes: []int{http.StatusOK},
+		Uri: Uri{
+			Entity: entity,
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("%s.BaseClient.Get(): %v", clientName, err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var data struct {
+		Objects []DirectoryObject `json:"value"`
+	}
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &data.Objects, status, nil
+}
+
+type listDirectoryObjectsFunc func(ctx context.Context, id string) (*[]DirectoryObject, int, error)
+
+// buildMembershipGraph traverses the graph breadth-first from the root, so that the depth of each node is the length of
+// its shortest path from the root.
+func buildMembershipGraph(ctx context.Context, root string, direction MembershipGraphDirection, options MembershipGraphOptions, list listDirectoryObjectsFunc) (*MembershipGraph, int, error) {
+	var status int
+
+	graph := MembershipGraph{
+		Root:      root,
+		Direction: direction,
+		Nodes:     make([]MembershipGraphNode, 0),
+		Edges:     make([]MembershipGraphEdge, 0),
+	}
+
+	nodes := map[string]*MembershipGraphNode{root: {Id: root}}
+	if direction == MembershipGraphDirectionMembers {
+		nodes[root].Type = odata.TypeGroup
+	}
+	queue := []string{root}
+
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		node := nodes[id]
+
+		if options.MaxDepth > 0 && node.Depth >= options.MaxDepth {
+			continue
+		}
+
+		// when traversing members, only groups have members of their own
+		if direction == MembershipGraphDirectionMembers && id != root && node.Type != odata.TypeGroup {
+			continue
+		}
+
+		objects, s, err := list(ctx, id)
+		status = s
+		if err != nil {
+			return nil, status, err
+		}
+
+		for _, o := range *objects {
+			if o.ID() == nil {
+				continue
+			}
+			childId := *o.ID()
+
+			if direction == MembershipGraphDirectionMembers {
+				graph.Edges = append(graph.Edges, MembershipGraphEdge{Group: id, Member: childId})
+			} else {
+				graph.Edges = append(graph.Edges, MembershipGraphEdge{Group: childId, Member: id})
+			}
+
+			if _, ok := nodes[childId]; ok {
+				continue
+			}
+
+			child := &MembershipGraphNode{Id: childId, Depth: node.Depth + 1}
+			if o.DisplayName != nil {
+				child.DisplayName = *o.DisplayName
+			}
+			if o.ODataType != nil {
+				child.Type = *o.ODataType
+			}
+			nodes[childId] = child
+			queue = append(queue, childId)
+		}
+	}
+
+	for _, node := range nodes {
+		graph.Nodes = append(graph.Nodes, *node)
+		if node.Depth > graph.MaxDepth {
+			graph.MaxDepth = node.Depth
+		}
+	}
+	sort.Slice(graph.Nodes, func(i, j int) bool {
+		if graph.Nodes[i].Depth != graph.Nodes[j].Depth {
+			return graph.Nodes[i].Depth < graph.Nodes[j].Depth
+		}
+		return graph.Nodes[i].Id < graph.Nodes[j].Id
+	})
+
+	graph.Cycles = graph.findCycles()
+
+	return &graph, status, nil
+}
+
+// adjacency returns the outgoing edges for each node, in the direction of traversal from the root.
+func (g MembershipGraph) adjacency() map[string][]string {
+	adj := make(map[string][]string)
+	for _, e := range g.Edges {
+		if g.Direction == MembershipGraphDirectionMemberOf {
+			adj[e.Member] = append(adj[e.Member], e.Group)
+		} else {
+			adj[e.Group] = append(adj[e.Group], e.Member)
+		}
+	}
+	return adj
+}
+
+// findCycles returns each cycle found in the graph as a list of object IDs, where the first and last IDs are the same.
+func (g MembershipGraph) findCycles() [][]string {
+	adj := g.adjacency()
+	cycles := make([][]string, 0)
+	visited := make(map[string]bool)
+	onPath := make(map[string]int)
+	path := make([]string, 0)
+
+	var visit func(id string)
+	visit = func(id string) {
+		visited[id] = true
+		onPath[id] = len(path)
+		path = append(path, id)
+
+		for _, next := range adj[id] {
+			if i, ok := onPath[next]; ok {
+				cycle := append(append([]string{}, path[i:]...), next)
+				cycles = append(cycles, cycle)
+				continue
+			}
+			if !visited[next] {
+				visit(next)
+			}
+		}
+
+		path = path[:len(path)-1]
+		delete(onPath, id)
+	}
+	visit(g.Root)
+
+	if len(cycles) == 0 {
+		return nil
+	}
+	return cycles
+}
+
+// Paths returns every membership path between the root and the specified object, with each path beginning with the
+// root. Paths are returned in the direction of traversal, so for a members graph each object in a path is a member of
+// the one before it, and for a memberOf graph each object in a path is a member of the one after it.
+func (g MembershipGraph) Paths(id string) [][]string {
+	adj := g.adjacency()
+	paths := make([][]string, 0)
+	onPath := make(map[string]bool)
+	path := make([]string, 0)
+
+	var visit func(current string)
+	visit = func(current string) {
+		onPath[current] = true
+		path = append(path, current)
+
+		if current == id && len(path) > 1 {
+			paths = append(paths, append([]string{}, path...))
+		} else {
+			for _, next := range adj[current] {
+				if !onPath[next] {
+					visit(next)
+				}
+			}
+		}
+
+		path = path[:len(path)-1]
+		delete(onPath, current)
+	}
+	visit(g.Root)
+
+	return paths
+}
+
+// DOT returns a representation of the graph in the Graphviz DOT language. Edges point from each group to its members.
+func (g MembershipGraph) DOT() string {
+	var b strings.Builder
+	b.WriteString("digraph membership {\n")
+	for _, node := range g.Nodes {
+		label := node.DisplayName
+		if label == "" {
+			label = node.Id
+		}
+		shape := "ellipse"
+		if node.Type == odata.TypeGroup {
+			shape = "box"
+		}
+		fmt.Fprintf(&b, "\t%q [label=%q, shape=%s];\n", node.Id, label, shape)
+	}
+	for _, edge := range g.Edges {
+		fmt.Fprintf(&b, "\t%q -> %q;\n", edge.Group, edge.Member)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
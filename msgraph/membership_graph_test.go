@@ -0,0 +1,63 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGroupsClient_MembersGraph(t *testing.T) {
+	members := map[string]string{
+		"/beta/groups/root/members": `[{"@odata.type":"#microsoft.graph.user","id":"u1"},{"@odata.type":"#microsoft.graph.group","id":"g1","displayName":"Group 1"}]`,
+		"/beta/groups/g1/members":   `[{"@odata.type":"#microsoft.graph.user","id":"u2"},{"@odata.type":"#microsoft.graph.group","id":"g2"}]`,
+		"/beta/groups/g2/members":   `[{"@odata.type":"#microsoft.graph.group","id":"g1"},{"@odata.type":"#microsoft.graph.user","id":"u1"}]`,
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		value, ok := members[r.URL.Path]
+		if !ok {
+			t.Errorf("unexpected request: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"value":` + value + `}`))
+	}))
+	defer ts.Close()
+
+	c := NewGroupsClient(WithEndpoint(ts.URL), WithRetryMax(0))
+	graph, _, err := c.MembersGraph(context.Background(), "root", MembershipGraphOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if graph.MaxDepth != 2 || len(graph.Nodes) != 5 {
+		t.Fatalf("got %d nodes with max depth %d, want 5 nodes with max depth 2", len(graph.Nodes), graph.MaxDepth)
+	}
+
+	if want := [][]string{{"g1", "g2", "g1"}}; !reflect.DeepEqual(graph.Cycles, want) {
+		t.Fatalf("got cycles %v, want %v", graph.Cycles, want)
+	}
+
+	if want := [][]string{{"root", "u1"}, {"root", "g1", "g2", "u1"}}; !reflect.DeepEqual(graph.Paths("u1"), want) {
+		t.Fatalf("got paths %v, want %v", graph.Paths("u1"), want)
+	}
+
+	if _, err := json.Marshal(graph); err != nil {
+		t.Fatalf("json.Marshal(): %v", err)
+	}
+	if dot := graph.DOT(); !strings.Contains(dot, `"g2" -> "g1";`) || !strings.Contains(dot, `"g1" [label="Group 1", shape=box];`) {
+		t.Fatalf("unexpected DOT output:\n%s", dot)
+	}
+
+	graph, _, err = c.MembersGraph(context.Background(), "root", MembershipGraphOptions{MaxDepth: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if graph.MaxDepth != 1 || len(graph.Nodes) != 3 {
+		t.Fatalf("got %d nodes with max depth %d, want 3 nodes with max depth 1", len(graph.Nodes), graph.MaxDepth)
+	}
+}
@@ -0,0 +1,1020 @@
+package msgraph
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/manicminer/hamilton/errors"
+)
+
+type MembershipRuleSubject = string
+
+const (
+	MembershipRuleSubjectDevice MembershipRuleSubject = "device"
+	MembershipRuleSubjectUser   MembershipRuleSubject = "user"
+)
+
+// MembershipRule is a parsed dynamic membership rule, which can be evaluated against User or Device models.
+type MembershipRule struct {
+	rule       string
+	subject    MembershipRuleSubject
+	expression membershipRuleNode
+}
+
+// ParseMembershipRule parses and validates a dynamic membership rule, checking the syntax of the rule as well as the
+// properties and operators it uses. Any problem is returned as an errors.InvalidMembershipRuleError.
+func ParseMembershipRule(rule string) (*MembershipRule, error) {
+	tokens, err := tokenizeMembershipRule(rule)
+	if err != nil {
+		return nil, err
+	}
+
+	p := membershipRuleParser{tokens: tokens}
+	expression, err := p.parseRule()
+	if err != nil {
+		return nil, err
+	}
+
+	return &MembershipRule{
+		rule:       rule,
+		subject:    p.subject,
+		expression: expression,
+	}, nil
+}
+
+// String returns the original text of the rule.
+func (r MembershipRule) String() string {
+	return r.rule
+}
+
+// Subject returns the type of object the rule applies to.
+func (r MembershipRule) Subject() MembershipRuleSubject {
+	return r.subject
+}
+
+// EvaluateUser determines whether the user satisfies the rule. Properties that are not present on the model, such as
+// memberOf or assignedPlans, cannot be evaluated offline and result in an error.
+func (r MembershipRule) EvaluateUser(user User) (bool, error) {
+	if r.subject != MembershipRuleSubjectUser {
+		return false, fmt.Errorf("rule applies to %s objects and cannot be evaluated for a user", r.subject)
+	}
+	return r.expression.eval(func(property string) (interface{}, error) {
+		return userMembershipRuleValue(user, property)
+	})
+}
+
+// EvaluateDevice determines whether the device satisfies the rule. Properties that are not present on the model, such
+// as memberOf, cannot be evaluated offline and result in an error.
+func (r MembershipRule) EvaluateDevice(device Device) (bool, error) {
+	if r.subject != MembershipRuleSubjectDevice {
+		return false, fmt.Errorf("rule applies to %s objects and cannot be evaluated for a device", r.subject)
+	}
+	return r.expression.eval(func(property string) (interface{}, error) {
+		return deviceMembershipRuleValue(device, property)
+	})
+}
+
+type membershipRulePropertyType int
+
+const (
+	membershipRulePropertyString membershipRulePropertyType = iota
+	membershipRulePropertyBool
+	membershipRulePropertyDateTime
+	membershipRulePropertyStringCollection
+	membershipRulePropertyObjectCollection
+)
+
+// membershipRuleProperty describes a property that can be referenced in a rule. Object collections are traversed with
+// -any or -all, using the named variable to refer to properties of each element.
+type membershipRuleProperty struct {
+	name       string
+	typ        membershipRulePropertyType
+	variable   string
+	properties []string
+}
+
+var membershipRuleExtensionAttributes = func() []string {
+	names := make([]string, 15)
+	for i := range names {
+		names[i] = fmt.Sprintf("extensionAttribute%d", i+1)
+	}
+	return names
+}()
+
+var membershipRuleExtensionPropertyRegex = regexp.MustCompile(`^extension_[0-9a-fA-F]{32}_\w+$`)
+
+var membershipRuleProperties = map[MembershipRuleSubject]map[string]membershipRuleProperty{
+	MembershipRuleSubjectUser: membershipRulePropertyMap(
+		membershipRulePropertyNames(membershipRulePropertyBool, "accountEnabled", "dirSyncEnabled"),
+		membershipRulePropertyNames(membershipRulePropertyDateTime, "employeeHireDate"),
+		membershipRulePropertyNames(membershipRulePropertyString, append([]string{
+			"city", "companyName", "country", "department", "displayName", "employeeId", "facsimileTelephoneNumber",
+			"givenName", "jobTitle", "mail", "mailNickName", "mobile", "objectId", "onPremisesDistinguishedName",
+			"onPremisesSamAccountName", "onPremisesSecurityIdentifier", "onPremisesUserPrincipalName",
+			"passwordPolicies", "physicalDeliveryOfficeName", "postalCode", "preferredLanguage", "sipProxyAddress",
+			"state", "streetAddress", "surname", "telephoneNumber", "usageLocation", "userPrincipalName", "userType",
+		}, membershipRuleExtensionAttributes...)...),
+		membershipRulePropertyNames(membershipRulePropertyStringCollection, "otherMails", "proxyAddresses"),
+		[]membershipRuleProperty{
+			{name: "assignedPlans", typ: membershipRulePropertyObjectCollection, variable: "assignedPlan", properties: []string{"capabilityStatus", "service", "servicePlanId"}},
+			{name: "memberOf", typ: membershipRulePropertyObjectCollection, variable: "group", properties: []string{"objectId"}},
+		},
+	),
+	MembershipRuleSubjectDevice: membershipRulePropertyMap(
+		membershipRulePropertyNames(membershipRulePropertyBool, "accountEnabled", "isRooted"),
+		membershipRulePropertyNames(membershipRulePropertyString, append([]string{
+			"deviceCategory", "deviceId", "deviceManufacturer", "deviceModel", "deviceOSType", "deviceOSVersion",
+			"deviceOwnership", "deviceTrustType", "displayName", "enrollmentProfileName", "managementType", "objectId",
+			"profileType", "systemLabels",
+		}, membershipRuleExtensionAttributes...)...),
+		membershipRulePropertyNames(membershipRulePropertyStringCollection, "devicePhysicalIds"),
+		[]membershipRuleProperty{
+			{name: "memberOf", typ: membershipRulePropertyObjectCollection, variable: "group", properties: []string{"objectId"}},
+		},
+	),
+}
+
+func membershipRulePropertyNames(typ membershipRulePropertyType, names ...string) []membershipRuleProperty {
+	properties := make([]membershipRuleProperty, len(names))
+	for i, name := range names {
+		properties[i] = membershipRuleProperty{name: name, typ: typ}
+	}
+	return properties
+}
+
+func membershipRulePropertyMap(groups ...[]membershipRuleProperty) map[string]membershipRuleProperty {
+	m := make(map[string]membershipRuleProperty)
+	for _, properties := range groups {
+		for _, property := range properties {
+			m[strings.ToLower(property.name)] = property
+		}
+	}
+	return m
+}
+
+// membershipRuleOperators maps the lower-cased form of each comparison operator to its canonical form.
+var membershipRuleOperators = map[string]string{
+	"-eq":            "-eq",
+	"-ne":            "-ne",
+	"-startswith":    "-startsWith",
+	"-notstartswith": "-notStartsWith",
+	"-contains":      "-contains",
+	"-notcontains":   "-notContains",
+	"-match":         "-match",
+	"-notmatch":      "-notMatch",
+	"-in":            "-in",
+	"-notin":         "-notIn",
+	"-ge":            "-ge",
+	"-gt":            "-gt",
+	"-le":            "-le",
+	"-lt":            "-lt",
+}
+
+// membershipRuleNegatedOperators maps negated operators to the operator they negate.
+var membershipRuleNegatedOperators = map[string]string{
+	"-ne":            "-eq",
+	"-notStartsWith": "-startsWith",
+	"-notContains":   "-contains",
+	"-notMatch":      "-match",
+	"-notIn":         "-in",
+}
+
+func membershipRuleOperatorsFor(typ membershipRulePropertyType) []string {
+	switch typ {
+	case membershipRulePropertyBool:
+		return []string{"-eq", "-ne"}
+	case membershipRulePropertyDateTime:
+		return []string{"-eq", "-ne", "-ge", "-gt", "-le", "-lt"}
+	case membershipRulePropertyStringCollection, membershipRulePropertyObjectCollection:
+		return []string{"-any", "-all"}
+	}
+	return []string{"-eq", "-ne", "-startsWith", "-notStartsWith", "-contains", "-notContains", "-match", "-notMatch", "-in", "-notIn"}
+}
+
+type membershipRuleTokenType int
+
+const (
+	membershipRuleTokenIdentifier membershipRuleTokenType = iota
+	membershipRuleTokenOperator
+	membershipRuleTokenString
+	membershipRuleTokenPunctuation
+)
+
+type membershipRuleToken struct {
+	typ   membershipRuleTokenType
+	value string
+	pos   int
+	end   int
+}
+
+func tokenizeMembershipRule(rule string) ([]membershipRuleToken, error) {
+	tokens := make([]membershipRuleToken, 0)
+	runes := []rune(rule)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case strings.ContainsRune("()[],", r):
+			tokens = append(tokens, membershipRuleToken{typ: membershipRuleTokenPunctuation, value: string(r), pos: i, end: i + 1})
+			i++
+
+		case r == '"' || r == '\'':
+			start := i
+			var b strings.Builder
+			i++
+			for ; i < len(runes) && runes[i] != r; i++ {
+				// a backtick escapes the following character, i.e. `" for a literal quote
+				if runes[i] == '`' && i+1 < len(runes) {
+					i++
+				}
+				b.WriteRune(runes[i])
+			}
+			if i >= len(runes) {
+				return nil, errors.InvalidMembershipRuleError{Position: start, Message: "unterminated string"}
+			}
+			i++
+			tokens = append(tokens, membershipRuleToken{typ: membershipRuleTokenString, value: b.String(), pos: start, end: i})
+
+		case r == '-':
+			start := i
+			i++
+			for i < len(runes) && unicode.IsLetter(runes[i]) {
+				i++
+			}
+			if i == start+1 {
+				return nil, errors.InvalidMembershipRuleError{Position: start, Message: "expected an operator after '-'"}
+			}
+			tokens = append(tokens, membershipRuleToken{typ: membershipRuleTokenOperator, value: strings.ToLower(string(runes[start:i])), pos: start, end: i})
+
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_' || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, membershipRuleToken{typ: membershipRuleTokenIdentifier, value: string(runes[start:i]), pos: start, end: i})
+
+		default:
+			return nil, errors.InvalidMembershipRuleError{Position: i, Message: fmt.Sprintf("unexpected character %q", r)}
+		}
+	}
+
+	return tokens, nil
+}
+
+// membershipRuleNow is the value of system.now in a rule, optionally offset by an ISO-8601 duration using -plus or
+// -minus. Years, months and days are kept separately from the time component so they follow the calendar.
+type membershipRuleNow struct {
+	years, months, days int
+	duration            time.Duration
+}
+
+func (n membershipRuleNow) at(now time.Time) time.Time {
+	return now.AddDate(n.years, n.months, n.days).Add(n.duration)
+}
+
+var membershipRuleDurationRegex = regexp.MustCompile(`^(?i)P(?:(\d+)Y)?(?:(\d+)M)?(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseMembershipRuleDuration parses an ISO-8601 duration such as p30d or PT12H, as used with system.now.
+func parseMembershipRuleDuration(value string) (membershipRuleNow, bool) {
+	m := membershipRuleDurationRegex.FindStringSubmatch(value)
+	if m == nil || strings.HasSuffix(strings.ToUpper(value), "T") {
+		return membershipRuleNow{}, false
+	}
+	parts := make([]int, len(m)-1)
+	found := false
+	for i, v := range m[1:] {
+		if v != "" {
+			parts[i], _ = strconv.Atoi(v)
+			found = true
+		}
+	}
+	if !found {
+		return membershipRuleNow{}, false
+	}
+	return membershipRuleNow{
+		years:    parts[0],
+		months:   parts[1],
+		days:     parts[2]*7 + parts[3],
+		duration: time.Duration(parts[4])*time.Hour + time.Duration(parts[5])*time.Minute + time.Duration(parts[6])*time.Second,
+	}, true
+}
+
+type membershipRuleResolver func(property string) (interface{}, error)
+
+type membershipRuleNode interface {
+	eval(resolve membershipRuleResolver) (bool, error)
+}
+
+type membershipRuleAnd struct{ left, right membershipRuleNode }
+
+func (n membershipRuleAnd) eval(resolve membershipRuleResolver) (bool, error) {
+	left, err := n.left.eval(resolve)
+	if err != nil || !left {
+		return false, err
+	}
+	return n.right.eval(resolve)
+}
+
+type membershipRuleOr struct{ left, right membershipRuleNode }
+
+func (n membershipRuleOr) eval(resolve membershipRuleResolver) (bool, error) {
+	left, err := n.left.eval(resolve)
+	if err != nil || left {
+		return left, err
+	}
+	return n.right.eval(resolve)
+}
+
+type membershipRuleNot struct{ expression membershipRuleNode }
+
+func (n membershipRuleNot) eval(resolve membershipRuleResolver) (bool, error) {
+	result, err := n.expression.eval(resolve)
+	return !result, err
+}
+
+type membershipRuleDirectReports struct{ managerId string }
+
+func (n membershipRuleDirectReports) eval(membershipRuleResolver) (bool, error) {
+	return false, fmt.Errorf("direct reports rules cannot be evaluated offline")
+}
+
+type membershipRuleComparison struct {
+	property string
+	typ      membershipRulePropertyType
+	operator string
+	value    interface{}
+}
+
+func (n membershipRuleComparison) eval(resolve membershipRuleResolver) (bool, error) {
+	actual, err := resolve(n.property)
+	if err != nil {
+		return false, err
+	}
+
+	if positive, ok := membershipRuleNegatedOperators[n.operator]; ok {
+		result, err := membershipRuleComparison{property: n.property, typ: n.typ, operator: positive, value: n.value}.compare(actual)
+		return !result, err
+	}
+	return n.compare(actual)
+}
+
+func (n membershipRuleComparison) compare(actual interface{}) (bool, error) {
+	if n.value == nil {
+		// comparison with null
+		switch v := actual.(type) {
+		case nil:
+			return true, nil
+		case string:
+			return v == "", nil
+		case []string:
+			return len(v) == 0, nil
+		}
+		return false, nil
+	}
+
+	switch n.typ {
+	case membershipRulePropertyBool:
+		v, ok := actual.(bool)
+		return ok && v == n.value.(bool), nil
+
+	case membershipRulePropertyDateTime:
+		v, ok := actual.(time.Time)
+		if !ok {
+			return false, nil
+		}
+		var expected time.Time
+		switch t := n.value.(type) {
+		case time.Time:
+			expected = t
+		case membershipRuleNow:
+			expected = t.at(time.Now())
+		}
+		switch n.operator {
+		case "-ge":
+			return !v.Before(expected), nil
+		case "-gt":
+			return v.After(expected), nil
+		case "-le":
+			return !v.After(expected), nil
+		case "-lt":
+			return v.Before(expected), nil
+		}
+		return v.Equal(expected), nil
+	}
+
+	values := make([]string, 0)
+	switch v := actual.(type) {
+	case string:
+		values = append(values, v)
+	case []string:
+		values = v
+	}
+
+	// string comparisons are case-insensitive, and a multi-valued property matches when any value matches
+	for _, v := range values {
+		match, err := n.compareString(strings.ToLower(v))
+		if err != nil || match {
+			return match, err
+		}
+	}
+	return false, nil
+}
+
+func (n membershipRuleComparison) compareString(actual string) (bool, error) {
+	switch n.operator {
+	case "-in":
+		for _, v := range n.value.([]string) {
+			if actual == strings.ToLower(v) {
+				return true, nil
+			}
+		}
+		return false, nil
+	case "-match":
+		re, err := regexp.Compile("(?i)" + n.value.(string))
+		if err != nil {
+			return false, err
+		}
+		return re.MatchString(actual), nil
+	}
+
+	expected := strings.ToLower(n.value.(string))
+	switch n.operator {
+	case "-startsWith":
+		return strings.HasPrefix(actual, expected), nil
+	case "-contains":
+		return strings.Contains(actual, expected), nil
+	}
+	return actual == expected, nil
+}
+
+// membershipRuleCollection is an -any or -all expression over the elements of a multi-valued property.
+type membershipRuleCollection struct {
+	property   string
+	all        bool
+	expression membershipRuleNode
+}
+
+func (n membershipRuleCollection) eval(resolve membershipRuleResolver) (bool, error) {
+	actual, err := resolve(n.property)
+	if err != nil {
+		return false, err
+	}
+
+	values, ok := actual.([]string)
+	if !ok {
+		return false, fmt.Errorf("property %q cannot be evaluated offline", n.property)
+	}
+
+	for _, v := range values {
+		element := v
+		result, err := n.expression.eval(func(string) (interface{}, error) { return element, nil })
+		if err != nil {
+			return false, err
+		}
+		if result != n.all {
+			return result, nil
+		}
+	}
+	return n.all, nil
+}
+
+type membershipRuleParser struct {
+	tokens  []membershipRuleToken
+	pos     int
+	subject MembershipRuleSubject
+
+	// collection is set while parsing the expression for an -any or -all operator
+	collection *membershipRuleProperty
+}
+
+func (p *membershipRuleParser) peek() *membershipRuleToken {
+	if p.pos < len(p.tokens) {
+		return &p.tokens[p.pos]
+	}
+	return nil
+}
+
+func (p *membershipRuleParser) next() *membershipRuleToken {
+	t := p.peek()
+	if t != nil {
+		p.pos++
+	}
+	return t
+}
+
+func (p *membershipRuleParser) errorf(format string, v ...interface{}) error {
+	pos := 0
+	if t := p.peek(); t != nil {
+		pos = t.pos
+	} else if len(p.tokens) > 0 {
+		pos = p.tokens[len(p.tokens)-1].end
+	}
+	return errors.InvalidMembershipRuleError{Position: pos, Message: fmt.Sprintf(format, v...)}
+}
+
+func (p *membershipRuleParser) accept(typ membershipRuleTokenType, value string) bool {
+	if t := p.peek(); t != nil && t.typ == typ && t.value == value {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// acceptLogical accepts a logical operator, which may be written with or without the leading hyphen, e.g. -and or and.
+func (p *membershipRuleParser) acceptLogical(name string) bool {
+	if t := p.peek(); t != nil && t.typ == membershipRuleTokenIdentifier && strings.EqualFold(t.value, name) {
+		p.pos++
+		return true
+	}
+	return p.accept(membershipRuleTokenOperator, "-"+name)
+}
+
+func (p *membershipRuleParser) parseRule() (membershipRuleNode, error) {
+	if len(p.tokens) == 0 {
+		return nil, errors.InvalidMembershipRuleError{Message: "rule is empty"}
+	}
+
+	if node, ok, err := p.parseDirectReports(); ok || err != nil {
+		return node, err
+	}
+
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t != nil {
+		return nil, p.errorf("unexpected %q", t.value)
+	}
+	return node, nil
+}
+
+// parseDirectReports parses the special rule `Direct Reports for "{managerId}"`.
+func (p *membershipRuleParser) parseDirectReports() (membershipRuleNode, bool, error) {
+	if len(p.tokens) < 3 || !strings.EqualFold(p.tokens[0].value, "direct") || !strings.EqualFold(p.tokens[1].value, "reports") {
+		return nil, false, nil
+	}
+	p.pos = 2
+	if t := p.next(); t == nil || !strings.EqualFold(t.value, "for") {
+		p.pos--
+		return nil, true, p.errorf("expected 'for'")
+	}
+	t := p.next()
+	if t == nil || t.typ != membershipRuleTokenString {
+		return nil, true, p.errorf("expected the object ID of a manager")
+	}
+	if p.peek() != nil {
+		return nil, true, p.errorf("a direct reports rule cannot be combined with other expressions")
+	}
+	p.subject = MembershipRuleSubjectUser
+	return membershipRuleDirectReports{managerId: t.value}, true, nil
+}
+
+func (p *membershipRuleParser) parseOr() (membershipRuleNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptLogical("or") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = membershipRuleOr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *membershipRuleParser) parseAnd() (membershipRuleNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptLogical("and") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = membershipRuleAnd{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *membershipRuleParser) parseUnary() (membershipRuleNode, error) {
+	if p.accept(membershipRuleTokenOperator, "-not") {
+		expression, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return membershipRuleNot{expression: expression}, nil
+	}
+
+	if p.accept(membershipRuleTokenPunctuation, "(") {
+		expression, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.accept(membershipRuleTokenPunctuation, ")") {
+			return nil, p.errorf("expected ')'")
+		}
+		return expression, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *membershipRuleParser) parseComparison() (membershipRuleNode, error) {
+	t := p.peek()
+	if t == nil || t.typ != membershipRuleTokenIdentifier {
+		return nil, p.errorf("expected a property")
+	}
+
+	property, err := p.parseProperty()
+	if err != nil {
+		return nil, err
+	}
+
+	op := p.peek()
+	if op == nil || op.typ != membershipRuleTokenOperator {
+		return nil, p.errorf("expected an operator after %q", property.name)
+	}
+
+	if op.value == "-any" || op.value == "-all" {
+		return p.parseCollection(property)
+	}
+
+	operator, ok := membershipRuleOperators[op.value]
+	if !ok || !membershipRuleOperatorSupported(property.typ, operator) {
+		return nil, p.errorf("operator %q is not supported for property %q", op.value, property.name)
+	}
+	p.next()
+
+	value, err := p.parseValue(property, operator)
+	if err != nil {
+		return nil, err
+	}
+
+	return membershipRuleComparison{property: property.name, typ: property.typ, operator: operator, value: value}, nil
+}
+
+func membershipRuleOperatorSupported(typ membershipRulePropertyType, operator string) bool {
+	for _, o := range membershipRuleOperatorsFor(typ) {
+		if o == operator {
+			return true
+		}
+	}
+	return false
+}
+
+// parseProperty parses a property reference such as user.department, or a reference to a collection element within an
+// -any or -all expression, such as _ or assignedPlan.servicePlanId.
+func (p *membershipRuleParser) parseProperty() (*membershipRuleProperty, error) {
+	t := p.peek()
+
+	if p.collection != nil {
+		if p.collection.typ == membershipRulePropertyStringCollection {
+			if t.value != "_" {
+				return nil, p.errorf("expected '_' to refer to the values of %q", p.collection.name)
+			}
+			p.next()
+			return &membershipRuleProperty{name: "_", typ: membershipRulePropertyString}, nil
+		}
+
+		variable, name, _ := strings.Cut(t.value, ".")
+		if !strings.EqualFold(variable, p.collection.variable) {
+			return nil, p.errorf("expected a property of %q", p.collection.variable)
+		}
+		for _, property := range p.collection.properties {
+			if strings.EqualFold(property, name) {
+				p.next()
+				return &membershipRuleProperty{name: fmt.Sprintf("%s.%s", p.collection.variable, property), typ: membershipRulePropertyString}, nil
+			}
+		}
+		return nil, p.errorf("unknown property %q", t.value)
+	}
+
+	subject, name, ok := strings.Cut(t.value, ".")
+	subject = strings.ToLower(subject)
+	if !ok || (subject != MembershipRuleSubjectUser && subject != MembershipRuleSubjectDevice) {
+		return nil, p.errorf("expected a user or device property, got %q", t.value)
+	}
+	if p.subject != "" && p.subject != subject {
+		return nil, p.errorf("a rule cannot refer to both user and device properties")
+	}
+
+	property, ok := membershipRuleProperties[subject][strings.ToLower(name)]
+	if !ok {
+		if !membershipRuleExtensionPropertyRegex.MatchString(name) {
+			return nil, p.errorf("unknown property %q", t.value)
+		}
+		property = membershipRuleProperty{name: name, typ: membershipRulePropertyString}
+	}
+
+	p.subject = subject
+	p.next()
+	return &property, nil
+}
+
+func (p *membershipRuleParser) parseCollection(property *membershipRuleProperty) (membershipRuleNode, error) {
+	op := p.next()
+	if p.collection != nil {
+		return nil, p.errorf("%s expressions cannot be nested", op.value)
+	}
+	if property.typ != membershipRulePropertyStringCollection && property.typ != membershipRulePropertyObjectCollection {
+		p.pos--
+		return nil, p.errorf("operator %q is only supported for multi-valued properties", op.value)
+	}
+
+	p.collection = property
+	defer func() { p.collection = nil }()
+
+	var expression membershipRuleNode
+	var err error
+	if p.accept(membershipRuleTokenPunctuation, "(") {
+		if expression, err = p.parseOr(); err != nil {
+			return nil, err
+		}
+		if !p.accept(membershipRuleTokenPunctuation, ")") {
+			return nil, p.errorf("expected ')'")
+		}
+	} else if expression, err = p.parseComparison(); err != nil {
+		return nil, err
+	}
+
+	return membershipRuleCollection{property: property.name, all: op.value == "-all", expression: expression}, nil
+}
+
+func (p *membershipRuleParser) parseValue(property *membershipRuleProperty, operator string) (interface{}, error) {
+	if operator == "-in" || operator == "-notIn" {
+		if !p.accept(membershipRuleTokenPunctuation, "[") {
+			return nil, p.errorf("expected '[' to begin a list of values")
+		}
+		values := make([]string, 0)
+		for {
+			t := p.next()
+			if t == nil || t.typ != membershipRuleTokenString {
+				p.pos--
+				return nil, p.errorf("expected a string value")
+			}
+			values = append(values, t.value)
+			if p.accept(membershipRuleTokenPunctuation, "]") {
+				return values, nil
+			}
+			if !p.accept(membershipRuleTokenPunctuation, ",") {
+				return nil, p.errorf("expected ',' or ']'")
+			}
+		}
+	}
+
+	t := p.peek()
+	if t == nil || (t.typ != membershipRuleTokenString && t.typ != membershipRuleTokenIdentifier) {
+		return nil, p.errorf("expected a value")
+	}
+
+	if t.typ == membershipRuleTokenIdentifier && strings.EqualFold(t.value, "null") {
+		if operator != "-eq" && operator != "-ne" {
+			return nil, p.errorf("null can only be compared using -eq or -ne")
+		}
+		p.next()
+		return nil, nil
+	}
+
+	switch property.typ {
+	case membershipRulePropertyBool:
+		switch strings.ToLower(t.value) {
+		case "true":
+			p.next()
+			return true, nil
+		case "false":
+			p.next()
+			return false, nil
+		}
+		return nil, p.errorf("expected true or false for property %q", property.name)
+
+	case membershipRulePropertyDateTime:
+		if t.typ == membershipRuleTokenIdentifier && strings.EqualFold(t.value, "system.now") {
+			p.next()
+			return p.parseNowOffset()
+		}
+		if t.typ == membershipRuleTokenString {
+			for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+				if v, err := time.Parse(layout, t.value); err == nil {
+					p.next()
+					return v, nil
+				}
+			}
+		}
+		return nil, p.errorf("expected a date and time or system.now for property %q", property.name)
+	}
+
+	if t.typ != membershipRuleTokenString {
+		return nil, p.errorf("expected a quoted string value for property %q", property.name)
+	}
+	if operator == "-match" || operator == "-notMatch" {
+		if _, err := regexp.Compile(t.value); err != nil {
+			return nil, p.errorf("invalid regular expression: %v", err)
+		}
+	}
+	p.next()
+	return t.value, nil
+}
+
+// parseNowOffset parses an optional -plus or -minus duration following system.now, e.g. system.now -minus p30d.
+func (p *membershipRuleParser) parseNowOffset() (interface{}, error) {
+	minus := p.accept(membershipRuleTokenOperator, "-minus")
+	if !minus && !p.accept(membershipRuleTokenOperator, "-plus") {
+		return membershipRuleNow{}, nil
+	}
+
+	t := p.peek()
+	if t == nil || (t.typ != membershipRuleTokenIdentifier && t.typ != membershipRuleTokenString) {
+		return nil, p.errorf("expected an ISO-8601 duration after system.now")
+	}
+	now, ok := parseMembershipRuleDuration(t.value)
+	if !ok {
+		return nil, p.errorf("invalid ISO-8601 duration %q", t.value)
+	}
+	p.next()
+
+	if minus {
+		now = membershipRuleNow{years: -now.years, months: -now.months, days: -now.days, duration: -now.duration}
+	}
+	return now, nil
+}
+
+func membershipRuleString(v *string) interface{} {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
+
+func membershipRuleNullableString(v *StringNullWhenEmpty) interface{} {
+	if v == nil {
+		return nil
+	}
+	return string(*v)
+}
+
+func membershipRuleBool(v *bool) interface{} {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
+
+func membershipRuleStrings(v *[]string) interface{} {
+	if v == nil {
+		return []string{}
+	}
+	return *v
+}
+
+func membershipRuleExtensionAttribute(attributes *OnPremisesExtensionAttributes, property string) (interface{}, bool) {
+	if !strings.HasPrefix(property, "extensionAttribute") {
+		return nil, false
+	}
+	if attributes == nil {
+		return nil, true
+	}
+	field := reflect.ValueOf(*attributes).FieldByName("E" + strings.TrimPrefix(property, "e"))
+	if !field.IsValid() || field.IsNil() {
+		return nil, true
+	}
+	return field.Elem().String(), true
+}
+
+func userMembershipRuleValue(user User, property string) (interface{}, error) {
+	if v, ok := membershipRuleExtensionAttribute(user.OnPremisesExtensionAttributes, property); ok {
+		return v, nil
+	}
+
+	switch property {
+	case "accountEnabled":
+		return membershipRuleBool(user.AccountEnabled), nil
+	case "dirSyncEnabled":
+		return membershipRuleBool(user.OnPremisesSyncEnabled), nil
+	case "employeeHireDate":
+		if user.EmployeeHireDate == nil {
+			return nil, nil
+		}
+		return *user.EmployeeHireDate, nil
+	case "city":
+		return membershipRuleNullableString(user.City), nil
+	case "companyName":
+		return membershipRuleNullableString(user.CompanyName), nil
+	case "country":
+		return membershipRuleNullableString(user.Country), nil
+	case "department":
+		return membershipRuleNullableString(user.Department), nil
+	case "displayName":
+		return membershipRuleString(user.DisplayName), nil
+	case "employeeId":
+		return membershipRuleNullableString(user.EmployeeId), nil
+	case "facsimileTelephoneNumber":
+		return membershipRuleNullableString(user.FaxNumber), nil
+	case "givenName":
+		return membershipRuleNullableString(user.GivenName), nil
+	case "jobTitle":
+		return membershipRuleNullableString(user.JobTitle), nil
+	case "mail":
+		return membershipRuleNullableString(user.Mail), nil
+	case "mailNickName":
+		return membershipRuleString(user.MailNickname), nil
+	case "mobile":
+		return membershipRuleNullableString(user.MobilePhone), nil
+	case "objectId":
+		return membershipRuleString(user.ID()), nil
+	case "onPremisesDistinguishedName":
+		return membershipRuleString(user.OnPremisesDistinguishedName), nil
+	case "onPremisesSamAccountName":
+		return membershipRuleString(user.OnPremisesSamAccountName), nil
+	case "onPremisesSecurityIdentifier":
+		return membershipRuleString(user.OnPremisesSecurityIdentifier), nil
+	case "onPremisesUserPrincipalName":
+		return membershipRuleString(user.OnPremisesUserPrincipalName), nil
+	case "passwordPolicies":
+		return membershipRuleNullableString(user.PasswordPolicies), nil
+	case "physicalDeliveryOfficeName":
+		return membershipRuleNullableString(user.OfficeLocation), nil
+	case "postalCode":
+		return membershipRuleNullableString(user.PostalCode), nil
+	case "preferredLanguage":
+		return membershipRuleNullableString(user.PreferredLanguage), nil
+	case "state":
+		return membershipRuleNullableString(user.State), nil
+	case "streetAddress":
+		return membershipRuleNullableString(user.StreetAddress), nil
+	case "surname":
+		return membershipRuleNullableString(user.Surname), nil
+	case "telephoneNumber":
+		if user.BusinessPhones == nil || len(*user.BusinessPhones) == 0 {
+			return nil, nil
+		}
+		return (*user.BusinessPhones)[0], nil
+	case "usageLocation":
+		return membershipRuleNullableString(user.UsageLocation), nil
+	case "userPrincipalName":
+		return membershipRuleString(user.UserPrincipalName), nil
+	case "userType":
+		return membershipRuleString(user.UserType), nil
+	case "otherMails":
+		return membershipRuleStrings(user.OtherMails), nil
+	case "proxyAddresses":
+		return membershipRuleStrings(user.ProxyAddresses), nil
+	}
+
+	return nil, fmt.Errorf("property %q cannot be evaluated offline", fmt.Sprintf("user.%s", property))
+}
+
+func deviceMembershipRuleValue(device Device, property string) (interface{}, error) {
+	if v, ok := membershipRuleExtensionAttribute(device.ExtensionAttributes, property); ok {
+		return v, nil
+	}
+
+	switch property {
+	case "accountEnabled":
+		return membershipRuleBool(device.AccountEnabled), nil
+	case "isRooted":
+		return membershipRuleBool(device.IsRooted), nil
+	case "deviceCategory":
+		return membershipRuleString(device.DeviceCategory), nil
+	case "deviceId":
+		return membershipRuleString(device.DeviceId), nil
+	case "deviceManufacturer":
+		return membershipRuleString(device.Manufacturer), nil
+	case "deviceModel":
+		return membershipRuleString(device.Model), nil
+	case "deviceOSType":
+		return membershipRuleString(device.OperatingSystem), nil
+	case "deviceOSVersion":
+		return membershipRuleString(device.OperatingSystemVersion), nil
+	case "deviceOwnership":
+		return membershipRuleString(device.DeviceOwnership), nil
+	case "deviceTrustType":
+		return membershipRuleString(device.TrustType), nil
+	case "displayName":
+		return membershipRuleString(device.DisplayName), nil
+	case "enrollmentProfileName":
+		return membershipRuleString(device.EnrollmentProfileName), nil
+	case "managementType":
+		return membershipRuleString(device.ManagementType), nil
+	case "objectId":
+		return membershipRuleString(device.ID()), nil
+	case "profileType":
+		return membershipRuleString(device.ProfileType), nil
+	case "systemLabels":
+		return membershipRuleStrings(device.SystemLabels), nil
+	case "devicePhysicalIds":
+		return membershipRuleStrings(device.PhysicalIds), nil
+	}
+
+	return nil, fmt.Errorf("property %q cannot be evaluated offline", fmt.Sprintf("device.%s", property))
+}
@@ -0,0 +1,165 @@
+package msgraph
+
+import (
+	goerrors "errors"
+	"testing"
+	"time"
+
+	"github.com/manicminer/hamilton/errors"
+	"github.com/manicminer/hamilton/internal/utils"
+)
+
+func TestParseMembershipRule(t *testing.T) {
+	valid := []string{
+		`user.department -eq "Sales"`,
+		`(user.department -eq "Sales") -and (user.country -in ["US", 'GB'])`,
+		`user.accountEnabled -eq true -and -not (user.jobTitle -startsWith "Contract")`,
+		`USER.Department -EQ "Sales" -OR user.mail -ne null`,
+		`user.proxyAddresses -any (_ -contains "contoso")`,
+		`user.assignedPlans -any (assignedPlan.servicePlanId -eq "efb87545-963c-4e0d-99df-69c6916d9eb0" -and assignedPlan.capabilityStatus -eq "Enabled")`,
+		`user.memberOf -any (group.objectId -in ['00000000-0000-0000-0000-000000000000'])`,
+		`user.extensionAttribute15 -eq "Marketing" -and user.extension_c272a57b722d4eb29bfe327874ae79cb_OptionalAttribute -eq "x"`,
+		`user.employeeHireDate -le system.now -and user.employeeHireDate -ge "2020-01-01"`,
+		`(user.employeeHireDate -ge system.now -minus p30d)`,
+		`user.employeeHireDate -le system.now -plus p1d`,
+		`user.employeeHireDate -ge system.now -minus P1Y2M -and user.employeeHireDate -lt system.now -plus PT12H`,
+		`user.department -eq "Sales" and user.country -eq "GB" or user.accountEnabled -eq false`,
+		`user.displayName -match "^Sales.*` + "`" + `"$"`,
+		`device.devicePhysicalIds -any _ -startsWith "[OrderId]"`,
+		`device.systemLabels -contains "M365Managed"`,
+		`Direct Reports for "62e19b97-8b3d-4d4a-a106-4ce66896a863"`,
+	}
+	for _, rule := range valid {
+		if _, err := ParseMembershipRule(rule); err != nil {
+			t.Errorf("ParseMembershipRule(%q): %v", rule, err)
+		}
+	}
+
+	invalid := []struct {
+		rule     string
+		position int
+	}{
+		{`user.department -eq "Sales`, 20},
+		{`user.departmnt -eq "Sales"`, 0},
+		{`user.department -equals "Sales"`, 16},
+		{`user.department -eq "Sales" -and device.displayName -eq "x"`, 33},
+		{`user.accountEnabled -contains "true"`, 20},
+		{`(user.department -eq "Sales"`, 28},
+		{`user.proxyAddresses -any (assignedPlan.service -eq "x")`, 26},
+		{`user.department -in "Sales"`, 20},
+		{`user.department -eq "Sales" "Marketing"`, 28},
+		{`user.displayName -match "("`, 24},
+		{`group.displayName -eq "x"`, 0},
+		{`user.employeeHireDate -ge system.now -minus 30d`, 44},
+		{`user.employeeHireDate -ge system.now -plus`, 42},
+	}
+	for _, tc := range invalid {
+		_, err := ParseMembershipRule(tc.rule)
+		var ruleErr errors.InvalidMembershipRuleError
+		if !goerrors.As(err, &ruleErr) {
+			t.Errorf("ParseMembershipRule(%q): expected InvalidMembershipRuleError, got: %v", tc.rule, err)
+			continue
+		}
+		if ruleErr.Position != tc.position {
+			t.Errorf("ParseMembershipRule(%q): got position %d, want %d (%v)", tc.rule, ruleErr.Position, tc.position, err)
+		}
+	}
+}
+
+func TestMembershipRule_Evaluate(t *testing.T) {
+	hireDate := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	user := User{
+		DirectoryObject:  DirectoryObject{Id: utils.StringPtr("00000000-0000-0000-0000-000000000001")},
+		AccountEnabled:   utils.BoolPtr(true),
+		Department:       NullableString("Sales"),
+		Country:          NullableString("GB"),
+		EmployeeHireDate: &hireDate,
+		ProxyAddresses:   &[]string{"SMTP:alice@contoso.com", "smtp:alice@fabrikam.com"},
+		OnPremisesExtensionAttributes: &OnPremisesExtensionAttributes{
+			ExtensionAttribute3: utils.StringPtr("Contractor"),
+		},
+	}
+
+	cases := []struct {
+		rule string
+		want bool
+	}{
+		{`user.department -eq "sales"`, true},
+		{`user.department -ne "Sales"`, false},
+		{`user.department -eq "Sales" -and user.country -in ["US", "GB"]`, true},
+		{`user.country -notIn ["US", "GB"] -or user.accountEnabled -eq false`, false},
+		{`-not (user.accountEnabled -eq false)`, true},
+		{`user.jobTitle -eq null`, true},
+		{`user.department -ne null`, true},
+		{`user.department -startsWith "Sa" -and user.department -notContains "x"`, true},
+		{`user.department -match "^s.l"`, true},
+		{`user.proxyAddresses -any (_ -contains "fabrikam")`, true},
+		{`user.proxyAddresses -all (_ -startsWith "smtp:")`, true},
+		{`user.proxyAddresses -all (_ -contains "contoso")`, false},
+		{`user.extensionAttribute3 -eq "contractor"`, true},
+		{`user.employeeHireDate -lt "2022-01-01" -and user.employeeHireDate -le system.now`, true},
+		{`user.department -eq "Sales" and user.country -eq "GB"`, true},
+		{`user.department -eq "Marketing" or user.country -eq "GB"`, true},
+	}
+	for _, tc := range cases {
+		rule, err := ParseMembershipRule(tc.rule)
+		if err != nil {
+			t.Errorf("ParseMembershipRule(%q): %v", tc.rule, err)
+			continue
+		}
+		got, err := rule.EvaluateUser(user)
+		if err != nil {
+			t.Errorf("EvaluateUser(%q): %v", tc.rule, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("EvaluateUser(%q): got %t, want %t", tc.rule, got, tc.want)
+		}
+	}
+
+	recentHireDate := time.Now().AddDate(0, 0, -10)
+	recentHire := User{EmployeeHireDate: &recentHireDate}
+	for _, tc := range []struct {
+		rule string
+		want bool
+	}{
+		{`(user.employeeHireDate -ge system.now -minus p30d)`, true},
+		{`user.employeeHireDate -ge system.now -minus p7d`, false},
+		{`user.employeeHireDate -le system.now -plus p1d`, true},
+		{`user.employeeHireDate -le system.now -minus p1w`, true},
+		{`user.employeeHireDate -lt system.now -minus P1M`, false},
+	} {
+		rule, err := ParseMembershipRule(tc.rule)
+		if err != nil {
+			t.Errorf("ParseMembershipRule(%q): %v", tc.rule, err)
+			continue
+		}
+		if got, err := rule.EvaluateUser(recentHire); err != nil || got != tc.want {
+			t.Errorf("EvaluateUser(%q): got %t, %v, want %t", tc.rule, got, err, tc.want)
+		}
+	}
+
+	rule, err := ParseMembershipRule(`user.memberOf -any (group.objectId -in ['00000000-0000-0000-0000-000000000000'])`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rule.EvaluateUser(user); err == nil {
+		t.Error("expected an error evaluating memberOf offline")
+	}
+	if _, err := rule.EvaluateDevice(Device{}); err == nil {
+		t.Error("expected an error evaluating a user rule for a device")
+	}
+
+	device := Device{
+		OperatingSystem: utils.StringPtr("Windows"),
+		PhysicalIds:     &[]string{"[ZTDId]:a1b2c3", "[OrderId]:Kiosk"},
+		SystemLabels:    &[]string{"M365Managed"},
+	}
+	rule, err = ParseMembershipRule(`device.deviceOSType -eq "windows" -and device.devicePhysicalIds -any _ -eq "[OrderId]:Kiosk" -and device.systemLabels -contains "M365"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := rule.EvaluateDevice(device); err != nil || !got {
+		t.Errorf("EvaluateDevice(): got %t, %v", got, err)
+	}
+}
@@ -204,6 +204,14 @@ type AccessPackageSubject struct {
 	SubjectType                 *string `json:"subjectType,omitempty"`
 }
 
+type ActivityBasedTimeoutPolicy struct {
+	DirectoryObject
+	Definition            *[]string `json:"definition,omitempty"`
+	Description           *string   `json:"description,omitempty"`
+	DisplayName           *string   `json:"displayName,omitempty"`
+	IsOrganizationDefault *bool     `json:"isOrganizationDefault,omitempty"`
+}
+
 type AddIn struct {
 	ID         *string          `json:"id,omitempty"`
 	Properties *[]AddInKeyValue `json:"properties,omitempty"`
@@ -215,6 +223,39 @@ type AddInKeyValue struct {
 	Value *string `json:"value,omitempty"`
 }
 
+// AdminConsentReport describes the changes made when granting admin consent for an application. ApplicationId is the
+// object ID of the application and ServicePrincipalId is the object ID of its service principal.
+type AdminConsentReport struct {
+	AppId              string
+	ApplicationId      string
+	ServicePrincipalId string
+	Changes            []AdminConsentChange
+}
+
+// Failed returns the changes which could not be applied.
+func (r AdminConsentReport) Failed() []AdminConsentChange {
+	failed := make([]AdminConsentChange, 0)
+	for _, change := range r.Changes {
+		if change.Err != nil {
+			failed = append(failed, change)
+		}
+	}
+	return failed
+}
+
+// AdminConsentChange describes a single change made when granting admin consent. ResourceId is the object ID of the
+// resource service principal, Scopes are the delegated permissions granted for the resource after the change, and
+// AppRoleId identifies the application permission that was assigned or removed.
+type AdminConsentChange struct {
+	Action              AdminConsentAction
+	ResourceId          string
+	ResourceDisplayName string
+	Scopes              []string
+	AppRoleId           string
+	AppRoleValue        string
+	Err                 error
+}
+
 type AdministrativeUnit struct {
 	Description *StringNullWhenEmpty          `json:"description,omitempty"`
 	DisplayName *string                       `json:"displayName,omitempty"`
@@ -239,6 +280,9 @@ type Application struct {
 	DirectoryObject
 	Owners *Owners `json:"owners@odata.bind,omitempty"`
 
+	// FederatedIdentityCredentials is a navigation property, it is only populated when expanded and is never sent
+	FederatedIdentityCredentials *[]FederatedIdentityCredential `json:"-"` // see Application.UnmarshalJSON
+
 	AddIns                        *[]AddIn                  `json:"addIns,omitempty"`
 	Api                           *ApplicationApi           `json:"api,omitempty"`
 	AppId                         *string                   `json:"appId,omitempty"`
@@ -253,14 +297,14 @@ type Application struct {
 	GroupMembershipClaims         *[]GroupMembershipClaim   `json:"-"` // see Application.MarshalJSON / Application.UnmarshalJSON
 	IdentifierUris                *[]string                 `json:"identifierUris,omitempty"`
 	Info                          *InformationalUrl         `json:"info,omitempty"`
-	IsAuthorizationServiceEnabled *bool                     `json:"isAuthorizationServiceEnabled,omitempty"`
+	IsAuthorizationServiceEnabled *bool                     `json:"isAuthorizationServiceEnabled,omitempty" api:"beta"`
 	IsDeviceOnlyAuthSupported     *bool                     `json:"isDeviceOnlyAuthSupported,omitempty"`
 	IsFallbackPublicClient        *bool                     `json:"isFallbackPublicClient,omitempty"`
-	IsManagementRestricted        *bool                     `json:"isManagementRestricted,omitempty"`
+	IsManagementRestricted        *bool                     `json:"isManagementRestricted,omitempty" api:"beta"`
 	KeyCredentials                *[]KeyCredential          `json:"keyCredentials,omitempty"`
 	Oauth2RequirePostResponse     *bool                     `json:"oauth2RequirePostResponse,omitempty"` // field name has typo in beta API
 	Oauth2RequiredPostResponse    *bool                     `json:"oauth2RequiredPostResponse,omitempty"`
-	OnPremisesPublishing          *OnPremisesPublishing     `json:"onPremisesPublishing,omitempty"`
+	OnPremisesPublishing          *OnPremisesPublishing     `json:"onPremisesPublishing,omitempty" api:"beta"`
 	OptionalClaims                *OptionalClaims           `json:"optionalClaims,omitempty"`
 	Notes                         *StringNullWhenEmpty      `json:"notes,omitempty"`
 	ParentalControlSettings       *ParentalControlSettings  `json:"parentalControlSettings,omitempty"`
@@ -306,7 +350,8 @@ func (a *Application) UnmarshalJSON(data []byte) error {
 	// Local type needed to avoid recursive UnmarshalJSON calls
 	type application Application
 	app := struct {
-		GroupMembershipClaims *string `json:"groupMembershipClaims"`
+		FederatedIdentityCredentials *[]FederatedIdentityCredential `json:"federatedIdentityCredentials"`
+		GroupMembershipClaims        *string                        `json:"groupMembershipClaims"`
 		*application
 	}{
 		application: (*application)(a),
@@ -321,6 +366,7 @@ func (a *Application) UnmarshalJSON(data []byte) error {
 		}
 		a.GroupMembershipClaims = &groupMembershipClaims
 	}
+	a.FederatedIdentityCredentials = app.FederatedIdentityCredentials
 	return nil
 }
 
@@ -580,6 +626,11 @@ type ApprovalStep struct {
 	Status           ApprovalStepStatus `json:"status,omitempty"`
 }
 
+type AssignedLicense struct {
+	DisabledPlans *[]string `json:"disabledPlans,omitempty"`
+	SkuId         *string   `json:"skuId,omitempty"`
+}
+
 type AssignmentReviewSettings struct {
 	IsEnabled                       *bool                           `json:"isEnabled,omitempty"`
 	RecurrenceType                  AccessReviewRecurrenceType      `json:"recurrenceType,omitempty"`
@@ -784,6 +835,60 @@ func (c *ConditionalAccessGuestsOrExternalUsers) UnmarshalJSON(data []byte) erro
 	return nil
 }
 
+// ContainmentReport describes the outcome of each step taken to contain a compromised User.
+type Connector struct {
+	ID          *string          `json:"id,omitempty"`
+	ExternalIp  *string          `json:"externalIp,omitempty"`
+	MachineName *string          `json:"machineName,omitempty"`
+	Status      *ConnectorStatus `json:"status,omitempty"`
+}
+
+type ConnectorGroup struct {
+	ID                 *string               `json:"id,omitempty"`
+	ConnectorGroupType *ConnectorGroupType   `json:"connectorGroupType,omitempty"`
+	IsDefault          *bool                 `json:"isDefault,omitempty"`
+	Name               *string               `json:"name,omitempty"`
+	Region             *ConnectorGroupRegion `json:"region,omitempty"`
+}
+
+type ContainmentReport struct {
+	UserId string
+	Steps  []ContainmentStep
+}
+
+// Failed returns the steps which could not be completed.
+func (r ContainmentReport) Failed() []ContainmentStep {
+	failed := make([]ContainmentStep, 0)
+	for _, step := range r.Steps {
+		if step.Status == ContainmentStepStatusFailed {
+			failed = append(failed, step)
+		}
+	}
+	return failed
+}
+
+// Skipped returns the steps which were not attempted, such as the deletion of authentication methods of types which
+// cannot be deleted by UsersClient.Contain. These methods remain registered for the user.
+func (r ContainmentReport) Skipped() []ContainmentStep {
+	skipped := make([]ContainmentStep, 0)
+	for _, step := range r.Steps {
+		if step.Status == ContainmentStepStatusSkipped {
+			skipped = append(skipped, step)
+		}
+	}
+	return skipped
+}
+
+// ContainmentStep describes a single step taken to contain a compromised User. For steps that remove an authentication
+// method, Target is the ID of the method and MethodType is its OData type.
+type ContainmentStep struct {
+	Action     ContainmentAction
+	Target     string
+	MethodType odata.Type
+	Status     ContainmentStepStatus
+	Err        error
+}
+
 type ConnectionInfo struct {
 	Url *string `json:"url,omitempty"`
 }
@@ -893,6 +998,30 @@ func (d *DelegatedPermissionGrant) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+type Device struct {
+	DirectoryObject
+	AccountEnabled                *bool                          `json:"accountEnabled,omitempty"`
+	ApproximateLastSignInDateTime *time.Time                     `json:"approximateLastSignInDateTime,omitempty"`
+	DeviceCategory                *string                        `json:"deviceCategory,omitempty"`
+	DeviceId                      *string                        `json:"deviceId,omitempty"`
+	DeviceOwnership               *string                        `json:"deviceOwnership,omitempty"`
+	EnrollmentProfileName         *string                        `json:"enrollmentProfileName,omitempty"`
+	ExtensionAttributes           *OnPremisesExtensionAttributes `json:"extensionAttributes,omitempty"`
+	IsCompliant                   *bool                          `json:"isCompliant,omitempty"`
+	IsManaged                     *bool                          `json:"isManaged,omitempty"`
+	IsRooted                      *bool                          `json:"isRooted,omitempty"`
+	ManagementType                *string                        `json:"managementType,omitempty"`
+	Manufacturer                  *string                        `json:"manufacturer,omitempty"`
+	Model                         *string                        `json:"model,omitempty"`
+	OperatingSystem               *string                        `json:"operatingSystem,omitempty"`
+	OperatingSystemVersion        *string                        `json:"operatingSystemVersion,omitempty"`
+	PhysicalIds                   *[]string                      `json:"physicalIds,omitempty"`
+	ProfileType                   *string                        `json:"profileType,omitempty"`
+	RegistrationDateTime          *time.Time                     `json:"registrationDateTime,omitempty"`
+	SystemLabels                  *[]string                      `json:"systemLabels,omitempty"`
+	TrustType                     *string                        `json:"trustType,omitempty"`
+}
+
 type DeviceAndAppManagementAssignmentTarget struct {
 	DeviceAndAppManagementAssignmentFilterId   *string                                     `json:"deviceAndAppManagementAssignmentFilterId,omitempty"`
 	DeviceAndAppManagementAssignmentFilterType *DeviceAndAppManagementAssignmentFilterType `json:"deviceAndAppManagementAssignmentFilterType,omitempty"`
@@ -1024,6 +1153,19 @@ type EntitlementManagementSchedule struct {
 	Recurrence    *RecurrencePattern `json:"recurrence,omitempty"`
 }
 
+type EvaluateDynamicMembershipResult struct {
+	MembershipRule                  *string                      `json:"membershipRule,omitempty"`
+	MembershipRuleEvaluationResult  *bool                        `json:"membershipRuleEvaluationResult,omitempty"`
+	MembershipRuleEvaluationDetails *ExpressionEvaluationDetails `json:"membershipRuleEvaluationDetails,omitempty"`
+}
+
+type ExpressionEvaluationDetails struct {
+	Expression                  *string                        `json:"expression,omitempty"`
+	ExpressionResult            *bool                          `json:"expressionResult,omitempty"`
+	ExpressionEvaluationDetails *[]ExpressionEvaluationDetails `json:"expressionEvaluationDetails,omitempty"`
+	PropertyToEvaluate          *PropertyToEvaluate            `json:"propertyToEvaluate,omitempty"`
+}
+
 type ExtensionSchemaProperty struct {
 	Name *string                         `json:"name,omitempty"`
 	Type ExtensionSchemaPropertyDataType `json:"type,omitempty"`
@@ -1036,12 +1178,18 @@ type ExpirationPattern struct {
 }
 
 type FederatedIdentityCredential struct {
-	Audiences   *[]string            `json:"audiences,omitempty"`
-	Description *StringNullWhenEmpty `json:"description,omitempty"`
-	ID          *string              `json:"id,omitempty"`
-	Issuer      *string              `json:"issuer,omitempty"`
-	Name        *string              `json:"name,omitempty"`
-	Subject     *string              `json:"subject,omitempty"`
+	Audiences                *[]string                    `json:"audiences,omitempty"`
+	ClaimsMatchingExpression *FederatedIdentityExpression `json:"claimsMatchingExpression,omitempty" api:"beta"`
+	Description              *StringNullWhenEmpty         `json:"description,omitempty"`
+	ID                       *string                      `json:"id,omitempty"`
+	Issuer                   *string                      `json:"issuer,omitempty"`
+	Name                     *string                      `json:"name,omitempty"`
+	Subject                  *string                      `json:"subject,omitempty"`
+}
+
+type FederatedIdentityExpression struct {
+	LanguageVersion *int    `json:"languageVersion,omitempty"`
+	Value           *string `json:"value,omitempty"`
 }
 
 type Fido2AuthenticationMethod struct {
@@ -1069,7 +1217,7 @@ type Group struct {
 
 	AllowExternalSenders          *bool                               `json:"allowExternalSenders,omitempty"`
 	AssignedLabels                *[]GroupAssignedLabel               `json:"assignedLabels,omitempty"`
-	AssignedLicenses              *[]GroupAssignedLicense             `json:"assignLicenses,omitempty"`
+	AssignedLicenses              *[]GroupAssignedLicense             `json:"assignedLicenses,omitempty"`
 	AutoSubscribeNewMembers       *bool                               `json:"autoSubscribeNewMembers,omitempty"`
 	Classification                *string                             `json:"classification,omitempty"`
 	CreatedDateTime               *time.Time                          `json:"createdDateTime,omitempty"`
@@ -1105,9 +1253,10 @@ type Group struct {
 	SecurityEnabled               *bool                               `json:"securityEnabled,omitempty"`
 	SecurityIdentifier            *string                             `json:"securityIdentifier,omitempty"`
 	Theme                         *GroupTheme                         `json:"theme,omitempty"`
+	UniqueName                    *string                             `json:"uniqueName,omitempty"`
 	UnseenCount                   *int                                `json:"unseenCount,omitempty"`
 	Visibility                    *GroupVisibility                    `json:"visibility,omitempty"`
-	WritebackConfiguration        *GroupWritebackConfiguration        `json:"writebackConfiguration,omitempty"`
+	WritebackConfiguration        *GroupWritebackConfiguration        `json:"writebackConfiguration,omitempty" api:"beta"`
 }
 
 func (g Group) MarshalJSON() ([]byte, error) {
@@ -1176,9 +1325,13 @@ type GroupAssignedLabel struct {
 	DisplayName *string `json:"displayName,omitempty"`
 }
 
-type GroupAssignedLicense struct {
-	DisabledPlans *[]string `json:"disabledPlans,omitempty"`
-	SkuId         *string   `json:"skuId,omitempty"`
+type GroupAssignedLicense = AssignedLicense
+
+type GroupLifecyclePolicy struct {
+	ID                          *string                                `json:"id,omitempty"`
+	AlternateNotificationEmails *string                                `json:"alternateNotificationEmails,omitempty"`
+	GroupLifetimeInDays         *int32                                 `json:"groupLifetimeInDays,omitempty"`
+	ManagedGroupTypes           *GroupLifecyclePolicyManagedGroupTypes `json:"managedGroupTypes,omitempty"`
 }
 
 type GroupOnPremisesProvisioningError struct {
@@ -1188,11 +1341,33 @@ type GroupOnPremisesProvisioningError struct {
 	Value                *string   `json:"value,omitempty"`
 }
 
+type GroupSetting struct {
+	ID          *string         `json:"id,omitempty"`
+	DisplayName *string         `json:"displayName,omitempty"`
+	TemplateId  *string         `json:"templateId,omitempty"`
+	Values      *[]SettingValue `json:"values,omitempty"`
+}
+
+type GroupSettingTemplate struct {
+	ID          *string                 `json:"id,omitempty"`
+	Description *string                 `json:"description,omitempty"`
+	DisplayName *string                 `json:"displayName,omitempty"`
+	Values      *[]SettingTemplateValue `json:"values,omitempty"`
+}
+
 type GroupWritebackConfiguration struct {
 	IsEnabled           *bool                `json:"isEnabled"`
 	OnPremisesGroupType *OnPremisesGroupType `json:"onPremisesGroupType"`
 }
 
+type HomeRealmDiscoveryPolicy struct {
+	DirectoryObject
+	Definition            *[]string `json:"definition,omitempty"`
+	Description           *string   `json:"description,omitempty"`
+	DisplayName           *string   `json:"displayName,omitempty"`
+	IsOrganizationDefault *bool     `json:"isOrganizationDefault,omitempty"`
+}
+
 type Identity struct {
 	DisplayName *string `json:"displayName,omitempty"`
 	Id          *string `json:"id,omitempty"`
@@ -1290,6 +1465,20 @@ type KeyValue struct {
 	Value *string `json:"value,omitempty"`
 }
 
+type LicenseDetails struct {
+	ID            *string            `json:"id,omitempty"`
+	ServicePlans  *[]ServicePlanInfo `json:"servicePlans,omitempty"`
+	SkuId         *string            `json:"skuId,omitempty"`
+	SkuPartNumber *string            `json:"skuPartNumber,omitempty"`
+}
+
+type LicenseUnitsDetail struct {
+	Enabled   *int32 `json:"enabled,omitempty"`
+	LockedOut *int32 `json:"lockedOut,omitempty"`
+	Suspended *int32 `json:"suspended,omitempty"`
+	Warning   *int32 `json:"warning,omitempty"`
+}
+
 type Location struct {
 	City            *string         `json:"city,omitempty"`
 	CountryOrRegion *string         `json:"countryOrRegion,omitempty"`
@@ -1297,6 +1486,15 @@ type Location struct {
 	State           *string         `json:"state,omitempty"`
 }
 
+type LongRunningOperation struct {
+	ID                 *string                     `json:"id,omitempty"`
+	CreatedDateTime    *time.Time                  `json:"createdDateTime,omitempty"`
+	LastActionDateTime *time.Time                  `json:"lastActionDateTime,omitempty"`
+	ResourceLocation   *string                     `json:"resourceLocation,omitempty"`
+	Status             *LongRunningOperationStatus `json:"status,omitempty"`
+	StatusDetail       *string                     `json:"statusDetail,omitempty"`
+}
+
 type MailMessage struct {
 	Message *Message `json:"message,omitempty"`
 }
@@ -1308,6 +1506,27 @@ type Me struct {
 	UserPrincipalName *string `json:"userPrincipalName"`
 }
 
+// MemberResult reports the outcome of adding a single member.
+type MemberResult struct {
+	Member DirectoryObject
+	Status MemberResultStatus
+	Err    error
+}
+
+// MembershipChange describes a single addition or removal made when reconciling a relationship.
+type MembershipChange struct {
+	Id     string
+	Action MembershipChangeAction
+	Status MembershipChangeStatus
+	Err    error
+}
+
+// MembershipChangeReport describes the changes made when reconciling a relationship with a desired state.
+type MembershipChangeReport struct {
+	Unchanged []string
+	Changes   []MembershipChange
+}
+
 type Message struct {
 	ID            *string      `json:"id,omitempty"`
 	Subject       *string      `json:"subject,omitempty"`
@@ -1334,6 +1553,24 @@ type ModifiedProperty struct {
 
 type NamedLocation interface{}
 
+type OnPremisesExtensionAttributes struct {
+	ExtensionAttribute1  *string `json:"extensionAttribute1,omitempty"`
+	ExtensionAttribute2  *string `json:"extensionAttribute2,omitempty"`
+	ExtensionAttribute3  *string `json:"extensionAttribute3,omitempty"`
+	ExtensionAttribute4  *string `json:"extensionAttribute4,omitempty"`
+	ExtensionAttribute5  *string `json:"extensionAttribute5,omitempty"`
+	ExtensionAttribute6  *string `json:"extensionAttribute6,omitempty"`
+	ExtensionAttribute7  *string `json:"extensionAttribute7,omitempty"`
+	ExtensionAttribute8  *string `json:"extensionAttribute8,omitempty"`
+	ExtensionAttribute9  *string `json:"extensionAttribute9,omitempty"`
+	ExtensionAttribute10 *string `json:"extensionAttribute10,omitempty"`
+	ExtensionAttribute11 *string `json:"extensionAttribute11,omitempty"`
+	ExtensionAttribute12 *string `json:"extensionAttribute12,omitempty"`
+	ExtensionAttribute13 *string `json:"extensionAttribute13,omitempty"`
+	ExtensionAttribute14 *string `json:"extensionAttribute14,omitempty"`
+	ExtensionAttribute15 *string `json:"extensionAttribute15,omitempty"`
+}
+
 type OnPremisesPublishing struct {
 	AlternateUrl                  *string `json:"alternateUrl,omitempty"`
 	ApplicationServerTimeout      *string `json:"applicationServerTimeout,omitempty"`
@@ -1411,6 +1648,13 @@ type PasswordAuthenticationMethod struct {
 	Password         *string    `json:"password,omitempty"`
 }
 
+// PasswordResetResponse is returned when resetting a user's password. NewPassword is only populated when the password
+// was generated by the service, and Operation describes the completed long-running reset operation.
+type PasswordResetResponse struct {
+	NewPassword *string               `json:"newPassword,omitempty"`
+	Operation   *LongRunningOperation `json:"-"`
+}
+
 type PasswordSingleSignOnSettings struct {
 	Fields *[]SingleSignOnField `json:"fields,omitempty"`
 }
@@ -1437,6 +1681,18 @@ type PhoneAuthenticationMethod struct {
 	PhoneType   *AuthenticationPhoneType `json:"phoneType,omitempty"`
 }
 
+type ProfilePhoto struct {
+	ID               *string `json:"id,omitempty"`
+	Height           *int32  `json:"height,omitempty"`
+	MediaContentType *string `json:"@odata.mediaContentType,omitempty"`
+	Width            *int32  `json:"width,omitempty"`
+}
+
+type PropertyToEvaluate struct {
+	PropertyName  *string `json:"propertyName,omitempty"`
+	PropertyValue *string `json:"propertyValue,omitempty"`
+}
+
 type PrivilegedAccessGroupAssignmentSchedule struct {
 	ID               *string                               `json:"id,omitempty"`
 	AccessId         PrivilegedAccessGroupRelationship     `json:"accessId,omitempty"`
@@ -1609,7 +1865,7 @@ type ServicePrincipal struct {
 	AppDisplayName                      *string                       `json:"appDisplayName,omitempty"`
 	AppId                               *string                       `json:"appId,omitempty"`
 	ApplicationTemplateId               *string                       `json:"applicationTemplateId,omitempty"`
-	AppMetadata                         *ServicePrincipalAppMetadata  `json:"appMetadata,omitempty"`
+	AppMetadata                         *ServicePrincipalAppMetadata  `json:"appMetadata,omitempty" api:"beta"`
 	AppOwnerOrganizationId              *string                       `json:"appOwnerOrganizationId,omitempty"`
 	AppRoleAssignmentRequired           *bool                         `json:"appRoleAssignmentRequired,omitempty"`
 	AppRoles                            *[]AppRole                    `json:"appRoles,omitempty"`
@@ -1625,11 +1881,11 @@ type ServicePrincipal struct {
 	NotificationEmailAddresses          *[]string                     `json:"notificationEmailAddresses,omitempty"`
 	OAuth2PermissionScopes              *[]PermissionScope            `json:"oauth2PermissionScopes,omitempty"`
 	PasswordCredentials                 *[]PasswordCredential         `json:"passwordCredentials,omitempty"`
-	PasswordSingleSignOnSettings        *PasswordSingleSignOnSettings `json:"passwordSingleSignOnSettings,omitempty"`
+	PasswordSingleSignOnSettings        *PasswordSingleSignOnSettings `json:"passwordSingleSignOnSettings,omitempty" api:"beta"`
 	PreferredSingleSignOnMode           *PreferredSingleSignOnMode    `json:"preferredSingleSignOnMode,omitempty"`
 	PreferredTokenSigningKeyThumbprint  *StringNullWhenEmpty          `json:"preferredTokenSigningKeyThumbprint,omitempty"`
-	PreferredTokenSigningKeyEndDateTime *time.Time                    `json:"preferredTokenSigningKeyEndDateTime,omitempty"`
-	PublishedPermissionScopes           *[]PermissionScope            `json:"publishedPermissionScopes,omitempty"`
+	PreferredTokenSigningKeyEndDateTime *time.Time                    `json:"preferredTokenSigningKeyEndDateTime,omitempty" api:"beta"`
+	PublishedPermissionScopes           *[]PermissionScope            `json:"publishedPermissionScopes,omitempty" api:"beta"`
 	ReplyUrls                           *[]string                     `json:"replyUrls,omitempty"`
 	SamlMetadataUrl                     *StringNullWhenEmpty          `json:"samlMetadataUrl,omitempty"`
 	SamlSingleSignOnSettings            *SamlSingleSignOnSettings     `json:"samlSingleSignOnSettings,omitempty"`
@@ -1657,6 +1913,32 @@ type ServicePrincipalAppMetadata struct {
 	Data    *[]KeyValueObject `json:"data,omitempty"`
 }
 
+type SubscribedSku struct {
+	ID               *string                        `json:"id,omitempty"`
+	AccountId        *string                        `json:"accountId,omitempty"`
+	AccountName      *string                        `json:"accountName,omitempty"`
+	AppliesTo        *LicenseAppliesTo              `json:"appliesTo,omitempty"`
+	CapabilityStatus *SubscribedSkuCapabilityStatus `json:"capabilityStatus,omitempty"`
+	ConsumedUnits    *int32                         `json:"consumedUnits,omitempty"`
+	PrepaidUnits     *LicenseUnitsDetail            `json:"prepaidUnits,omitempty"`
+	ServicePlans     *[]ServicePlanInfo             `json:"servicePlans,omitempty"`
+	SkuId            *string                        `json:"skuId,omitempty"`
+	SkuPartNumber    *string                        `json:"skuPartNumber,omitempty"`
+	SubscriptionIds  *[]string                      `json:"subscriptionIds,omitempty"`
+}
+
+// AvailableUnits returns the number of enabled units which have not been consumed.
+func (s SubscribedSku) AvailableUnits() int32 {
+	var enabled, consumed int32
+	if s.PrepaidUnits != nil && s.PrepaidUnits.Enabled != nil {
+		enabled = *s.PrepaidUnits.Enabled
+	}
+	if s.ConsumedUnits != nil {
+		consumed = *s.ConsumedUnits
+	}
+	return enabled - consumed
+}
+
 type SynchronizationSchedule struct {
 	Expiration *time.Time `json:"expiration,omitempty"`
 	Interval   *string    `json:"interval,omitempty"`
@@ -1748,6 +2030,25 @@ type SynchronizationJob struct {
 	TemplateId                 *string                       `json:"templateId,omitempty"`
 }
 
+type ServicePlanInfo struct {
+	AppliesTo          *LicenseAppliesTo              `json:"appliesTo,omitempty"`
+	ProvisioningStatus *ServicePlanProvisioningStatus `json:"provisioningStatus,omitempty"`
+	ServicePlanId      *string                        `json:"servicePlanId,omitempty"`
+	ServicePlanName    *string                        `json:"servicePlanName,omitempty"`
+}
+
+type SettingTemplateValue struct {
+	DefaultValue *string `json:"defaultValue,omitempty"`
+	Description  *string `json:"description,omitempty"`
+	Name         *string `json:"name,omitempty"`
+	Type         *string `json:"type,omitempty"`
+}
+
+type SettingValue struct {
+	Name  *string `json:"name,omitempty"`
+	Value *string `json:"value,omitempty"`
+}
+
 type SignInActivity struct {
 	LastSignInDateTime                *time.Time `json:"lastSignInDateTime,omitempty"`
 	LastSignInRequestId               *string    `json:"lastSignInRequestId,omitempty"`
@@ -1866,6 +2167,14 @@ type TokenIssuancePolicy struct {
 	IsOrganizationDefault *bool     `json:"isOrganizationDefault,omitempty"`
 }
 
+type TokenLifetimePolicy struct {
+	DirectoryObject
+	Definition            *[]string `json:"definition,omitempty"`
+	Description           *string   `json:"description,omitempty"`
+	DisplayName           *string   `json:"displayName,omitempty"`
+	IsOrganizationDefault *bool     `json:"isOrganizationDefault,omitempty"`
+}
+
 type UnifiedRoleAssignment struct {
 	DirectoryObject
 
@@ -1972,67 +2281,69 @@ type UnifiedRolePermission struct {
 type User struct {
 	DirectoryObject
 
-	AboutMe                         *string                  `json:"aboutMe,omitempty"`
-	AccountEnabled                  *bool                    `json:"accountEnabled,omitempty"`
-	AgeGroup                        *AgeGroup                `json:"ageGroup,omitempty"`
-	BusinessPhones                  *[]string                `json:"businessPhones,omitempty"`
-	City                            *StringNullWhenEmpty     `json:"city,omitempty"`
-	CompanyName                     *StringNullWhenEmpty     `json:"companyName,omitempty"`
-	ConsentProvidedForMinor         *ConsentProvidedForMinor `json:"consentProvidedForMinor,omitempty"`
-	Country                         *StringNullWhenEmpty     `json:"country,omitempty"`
-	CreatedDateTime                 *time.Time               `json:"createdDateTime,omitempty"`
-	CreationType                    *string                  `json:"creationType,omitempty"`
-	DeletedDateTime                 *time.Time               `json:"deletedDateTime,omitempty"`
-	Department                      *StringNullWhenEmpty     `json:"department,omitempty"`
-	DisplayName                     *string                  `json:"displayName,omitempty"`
-	EmployeeHireDate                *time.Time               `json:"employeeHireDate,omitempty"`
-	EmployeeId                      *StringNullWhenEmpty     `json:"employeeId,omitempty"`
-	EmployeeOrgData                 *EmployeeOrgData         `json:"employeeOrgData,omitempty"`
-	EmployeeType                    *StringNullWhenEmpty     `json:"employeeType,omitempty"`
-	ExternalUserState               *string                  `json:"externalUserState,omitempty"`
-	FaxNumber                       *StringNullWhenEmpty     `json:"faxNumber,omitempty"`
-	GivenName                       *StringNullWhenEmpty     `json:"givenName,omitempty"`
-	ImAddresses                     *[]string                `json:"imAddresses,omitempty"`
-	Interests                       *[]string                `json:"interests,omitempty"`
-	IsManagementRestricted          *bool                    `json:"isManagementRestricted,omitempty"`
-	IsResourceAccount               *bool                    `json:"isResourceAccount,omitempty"`
-	JobTitle                        *StringNullWhenEmpty     `json:"jobTitle,omitempty"`
-	Mail                            *StringNullWhenEmpty     `json:"mail,omitempty"`
-	MailNickname                    *string                  `json:"mailNickname,omitempty"`
-	MemberOf                        *[]DirectoryObject       `json:"memberOf,omitempty"`
-	MobilePhone                     *StringNullWhenEmpty     `json:"mobilePhone,omitempty"`
-	MySite                          *string                  `json:"mySite,omitempty"`
-	OfficeLocation                  *StringNullWhenEmpty     `json:"officeLocation,omitempty"`
-	OnPremisesDistinguishedName     *string                  `json:"onPremisesDistinguishedName,omitempty"`
-	OnPremisesDomainName            *string                  `json:"onPremisesDomainName,omitempty"`
-	OnPremisesImmutableId           *string                  `json:"onPremisesImmutableId,omitempty"`
-	OnPremisesLastSyncDateTime      *string                  `json:"onPremisesLastSyncDateTime,omitempty"`
-	OnPremisesSamAccountName        *string                  `json:"onPremisesSamAccountName,omitempty"`
-	OnPremisesSecurityIdentifier    *string                  `json:"onPremisesSecurityIdentifier,omitempty"`
-	OnPremisesSyncEnabled           *bool                    `json:"onPremisesSyncEnabled,omitempty"`
-	OnPremisesUserPrincipalName     *string                  `json:"onPremisesUserPrincipalName,omitempty"`
-	OtherMails                      *[]string                `json:"otherMails,omitempty"`
-	PasswordPolicies                *StringNullWhenEmpty     `json:"passwordPolicies,omitempty"`
-	PasswordProfile                 *UserPasswordProfile     `json:"passwordProfile,omitempty"`
-	PastProjects                    *[]string                `json:"pastProjects,omitempty"`
-	PostalCode                      *StringNullWhenEmpty     `json:"postalCode,omitempty"`
-	PreferredDataLocation           *string                  `json:"preferredDataLocation,omitempty"`
-	PreferredLanguage               *StringNullWhenEmpty     `json:"preferredLanguage,omitempty"`
-	PreferredName                   *string                  `json:"preferredName,omitempty"`
-	ProxyAddresses                  *[]string                `json:"proxyAddresses,omitempty"`
-	RefreshTokensValidFromDateTime  *time.Time               `json:"refreshTokensValidFromDateTime,omitempty"`
-	Responsibilities                *[]string                `json:"responsibilities,omitempty"`
-	Schools                         *[]string                `json:"schools,omitempty"`
-	ShowInAddressList               *bool                    `json:"showInAddressList,omitempty"`
-	SignInActivity                  *SignInActivity          `json:"signInActivity,omitempty"`
-	SignInSessionsValidFromDateTime *time.Time               `json:"signInSessionsValidFromDateTime,omitempty"`
-	Skills                          *[]string                `json:"skills,omitempty"`
-	State                           *StringNullWhenEmpty     `json:"state,omitempty"`
-	StreetAddress                   *StringNullWhenEmpty     `json:"streetAddress,omitempty"`
-	Surname                         *StringNullWhenEmpty     `json:"surname,omitempty"`
-	UsageLocation                   *StringNullWhenEmpty     `json:"usageLocation,omitempty"`
-	UserPrincipalName               *string                  `json:"userPrincipalName,omitempty"`
-	UserType                        *string                  `json:"userType,omitempty"`
+	AboutMe                         *string                        `json:"aboutMe,omitempty"`
+	AccountEnabled                  *bool                          `json:"accountEnabled,omitempty"`
+	AgeGroup                        *AgeGroup                      `json:"ageGroup,omitempty"`
+	AssignedLicenses                *[]AssignedLicense             `json:"assignedLicenses,omitempty"`
+	BusinessPhones                  *[]string                      `json:"businessPhones,omitempty"`
+	City                            *StringNullWhenEmpty           `json:"city,omitempty"`
+	CompanyName                     *StringNullWhenEmpty           `json:"companyName,omitempty"`
+	ConsentProvidedForMinor         *ConsentProvidedForMinor       `json:"consentProvidedForMinor,omitempty"`
+	Country                         *StringNullWhenEmpty           `json:"country,omitempty"`
+	CreatedDateTime                 *time.Time                     `json:"createdDateTime,omitempty"`
+	CreationType                    *string                        `json:"creationType,omitempty"`
+	DeletedDateTime                 *time.Time                     `json:"deletedDateTime,omitempty"`
+	Department                      *StringNullWhenEmpty           `json:"department,omitempty"`
+	DisplayName                     *string                        `json:"displayName,omitempty"`
+	EmployeeHireDate                *time.Time                     `json:"employeeHireDate,omitempty"`
+	EmployeeId                      *StringNullWhenEmpty           `json:"employeeId,omitempty"`
+	EmployeeOrgData                 *EmployeeOrgData               `json:"employeeOrgData,omitempty"`
+	EmployeeType                    *StringNullWhenEmpty           `json:"employeeType,omitempty"`
+	ExternalUserState               *string                        `json:"externalUserState,omitempty"`
+	FaxNumber                       *StringNullWhenEmpty           `json:"faxNumber,omitempty"`
+	GivenName                       *StringNullWhenEmpty           `json:"givenName,omitempty"`
+	ImAddresses                     *[]string                      `json:"imAddresses,omitempty"`
+	Interests                       *[]string                      `json:"interests,omitempty"`
+	IsManagementRestricted          *bool                          `json:"isManagementRestricted,omitempty" api:"beta"`
+	IsResourceAccount               *bool                          `json:"isResourceAccount,omitempty"`
+	JobTitle                        *StringNullWhenEmpty           `json:"jobTitle,omitempty"`
+	Mail                            *StringNullWhenEmpty           `json:"mail,omitempty"`
+	MailNickname                    *string                        `json:"mailNickname,omitempty"`
+	MemberOf                        *[]DirectoryObject             `json:"memberOf,omitempty"`
+	MobilePhone                     *StringNullWhenEmpty           `json:"mobilePhone,omitempty"`
+	MySite                          *string                        `json:"mySite,omitempty"`
+	OfficeLocation                  *StringNullWhenEmpty           `json:"officeLocation,omitempty"`
+	OnPremisesDistinguishedName     *string                        `json:"onPremisesDistinguishedName,omitempty"`
+	OnPremisesDomainName            *string                        `json:"onPremisesDomainName,omitempty"`
+	OnPremisesExtensionAttributes   *OnPremisesExtensionAttributes `json:"onPremisesExtensionAttributes,omitempty"`
+	OnPremisesImmutableId           *string                        `json:"onPremisesImmutableId,omitempty"`
+	OnPremisesLastSyncDateTime      *string                        `json:"onPremisesLastSyncDateTime,omitempty"`
+	OnPremisesSamAccountName        *string                        `json:"onPremisesSamAccountName,omitempty"`
+	OnPremisesSecurityIdentifier    *string                        `json:"onPremisesSecurityIdentifier,omitempty"`
+	OnPremisesSyncEnabled           *bool                          `json:"onPremisesSyncEnabled,omitempty"`
+	OnPremisesUserPrincipalName     *string                        `json:"onPremisesUserPrincipalName,omitempty"`
+	OtherMails                      *[]string                      `json:"otherMails,omitempty"`
+	PasswordPolicies                *StringNullWhenEmpty           `json:"passwordPolicies,omitempty"`
+	PasswordProfile                 *UserPasswordProfile           `json:"passwordProfile,omitempty"`
+	PastProjects                    *[]string                      `json:"pastProjects,omitempty"`
+	PostalCode                      *StringNullWhenEmpty           `json:"postalCode,omitempty"`
+	PreferredDataLocation           *string                        `json:"preferredDataLocation,omitempty"`
+	PreferredLanguage               *StringNullWhenEmpty           `json:"preferredLanguage,omitempty"`
+	PreferredName                   *string                        `json:"preferredName,omitempty"`
+	ProxyAddresses                  *[]string                      `json:"proxyAddresses,omitempty"`
+	RefreshTokensValidFromDateTime  *time.Time                     `json:"refreshTokensValidFromDateTime,omitempty" api:"beta"`
+	Responsibilities                *[]string                      `json:"responsibilities,omitempty"`
+	Schools                         *[]string                      `json:"schools,omitempty"`
+	ShowInAddressList               *bool                          `json:"showInAddressList,omitempty"`
+	SignInActivity                  *SignInActivity                `json:"signInActivity,omitempty"`
+	SignInSessionsValidFromDateTime *time.Time                     `json:"signInSessionsValidFromDateTime,omitempty"`
+	Skills                          *[]string                      `json:"skills,omitempty"`
+	State                           *StringNullWhenEmpty           `json:"state,omitempty"`
+	StreetAddress                   *StringNullWhenEmpty           `json:"streetAddress,omitempty"`
+	Surname                         *StringNullWhenEmpty           `json:"surname,omitempty"`
+	UsageLocation                   *StringNullWhenEmpty           `json:"usageLocation,omitempty"`
+	UserPrincipalName               *string                        `json:"userPrincipalName,omitempty"`
+	UserType                        *string                        `json:"userType,omitempty"`
 
 	SchemaExtensions *[]SchemaExtensionData `json:"-"`
 }
@@ -17,9 +17,9 @@ type NamedLocationsClient struct {
 }
 
 // NewNamedLocationsClient returns a new NamedLocationsClient.
-func NewNamedLocationsClient() *NamedLocationsClient {
+func NewNamedLocationsClient(opts ...ClientOption) *NamedLocationsClient {
 	return &NamedLocationsClient{
-		BaseClient: NewClient(Version10),
+		BaseClient: NewClient(Version10, opts...),
 	}
 }
 
@@ -0,0 +1,350 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+)
+
+// orgChartSelect lists the properties retrieved for each person in an org chart.
+var orgChartSelect = []string{"id", "displayName", "userPrincipalName", "jobTitle", "department"}
+
+// OrgChartNode is a person in an OrgChart, along with the people who report to them.
+type OrgChartNode struct {
+	Id                string          `json:"id"`
+	DisplayName       string          `json:"displayName,omitempty"`
+	UserPrincipalName string          `json:"userPrincipalName,omitempty"`
+	JobTitle          string          `json:"jobTitle,omitempty"`
+	Department        string          `json:"department,omitempty"`
+	Type              odata.Type      `json:"type,omitempty"`
+	DirectReports     []*OrgChartNode `json:"directReports,omitempty"`
+}
+
+// OrgChart describes the reporting structure below a user, and optionally the chain of managers above them. Managers
+// are ordered from the nearest manager upwards. Cycles lists any reporting loops that were found, each as a sequence of
+// object IDs where every person reports to the next one.
+type OrgChart struct {
+	Root     *OrgChartNode  `json:"root"`
+	Managers []OrgChartNode `json:"managers,omitempty"`
+	Cycles   [][]string     `json:"cycles,omitempty"`
+	MaxDepth int            `json:"maxDepth"`
+}
+
+// OrgChartOptions configures the traversal when building an OrgChart.
+type OrgChartOptions struct {
+	// MaxDepth limits how many levels of direct reports are traversed. When zero, all levels are traversed.
+	MaxDepth int
+
+	// IncludeManagers retrieves the chain of managers above the root user.
+	IncludeManagers bool
+}
+
+// Find returns the node for the specified object ID, or nil if they are not below the root of the org chart.
+func (o OrgChart) Find(id string) *OrgChartNode {
+	queue := []*OrgChartNode{o.Root}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		if node == nil {
+			continue
+		}
+		if strings.EqualFold(node.Id, id) {
+			return node
+		}
+		queue = append(queue, node.DirectReports...)
+	}
+	return nil
+}
+
+// OrgChart builds the org chart below the specified user by walking their direct reports. When a person is reached more
+// than once due to a reporting loop, the loop is recorded in Cycles and traversal does not continue through them.
+func (c *UsersClient) OrgChart(ctx context.Context, id string, options OrgChartOptions) (*OrgChart, int, error) {
+	user, status, err := c.Get(ctx, id, odata.Query{Select: orgChartSelect})
+	if err != nil {
+		return nil, status, err
+	}
+
+	root := newOrgChartNode(*user)
+	chart := OrgChart{Root: &root}
+
+	parents := map[string]string{}
+	depths := map[string]int{root.Id: 0}
+	queue := []*OrgChartNode{&root}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+
+		if options.MaxDepth > 0 && depths[node.Id] >= options.MaxDepth {
+			continue
+		}
+
+		reports, status, err := c.ListDirectReports(ctx, node.Id, odata.Query{Select: orgChartSelect})
+		if err != nil {
+			return nil, status, err
+		}
+
+		for _, report := range *reports {
+			if report.ID() == nil {
+				continue
+			}
+			if _, seen := depths[*report.ID()]; seen {
+				if cycle := orgChartCycle(parents, node.Id, *report.ID()); cycle != nil {
+					chart.Cycles = append(chart.Cycles, cycle)
+				}
+				continue
+			}
+
+			child := newOrgChartNode(report)
+			parents[child.Id] = node.Id
+			depths[child.Id] = depths[node.Id] + 1
+			if depths[child.Id] > chart.MaxDepth {
+				chart.MaxDepth = depths[child.Id]
+			}
+			node.DirectReports = append(node.DirectReports, &child)
+			queue = append(queue, &child)
+		}
+	}
+
+	if options.IncludeManagers {
+		managers, cycle, status, err := c.managerChain(ctx, root.Id)
+		if err != nil {
+			return nil, status, err
+		}
+		for _, manager := range managers {
+			chart.Managers = append(chart.Managers, newOrgChartNode(manager))
+		}
+		if cycle != nil && !orgChartHasCycle(chart.Cycles, cycle) {
+			chart.Cycles = append(chart.Cycles, cycle)
+		}
+	}
+
+	return &chart, status, nil
+}
+
+// ManagerChain returns the managers of the specified user, starting with their manager and ending with the person at the
+// top of the reporting structure. When the chain contains a reporting loop, it ends before the first repeated person.
+func (c *UsersClient) ManagerChain(ctx context.Context, id string) (*[]User, int, error) {
+	managers, _, status, err := c.managerChain(ctx, id)
+	if err != nil {
+		return nil, status, err
+	}
+	return &managers, status, nil
+}
+
+// managerChain retrieves the chain of managers using $expand=manager($levels=max) where supported, and otherwise walks
+// the manager of each person in turn. Any reporting loop is returned as a cycle.
+func (c *UsersClient) managerChain(ctx context.Context, id string) ([]User, []string, int, error) {
+	managers, status, err := c.expandManagers(ctx, id)
+	if err != nil {
+		if status != http.StatusBadRequest {
+			return nil, nil, status, err
+		}
+
+		// expanding all levels is not supported in this environment, so walk the chain instead
+		managers = make([]User, 0)
+		current := id
+		for {
+			manager, status, err := c.getManager(ctx, current)
+			if err != nil {
+				return nil, nil, status, err
+			}
+			if manager == nil || manager.ID() == nil {
+				break
+			}
+			managers = append(managers, *manager)
+			if orgChartContains(id, managers[:len(managers)-1], *manager.ID()) {
+				break
+			}
+			current = *manager.ID()
+		}
+	}
+
+	// truncate the chain before the first repeated person and report the loop
+	seen := map[string]int{strings.ToLower(id): -1}
+	for i, manager := range managers {
+		if manager.ID() == nil {
+			return managers[:i], nil, status, nil
+		}
+		key := strings.ToLower(*manager.ID())
+		if start, ok := seen[key]; ok {
+			cycle := make([]string, 0)
+			if start == -1 {
+				cycle = append(cycle, id)
+				start = 0
+			}
+			for _, m := range managers[start:i] {
+				cycle = append(cycle, *m.ID())
+			}
+			return managers[:i], cycle, status, nil
+		}
+		seen[key] = i
+	}
+
+	return managers, nil, status, nil
+}
+
+// expandManagers retrieves the chain of managers for a user in a single request.
+func (c *UsersClient) expandManagers(ctx context.Context, id string) ([]User, int, error) {
+	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		OData: odata.Query{
+			ConsistencyLevel: odata.ConsistencyLevelEventual,
+			Count:            true,
+			Select:           []string{"id"},
+		},
+		ValidStatusCodes: []int{http.StatusOK},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/users/%s", id),
+			Params: url.Values{
+				"$expand": []string{fmt.Sprintf("manager($levels=max;$select=%s)", strings.Join(orgChartSelect, ","))},
+			},
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("UsersClient.BaseClient.Get(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var data struct {
+		Manager *json.RawMessage `json:"manager"`
+	}
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	managers := make([]User, 0)
+	for data.Manager != nil {
+		var manager User
+		if err := json.Unmarshal(*data.Manager, &manager); err != nil {
+			return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+		}
+		managers = append(managers, manager)
+
+		next := data.Manager
+		data.Manager = nil
+		if err := json.Unmarshal(*next, &data); err != nil {
+			return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+		}
+	}
+
+	return managers, status, nil
+}
+
+// getManager retrieves the manager of a user, returning nil when they have no manager.
+func (c *UsersClient) getManager(ctx context.Context, id string) (*User, int, error) {
+	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+		OData: odata.Query{
+			Select: orgChartSelect,
+		},
+		ValidStatusCodes: []int{
+			http.StatusOK,
+			http.StatusNotFound,
+		},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/users/%s/manager", id),
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("UsersClient.BaseClient.Get(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	if status == http.StatusNotFound {
+		return nil, status, nil
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var manager User
+	if err := json.Unmarshal(respBody, &manager); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &manager, status, nil
+}
+
+func newOrgChartNode(user User) OrgChartNode {
+	node := OrgChartNode{}
+	if user.ID() != nil {
+		node.Id = *user.ID()
+	}
+	if user.ODataType != nil {
+		node.Type = *user.ODataType
+	}
+	if user.DisplayName != nil {
+		node.DisplayName = *user.DisplayName
+	}
+	if user.UserPrincipalName != nil {
+		node.UserPrincipalName = *user.UserPrincipalName
+	}
+	if user.JobTitle != nil {
+		node.JobTitle = string(*user.JobTitle)
+	}
+	if user.Department != nil {
+		node.Department = string(*user.Department)
+	}
+	return node
+}
+
+// orgChartCycle returns the reporting loop formed when report is found below manager, or nil if report is not one of
+// manager's ancestors.
+func orgChartCycle(parents map[string]string, manager, report string) []string {
+	cycle := []string{report}
+	for id := manager; !strings.EqualFold(id, report); {
+		cycle = append(cycle, id)
+		parent, ok := parents[id]
+		if !ok {
+			return nil
+		}
+		id = parent
+	}
+	return cycle
+}
+
+func orgChartContains(root string, managers []User, id string) bool {
+	if strings.EqualFold(root, id) {
+		return true
+	}
+	for _, m := range managers {
+		if m.ID() != nil && strings.EqualFold(*m.ID(), id) {
+			return true
+		}
+	}
+	return false
+}
+
+// orgChartHasCycle determines whether a reporting loop has already been recorded, starting from any person in the loop.
+func orgChartHasCycle(cycles [][]string, cycle []string) bool {
+	for _, existing := range cycles {
+		if len(existing) != len(cycle) {
+			continue
+		}
+		for offset := range existing {
+			match := true
+			for i := range cycle {
+				if !strings.EqualFold(existing[(offset+i)%len(existing)], cycle[i]) {
+					match = false
+					break
+				}
+			}
+			if match {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,145 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func newOrgChartTestServer(t *testing.T, managers map[string]string, expand bool) *httptest.Server {
+	reports := map[string][]string{}
+	for user, manager := range managers {
+		reports[manager] = append(reports[manager], user)
+	}
+
+	user := func(id string) map[string]interface{} {
+		return map[string]interface{}{"id": id, "displayName": strings.ToUpper(id), "jobTitle": "Engineer"}
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/beta/users/"), "/")
+		id := parts[0]
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case len(parts) == 1 && r.URL.Query().Get("$expand") != "":
+			if !expand {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error":{"code":"Request_UnsupportedQuery","message":"Unsupported query."}}`))
+				return
+			}
+			// nest the chain of managers, stopping after the first repeated person as the service does
+			root := user(id)
+			current := root
+			seen := map[string]bool{id: true}
+			for m, ok := managers[id]; ok; m, ok = managers[m] {
+				next := user(m)
+				current["manager"] = next
+				current = next
+				if seen[m] {
+					break
+				}
+				seen[m] = true
+			}
+			json.NewEncoder(w).Encode(root)
+
+		case len(parts) == 1:
+			json.NewEncoder(w).Encode(user(id))
+
+		case parts[1] == "directReports":
+			value := make([]map[string]interface{}, 0)
+			for _, r := range reports[id] {
+				value = append(value, user(r))
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"value": value})
+
+		case parts[1] == "manager":
+			manager, ok := managers[id]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"error":{"code":"Request_ResourceNotFound","message":"Resource 'manager' does not exist."}}`))
+				return
+			}
+			json.NewEncoder(w).Encode(user(manager))
+
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestUsersClient_OrgChart(t *testing.T) {
+	// a reports to b, b reports to ceo; c and d report to a; e reports to d
+	managers := map[string]string{"a": "b", "b": "ceo", "c": "a", "d": "a", "e": "d"}
+
+	for _, expand := range []bool{true, false} {
+		ts := newOrgChartTestServer(t, managers, expand)
+		c := NewUsersClient(WithEndpoint(ts.URL), WithRetryMax(0))
+
+		chart, _, err := c.OrgChart(context.Background(), "a", OrgChartOptions{IncludeManagers: true})
+		ts.Close()
+		if err != nil {
+			t.Fatalf("OrgChart(): %v", err)
+		}
+
+		if chart.Root.DisplayName != "A" || chart.Root.JobTitle != "Engineer" {
+			t.Errorf("unexpected root: %+v", chart.Root)
+		}
+		if chart.MaxDepth != 2 {
+			t.Errorf("got max depth %d, want 2", chart.MaxDepth)
+		}
+		if node := chart.Find("e"); node == nil {
+			t.Errorf("expected e to be in the org chart")
+		}
+		if node := chart.Find("b"); node != nil {
+			t.Errorf("expected b not to be below the root")
+		}
+
+		ids := make([]string, 0)
+		for _, m := range chart.Managers {
+			ids = append(ids, m.Id)
+		}
+		if !reflect.DeepEqual(ids, []string{"b", "ceo"}) {
+			t.Errorf("expand %t: got managers %v, want [b ceo]", expand, ids)
+		}
+		if len(chart.Cycles) > 0 {
+			t.Errorf("unexpected cycles: %v", chart.Cycles)
+		}
+
+		if _, err := json.Marshal(chart); err != nil {
+			t.Errorf("json.Marshal(): %v", err)
+		}
+	}
+}
+
+func TestUsersClient_OrgChartCycle(t *testing.T) {
+	// a reports to b, b reports to c, and c reports to a
+	managers := map[string]string{"a": "b", "b": "c", "c": "a"}
+
+	for _, expand := range []bool{true, false} {
+		ts := newOrgChartTestServer(t, managers, expand)
+		c := NewUsersClient(WithEndpoint(ts.URL), WithRetryMax(0))
+
+		chart, _, err := c.OrgChart(context.Background(), "a", OrgChartOptions{IncludeManagers: true})
+		if err != nil {
+			t.Fatalf("OrgChart(): %v", err)
+		}
+		if len(chart.Cycles) != 1 || len(chart.Cycles[0]) != 3 {
+			t.Errorf("expand %t: expected a single cycle of three people, got %v", expand, chart.Cycles)
+		}
+
+		chain, _, err := c.ManagerChain(context.Background(), "a")
+		ts.Close()
+		if err != nil {
+			t.Fatalf("ManagerChain(): %v", err)
+		}
+		if len(*chain) != 2 {
+			t.Errorf("expand %t: got %d managers, want 2", expand, len(*chain))
+		}
+	}
+}
@@ -0,0 +1,340 @@
+package msgraph
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+)
+
+// MicrosoftGraphAppId is the application ID of the Microsoft Graph resource, which publishes most permissions
+// requested by applications.
+const MicrosoftGraphAppId = "00000003-0000-0000-c000-000000000000"
+
+// permissionResourceSelect returns the service principal properties needed to resolve permissions. Permission scopes
+// are published as oauth2PermissionScopes in API version v1.0, and as publishedPermissionScopes in beta.
+func (r *PermissionResolver) permissionResourceSelect() []string {
+	scopes := "publishedPermissionScopes"
+	if r.client.BaseClient.ApiVersion == Version10 {
+		scopes = "oauth2PermissionScopes"
+	}
+	return []string{"id", "appId", "displayName", "appRoles", scopes}
+}
+
+// Permission is an app role or delegated permission scope published by a resource service principal.
+type Permission struct {
+	ResourceAppId       string             `json:"resourceAppId"`
+	ResourceId          string             `json:"resourceId"`
+	ResourceDisplayName string             `json:"resourceDisplayName,omitempty"`
+	Id                  string             `json:"id"`
+	Value               string             `json:"value"`
+	Type                ResourceAccessType `json:"type"`
+	DisplayName         string             `json:"displayName,omitempty"`
+	IsEnabled           bool               `json:"isEnabled"`
+}
+
+// String returns the permission value qualified by the resource name, e.g. "Microsoft Graph/User.Read.All (Role)".
+func (p Permission) String() string {
+	resource := p.ResourceDisplayName
+	if resource == "" {
+		resource = p.ResourceAppId
+	}
+	return fmt.Sprintf("%s/%s (%s)", resource, p.Value, p.Type)
+}
+
+// PermissionNames declares the permissions required from a resource by name, for use with
+// PermissionResolver.RequiredResourceAccess.
+type PermissionNames struct {
+	// ResourceAppId is the application ID of the resource, e.g. MicrosoftGraphAppId.
+	ResourceAppId string
+
+	// Scopes are the values of delegated permissions, e.g. "User.Read".
+	Scopes []string
+
+	// Roles are the values of application permissions, e.g. "User.Read.All".
+	Roles []string
+}
+
+// permissionResource holds the permissions published by a single resource service principal.
+type permissionResource struct {
+	appId       string
+	id          string
+	displayName string
+	byId        map[string]Permission
+	byValue     map[string]Permission
+}
+
+// PermissionResolver translates between the IDs and values of the app roles and permission scopes published by
+// resource service principals. Each resource is retrieved once using the ServicePrincipalsClient and then cached, and
+// a PermissionResolver is safe for concurrent use.
+type PermissionResolver struct {
+	client *ServicePrincipalsClient
+
+	mu        sync.Mutex
+	resources map[string]*permissionResource
+	appIds    map[string]string
+}
+
+// NewPermissionResolver returns a PermissionResolver that retrieves resource service principals using client.
+func NewPermissionResolver(client *ServicePrincipalsClient) *PermissionResolver {
+	return &PermissionResolver{
+		client:    client,
+		resources: make(map[string]*permissionResource),
+		appIds:    make(map[string]string),
+	}
+}
+
+// ResolveId returns the permission with the specified ID published by the resource with the specified application ID.
+func (r *PermissionResolver) ResolveId(ctx context.Context, resourceAppId string, permissionType ResourceAccessType, id string) (*Permission, error) {
+	resource, err := r.resource(ctx, resourceAppId)
+	if err != nil {
+		return nil, err
+	}
+	permission, ok := resource.byId[permissionKey(permissionType, id)]
+	if !ok {
+		return nil, fmt.Errorf("%s with ID %q is not published by resource %q", strings.ToLower(permissionType), id, resource.displayName)
+	}
+	return &permission, nil
+}
+
+// ResolveName returns the permission with the specified value, e.g. "User.Read.All", published by the resource with
+// the specified application ID. Values are matched case-insensitively.
+func (r *PermissionResolver) ResolveName(ctx context.Context, resourceAppId string, permissionType ResourceAccessType, value string) (*Permission, error) {
+	resource, err := r.resource(ctx, resourceAppId)
+	if err != nil {
+		return nil, err
+	}
+	permission, ok := resource.byValue[permissionKey(permissionType, value)]
+	if !ok {
+		return nil, fmt.Errorf("%s %q is not published by resource %q", strings.ToLower(permissionType), value, resource.displayName)
+	}
+	return &permission, nil
+}
+
+// ResolveRequiredResourceAccess returns the permissions declared in the RequiredResourceAccess of an Application.
+func (r *PermissionResolver) ResolveRequiredResourceAccess(ctx context.Context, requiredResourceAccess []RequiredResourceAccess) ([]Permission, error) {
+	permissions := make([]Permission, 0)
+	for _, rra := range requiredResourceAccess {
+		if rra.ResourceAppId == nil || rra.ResourceAccess == nil {
+			continue
+		}
+		for _, access := range *rra.ResourceAccess {
+			if access.ID == nil {
+				continue
+			}
+			permission, err := r.ResolveId(ctx, *rra.ResourceAppId, access.Type, *access.ID)
+			if err != nil {
+				return nil, err
+			}
+			permissions = append(permissions, *permission)
+		}
+	}
+	return permissions, nil
+}
+
+// ResolveAppRoleAssignment returns the app role granted by an AppRoleAssignment, whose ResourceId is the object ID of
+// the resource service principal.
+func (r *PermissionResolver) ResolveAppRoleAssignment(ctx context.Context, assignment AppRoleAssignment) (*Permission, error) {
+	if assignment.ResourceId == nil || assignment.AppRoleId == nil {
+		return nil, fmt.Errorf("app role assignment has no resourceId or appRoleId")
+	}
+	resource, err := r.resourceByObjectId(ctx, *assignment.ResourceId)
+	if err != nil {
+		return nil, err
+	}
+	return r.ResolveId(ctx, resource.appId, ResourceAccessTypeRole, *assignment.AppRoleId)
+}
+
+// RequiredResourceAccess builds the RequiredResourceAccess for an Application from permissions declared by name, so
+// that required access can be written as e.g. "User.Read.All" rather than as a GUID. Resources are listed in the order
+// they are specified, and permissions for the same resource are combined.
+func (r *PermissionResolver) RequiredResourceAccess(ctx context.Context, permissions ...PermissionNames) (*[]RequiredResourceAccess, error) {
+	result := make([]RequiredResourceAccess, 0)
+	indexes := make(map[string]int)
+	seen := make(map[string]bool)
+
+	for _, p := range permissions {
+		resource, err := r.resource(ctx, p.ResourceAppId)
+		if err != nil {
+			return nil, err
+		}
+
+		i, ok := indexes[resource.appId]
+		if !ok {
+			appId := resource.appId
+			result = append(result, RequiredResourceAccess{
+				ResourceAppId:  &appId,
+				ResourceAccess: &[]ResourceAccess{},
+			})
+			i = len(result) - 1
+			indexes[resource.appId] = i
+		}
+
+		for _, declared := range []struct {
+			permissionType ResourceAccessType
+			values         []string
+		}{{ResourceAccessTypeScope, p.Scopes}, {ResourceAccessTypeRole, p.Roles}} {
+			for _, value := range declared.values {
+				permission, err := r.ResolveName(ctx, resource.appId, declared.permissionType, value)
+				if err != nil {
+					return nil, err
+				}
+				key := resource.appId + "/" + permissionKey(permission.Type, permission.Id)
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				id := permission.Id
+				*result[i].ResourceAccess = append(*result[i].ResourceAccess, ResourceAccess{ID: &id, Type: permission.Type})
+			}
+		}
+	}
+
+	return &result, nil
+}
+
+// Permissions returns all permissions published by the resource with the specified application ID, sorted by type
+// and value.
+func (r *PermissionResolver) Permissions(ctx context.Context, resourceAppId string) ([]Permission, error) {
+	resource, err := r.resource(ctx, resourceAppId)
+	if err != nil {
+		return nil, err
+	}
+	permissions := make([]Permission, 0, len(resource.byId))
+	for _, permission := range resource.byId {
+		permissions = append(permissions, permission)
+	}
+	sort.Slice(permissions, func(i, j int) bool {
+		if permissions[i].Type != permissions[j].Type {
+			return permissions[i].Type < permissions[j].Type
+		}
+		return permissions[i].Value < permissions[j].Value
+	})
+	return permissions, nil
+}
+
+// resource returns the cached permissions for the resource with the specified application ID, retrieving them first
+// when necessary.
+func (r *PermissionResolver) resource(ctx context.Context, appId string) (*permissionResource, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if resource, ok := r.resources[strings.ToLower(appId)]; ok {
+		return resource, nil
+	}
+
+	servicePrincipals, _, err := r.client.List(ctx, odata.Query{
+		Filter: fmt.Sprintf("appId eq '%s'", appId),
+		Select: r.permissionResourceSelect(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("retrieving service principal for resource %q: %v", appId, err)
+	}
+	if servicePrincipals == nil || len(*servicePrincipals) == 0 {
+		return nil, fmt.Errorf("no service principal found for resource %q", appId)
+	}
+
+	return r.cache((*servicePrincipals)[0]), nil
+}
+
+// resourceByObjectId returns the cached permissions for the resource service principal with the specified object ID,
+// retrieving them first when necessary.
+func (r *PermissionResolver) resourceByObjectId(ctx context.Context, id string) (*permissionResource, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if appId, ok := r.appIds[strings.ToLower(id)]; ok {
+		return r.resources[appId], nil
+	}
+
+	servicePrincipal, _, err := r.client.Get(ctx, id, odata.Query{Select: r.permissionResourceSelect()})
+	if err != nil {
+		return nil, fmt.Errorf("retrieving resource service principal %q: %v", id, err)
+	}
+
+	return r.cache(*servicePrincipal), nil
+}
+
+// cache indexes the permissions published by a service principal. The caller must hold r.mu.
+func (r *PermissionResolver) cache(servicePrincipal ServicePrincipal) *permissionResource {
+	resource := &permissionResource{
+		byId:    make(map[string]Permission),
+		byValue: make(map[string]Permission),
+	}
+	if servicePrincipal.AppId != nil {
+		resource.appId = *servicePrincipal.AppId
+	}
+	if servicePrincipal.ID() != nil {
+		resource.id = *servicePrincipal.ID()
+	}
+	if servicePrincipal.DisplayName != nil {
+		resource.displayName = *servicePrincipal.DisplayName
+	}
+
+	add := func(permission Permission) {
+		permission.ResourceAppId = resource.appId
+		permission.ResourceId = resource.id
+		permission.ResourceDisplayName = resource.displayName
+		resource.byId[permissionKey(permission.Type, permission.Id)] = permission
+
+		// prefer enabled permissions when a value has been reused
+		valueKey := permissionKey(permission.Type, permission.Value)
+		if existing, ok := resource.byValue[valueKey]; !ok || !existing.IsEnabled {
+			resource.byValue[valueKey] = permission
+		}
+	}
+
+	if servicePrincipal.AppRoles != nil {
+		for _, role := range *servicePrincipal.AppRoles {
+			if role.ID == nil || role.Value == nil {
+				continue
+			}
+			permission := Permission{
+				Id:        *role.ID,
+				Value:     *role.Value,
+				Type:      ResourceAccessTypeRole,
+				IsEnabled: role.IsEnabled == nil || *role.IsEnabled,
+			}
+			if role.DisplayName != nil {
+				permission.DisplayName = *role.DisplayName
+			}
+			add(permission)
+		}
+	}
+
+	scopes := make([]PermissionScope, 0)
+	if servicePrincipal.OAuth2PermissionScopes != nil {
+		scopes = append(scopes, *servicePrincipal.OAuth2PermissionScopes...)
+	}
+	if servicePrincipal.PublishedPermissionScopes != nil {
+		scopes = append(scopes, *servicePrincipal.PublishedPermissionScopes...)
+	}
+	for _, scope := range scopes {
+		if scope.ID == nil || scope.Value == nil {
+			continue
+		}
+		permission := Permission{
+			Id:        *scope.ID,
+			Value:     *scope.Value,
+			Type:      ResourceAccessTypeScope,
+			IsEnabled: scope.IsEnabled == nil || *scope.IsEnabled,
+		}
+		if scope.AdminConsentDisplayName != nil {
+			permission.DisplayName = *scope.AdminConsentDisplayName
+		}
+		add(permission)
+	}
+
+	r.resources[strings.ToLower(resource.appId)] = resource
+	r.appIds[strings.ToLower(resource.id)] = strings.ToLower(resource.appId)
+	return resource
+}
+
+// permissionKey returns the key used to index a permission by ID or value. The same value can be published as both
+// an app role and a permission scope, so the type is included.
+func permissionKey(permissionType ResourceAccessType, idOrValue string) string {
+	return strings.ToLower(permissionType + "/" + idOrValue)
+}
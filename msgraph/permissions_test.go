@@ -0,0 +1,140 @@
+package msgraph
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/manicminer/hamilton/internal/utils"
+)
+
+const testGraphServicePrincipal = `{
+	"id": "graph-sp-id",
+	"appId": "00000003-0000-0000-c000-000000000000",
+	"displayName": "Microsoft Graph",
+	"appRoles": [
+		{"id": "df021288-bdef-4463-88db-98f22de89214", "value": "User.Read.All", "displayName": "Read all users' full profiles", "isEnabled": true},
+		{"id": "19dbc75e-c2e2-444c-a770-ec69d8559fc7", "value": "Directory.ReadWrite.All", "isEnabled": true}
+	],
+	"publishedPermissionScopes": [
+		{"id": "e1fe6dd8-ba31-4d61-89e7-88639da4683d", "value": "User.Read", "adminConsentDisplayName": "Sign in and read user profile", "isEnabled": true},
+		{"id": "a154be20-db9c-4678-8ab7-66f6cc099a59", "value": "User.Read.All", "isEnabled": true}
+	]
+}`
+
+func TestPermissionResolver(t *testing.T) {
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		switch r.URL.Path {
+		case "/beta/servicePrincipals":
+			w.Header().Set("Content-Type", "application/json")
+			if r.URL.Query().Get("$filter") == "appId eq '00000003-0000-0000-c000-000000000000'" {
+				w.Write([]byte(`{"value":[` + testGraphServicePrincipal + `]}`))
+			} else {
+				w.Write([]byte(`{"value":[]}`))
+			}
+		case "/beta/servicePrincipals/graph-sp-id":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(testGraphServicePrincipal))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+
+	// assignments are resolved by the object ID of the resource, which also caches it by app ID
+	resolver := NewPermissionResolver(NewServicePrincipalsClient(WithEndpoint(ts.URL), WithRetryMax(0)))
+	permission, err := resolver.ResolveAppRoleAssignment(ctx, AppRoleAssignment{
+		ResourceId: utils.StringPtr("graph-sp-id"),
+		AppRoleId:  utils.StringPtr("df021288-bdef-4463-88db-98f22de89214"),
+	})
+	if err != nil {
+		t.Fatalf("ResolveAppRoleAssignment(): %v", err)
+	}
+	if permission.Value != "User.Read.All" || permission.Type != ResourceAccessTypeRole {
+		t.Errorf("unexpected permission: %+v", permission)
+	}
+	if s := permission.String(); s != "Microsoft Graph/User.Read.All (Role)" {
+		t.Errorf("unexpected String(): %s", s)
+	}
+
+	// the same value is published as a role and a scope
+	permission, err = resolver.ResolveName(ctx, MicrosoftGraphAppId, ResourceAccessTypeScope, "user.read.all")
+	if err != nil {
+		t.Fatalf("ResolveName(): %v", err)
+	}
+	if permission.Id != "a154be20-db9c-4678-8ab7-66f6cc099a59" {
+		t.Errorf("unexpected permission: %+v", permission)
+	}
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Errorf("expected resource to be retrieved once, got %d requests", n)
+	}
+
+	// a fresh resolver looks up the resource by app ID
+	resolver = NewPermissionResolver(NewServicePrincipalsClient(WithEndpoint(ts.URL), WithRetryMax(0)))
+	requiredResourceAccess, err := resolver.RequiredResourceAccess(ctx,
+		PermissionNames{ResourceAppId: MicrosoftGraphAppId, Scopes: []string{"User.Read"}},
+		PermissionNames{ResourceAppId: MicrosoftGraphAppId, Roles: []string{"User.Read.All", "Directory.ReadWrite.All"}, Scopes: []string{"User.Read"}},
+	)
+	if err != nil {
+		t.Fatalf("RequiredResourceAccess(): %v", err)
+	}
+	if len(*requiredResourceAccess) != 1 || len(*(*requiredResourceAccess)[0].ResourceAccess) != 3 {
+		t.Fatalf("unexpected requiredResourceAccess: %+v", *requiredResourceAccess)
+	}
+
+	permissions, err := resolver.ResolveRequiredResourceAccess(ctx, *requiredResourceAccess)
+	if err != nil {
+		t.Fatalf("ResolveRequiredResourceAccess(): %v", err)
+	}
+	var values []string
+	for _, p := range permissions {
+		values = append(values, p.Value+":"+p.Type)
+	}
+	if expected := []string{"User.Read:Scope", "User.Read.All:Role", "Directory.ReadWrite.All:Role"}; len(values) != len(expected) || values[0] != expected[0] || values[1] != expected[1] || values[2] != expected[2] {
+		t.Errorf("expected %v, got %v", expected, values)
+	}
+
+	if _, err := resolver.ResolveName(ctx, MicrosoftGraphAppId, ResourceAccessTypeRole, "User.Read"); err == nil {
+		t.Errorf("expected an error for a scope requested as a role")
+	}
+	if _, err := resolver.ResolveId(ctx, "unknown-app", ResourceAccessTypeRole, "id"); err == nil {
+		t.Errorf("expected an error for an unknown resource")
+	}
+}
+
+func TestPermissionResolver_Version10(t *testing.T) {
+	// v1.0 does not support publishedPermissionScopes, the scopes are returned as oauth2PermissionScopes instead
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1.0/servicePrincipals" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if selected := r.URL.Query().Get("$select"); strings.Contains(selected, "publishedPermissionScopes") || !strings.Contains(selected, "oauth2PermissionScopes") {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":{"code":"Request_BadRequest","message":"Could not find a property named 'publishedPermissionScopes'"}}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"value":[` + strings.Replace(testGraphServicePrincipal, "publishedPermissionScopes", "oauth2PermissionScopes", 1) + `]}`))
+	}))
+	defer ts.Close()
+
+	resolver := NewPermissionResolver(NewServicePrincipalsClient(WithEndpoint(ts.URL), WithRetryMax(0), WithApiVersion(Version10)))
+	permission, err := resolver.ResolveName(context.Background(), MicrosoftGraphAppId, ResourceAccessTypeScope, "User.Read")
+	if err != nil {
+		t.Fatalf("ResolveName(): %v", err)
+	}
+	if permission.Id != "e1fe6dd8-ba31-4d61-89e7-88639da4683d" {
+		t.Errorf("unexpected permission: %+v", permission)
+	}
+}
@@ -0,0 +1,108 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// photoEntity returns the entity for the photo of the specified size, or for the largest available photo when size is empty.
+func photoEntity(parent string, size ProfilePhotoSize) string {
+	if size == "" {
+		return fmt.Sprintf("%s/photo", parent)
+	}
+	return fmt.Sprintf("%s/photos/%s", parent, size)
+}
+
+// getPhoto retrieves the binary content of a photo. The response body is not buffered and is returned to the caller,
+// who is responsible for closing it.
+func (c Client) getPhoto(ctx context.Context, clientName, entity string) (io.ReadCloser, int, error) {
+	resp, status, _, err := c.Get(ctx, GetHttpRequestInput{
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusOK},
+		Uri: Uri{
+			Entity: fmt.Sprintf("%s/$value", entity),
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("%s.BaseClient.Get(): %v", clientName, err)
+	}
+
+	return resp.Body, status, nil
+}
+
+func (c Client) getPhotoMetadata(ctx context.Context, clientName, entity string) (*ProfilePhoto, int, error) {
+	resp, status, _, err := c.Get(ctx, GetHttpRequestInput{
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusOK},
+		Uri: Uri{
+			Entity: entity,
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("%s.BaseClient.Get(): %v", clientName, err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var photo ProfilePhoto
+	if err := json.Unmarshal(respBody, &photo); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &photo, status, nil
+}
+
+func (c Client) listPhotos(ctx context.Context, clientName, parent string) (*[]ProfilePhoto, int, error) {
+	resp, status, _, err := c.Get(ctx, GetHttpRequestInput{
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusOK},
+		Uri: Uri{
+			Entity: fmt.Sprintf("%s/photos", parent),
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("%s.BaseClient.Get(): %v", clientName, err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var data struct {
+		Photos []ProfilePhoto `json:"value"`
+	}
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &data.Photos, status, nil
+}
+
+func (c Client) uploadPhoto(ctx context.Context, clientName, parent, contentType string, photoData []byte) (int, error) {
+	_, status, _, err := c.Put(ctx, PutHttpRequestInput{
+		Body:                   photoData,
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		ContentType:            contentType,
+		ValidStatusCodes: []int{
+			http.StatusOK,
+			http.StatusNoContent,
+		},
+		Uri: Uri{
+			Entity: fmt.Sprintf("%s/photo/$value", parent),
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("%s.BaseClient.Put(): %v", clientName, err)
+	}
+
+	return status, nil
+}
@@ -0,0 +1,108 @@
+package msgraph
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestUsersClient_GetPhoto(t *testing.T) {
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/beta/users/user-id/photos/48x48/$value":
+			w.Header().Set("Content-Type", "image/jpeg")
+			w.Write([]byte("first"))
+			w.(http.Flusher).Flush()
+			// the remainder of the photo is only sent once the caller has started reading
+			<-release
+			w.Write([]byte("second"))
+
+		case "/beta/users/user-id/photo":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"@odata.mediaContentType":"image/jpeg","id":"648x648","height":648,"width":648}`))
+
+		case "/beta/users/user-id/photos":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"value":[{"id":"48x48","height":48,"width":48},{"id":"648x648","height":648,"width":648}]}`))
+
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c := NewUsersClient(WithEndpoint(ts.URL), WithRetryMax(0))
+
+	done := make(chan struct{})
+	var photo io.ReadCloser
+	var err error
+	go func() {
+		photo, _, err = c.GetPhoto(context.Background(), "user-id", ProfilePhotoSize48x48)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		close(release)
+		t.Fatal("GetPhoto(): response was buffered instead of streamed")
+	}
+	if err != nil {
+		close(release)
+		t.Fatalf("GetPhoto(): %v", err)
+	}
+	close(release)
+	data, err := io.ReadAll(photo)
+	photo.Close()
+	if err != nil {
+		t.Fatalf("io.ReadAll(): %v", err)
+	}
+	if string(data) != "firstsecond" {
+		t.Fatalf("unexpected photo content: %q", data)
+	}
+
+	metadata, _, err := c.GetPhotoMetadata(context.Background(), "user-id", "")
+	if err != nil {
+		t.Fatalf("GetPhotoMetadata(): %v", err)
+	}
+	if metadata.MediaContentType == nil || *metadata.MediaContentType != "image/jpeg" || *metadata.Width != 648 {
+		t.Fatalf("unexpected metadata: %+v", metadata)
+	}
+
+	photos, _, err := c.ListPhotos(context.Background(), "user-id")
+	if err != nil {
+		t.Fatalf("ListPhotos(): %v", err)
+	}
+	if len(*photos) != 2 || *(*photos)[0].ID != ProfilePhotoSize48x48 {
+		t.Fatalf("unexpected photos: %+v", *photos)
+	}
+}
+
+func TestGroupsClient_UploadPhoto(t *testing.T) {
+	photo := []byte{0x89, 'P', 'N', 'G'}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/beta/groups/group-id/photo/$value" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if contentType := r.Header.Get("Content-Type"); contentType != "image/png" {
+			t.Errorf("unexpected content type: %s", contentType)
+		}
+		if body, _ := io.ReadAll(r.Body); !bytes.Equal(body, photo) {
+			t.Errorf("unexpected request body: %v", body)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	c := NewGroupsClient(WithEndpoint(ts.URL), WithRetryMax(0))
+	if _, err := c.UploadPhoto(context.Background(), "group-id", "image/png", photo); err != nil {
+		t.Fatalf("UploadPhoto(): %v", err)
+	}
+}
@@ -0,0 +1,159 @@
+package msgraph
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// TokenLifetimeMinimum and TokenLifetimeMaximum are the bounds of the AccessTokenLifetime of a TokenLifetimePolicy.
+	TokenLifetimeMinimum = 10 * time.Minute
+	TokenLifetimeMaximum = 24 * time.Hour
+
+	// ActivityBasedTimeoutDefaultApplication is the ApplicationId of an activity-based timeout application policy
+	// that applies to all applications without a policy of their own.
+	ActivityBasedTimeoutDefaultApplication = "default"
+)
+
+// TokenLifetimePolicyDefinition builds the Definition of a TokenLifetimePolicy.
+type TokenLifetimePolicyDefinition struct {
+	// AccessTokenLifetime is the lifetime of access, ID and SAML2 tokens, between TokenLifetimeMinimum and
+	// TokenLifetimeMaximum.
+	AccessTokenLifetime time.Duration
+}
+
+// Definition returns the JSON definition for a TokenLifetimePolicy.
+func (d TokenLifetimePolicyDefinition) Definition() (*[]string, error) {
+	if d.AccessTokenLifetime < TokenLifetimeMinimum || d.AccessTokenLifetime > TokenLifetimeMaximum {
+		return nil, fmt.Errorf("AccessTokenLifetime must be between %s and %s", TokenLifetimeMinimum, TokenLifetimeMaximum)
+	}
+
+	return policyDefinition("TokenLifetimePolicy", struct {
+		Version             int    `json:"Version"`
+		AccessTokenLifetime string `json:"AccessTokenLifetime"`
+	}{
+		Version:             1,
+		AccessTokenLifetime: formatPolicyTimeSpan(d.AccessTokenLifetime),
+	})
+}
+
+// HomeRealmDiscoveryPolicyDefinition builds the Definition of a HomeRealmDiscoveryPolicy.
+type HomeRealmDiscoveryPolicyDefinition struct {
+	// AccelerateToFederatedDomain sends users directly to the federated identity provider for their domain, skipping
+	// the username entry page, when the tenant has a single federated domain or PreferredDomain is set.
+	AccelerateToFederatedDomain bool
+
+	// PreferredDomain is the federated domain to accelerate users to when the tenant has several.
+	PreferredDomain string
+
+	// AllowCloudPasswordValidation allows users of federated domains to authenticate with passwords synchronized to
+	// Microsoft Entra ID, for example when using the resource owner password credentials grant.
+	AllowCloudPasswordValidation bool
+
+	// AlternateIdLogin allows users to sign in with their email address instead of their user principal name.
+	AlternateIdLogin bool
+}
+
+// Definition returns the JSON definition for a HomeRealmDiscoveryPolicy.
+func (d HomeRealmDiscoveryPolicyDefinition) Definition() (*[]string, error) {
+	if d.PreferredDomain != "" && !d.AccelerateToFederatedDomain {
+		return nil, errors.New("PreferredDomain requires AccelerateToFederatedDomain")
+	}
+
+	type alternateIdLogin struct {
+		Enabled bool `json:"Enabled"`
+	}
+	definition := struct {
+		AccelerateToFederatedDomain  bool              `json:"AccelerateToFederatedDomain,omitempty"`
+		PreferredDomain              string            `json:"PreferredDomain,omitempty"`
+		AllowCloudPasswordValidation bool              `json:"AllowCloudPasswordValidation,omitempty"`
+		AlternateIdLogin             *alternateIdLogin `json:"AlternateIdLogin,omitempty"`
+	}{
+		AccelerateToFederatedDomain:  d.AccelerateToFederatedDomain,
+		PreferredDomain:              d.PreferredDomain,
+		AllowCloudPasswordValidation: d.AllowCloudPasswordValidation,
+	}
+	if d.AlternateIdLogin {
+		definition.AlternateIdLogin = &alternateIdLogin{Enabled: true}
+	}
+
+	return policyDefinition("HomeRealmDiscoveryPolicy", definition)
+}
+
+// ActivityBasedTimeoutPolicyDefinition builds the Definition of an ActivityBasedTimeoutPolicy.
+type ActivityBasedTimeoutPolicyDefinition struct {
+	// ApplicationPolicies sets the idle timeout for specific applications, or for all applications using
+	// ActivityBasedTimeoutDefaultApplication.
+	ApplicationPolicies []ActivityBasedTimeoutApplicationPolicy
+}
+
+// ActivityBasedTimeoutApplicationPolicy sets the idle timeout of web sessions for an application.
+type ActivityBasedTimeoutApplicationPolicy struct {
+	// ApplicationId is the application ID (not object ID) of the application, or ActivityBasedTimeoutDefaultApplication.
+	ApplicationId string
+
+	// WebSessionIdleTimeout is the period of inactivity after which users are signed out.
+	WebSessionIdleTimeout time.Duration
+}
+
+// Definition returns the JSON definition for an ActivityBasedTimeoutPolicy.
+func (d ActivityBasedTimeoutPolicyDefinition) Definition() (*[]string, error) {
+	if len(d.ApplicationPolicies) == 0 {
+		return nil, errors.New("at least one application policy is required")
+	}
+
+	type applicationPolicy struct {
+		ApplicationId         string `json:"ApplicationId"`
+		WebSessionIdleTimeout string `json:"WebSessionIdleTimeout"`
+	}
+	applicationPolicies := make([]applicationPolicy, 0, len(d.ApplicationPolicies))
+	seen := make(map[string]bool)
+	for _, policy := range d.ApplicationPolicies {
+		if policy.ApplicationId == "" {
+			return nil, errors.New("ApplicationId is required for each application policy")
+		}
+		if seen[policy.ApplicationId] {
+			return nil, fmt.Errorf("duplicate application policy for %q", policy.ApplicationId)
+		}
+		seen[policy.ApplicationId] = true
+		if policy.WebSessionIdleTimeout < time.Minute {
+			return nil, fmt.Errorf("WebSessionIdleTimeout for %q must be at least 1m0s", policy.ApplicationId)
+		}
+		applicationPolicies = append(applicationPolicies, applicationPolicy{
+			ApplicationId:         policy.ApplicationId,
+			WebSessionIdleTimeout: formatPolicyTimeSpan(policy.WebSessionIdleTimeout),
+		})
+	}
+
+	return policyDefinition("ActivityBasedTimeoutPolicy", struct {
+		Version             int                 `json:"Version"`
+		ApplicationPolicies []applicationPolicy `json:"ApplicationPolicies"`
+	}{
+		Version:             1,
+		ApplicationPolicies: applicationPolicies,
+	})
+}
+
+// policyDefinition wraps a policy definition in an object keyed by the policy type, and encodes it as the single
+// element of the Definition of a policy.
+func policyDefinition(policyType string, definition interface{}) (*[]string, error) {
+	data, err := json.Marshal(map[string]interface{}{policyType: definition})
+	if err != nil {
+		return nil, fmt.Errorf("json.Marshal(): %v", err)
+	}
+	return &[]string{string(data)}, nil
+}
+
+// formatPolicyTimeSpan formats a duration as a .NET TimeSpan, i.e. "hh:mm:ss" or "d.hh:mm:ss", rounded down to the
+// second.
+func formatPolicyTimeSpan(d time.Duration) string {
+	seconds := int64(d / time.Second)
+	days, seconds := seconds/86400, seconds%86400
+	timeSpan := fmt.Sprintf("%02d:%02d:%02d", seconds/3600, seconds%3600/60, seconds%60)
+	if days > 0 {
+		return fmt.Sprintf("%d.%s", days, timeSpan)
+	}
+	return timeSpan
+}
@@ -0,0 +1,87 @@
+package msgraph
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestPolicyDefinitions(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		builder  interface{ Definition() (*[]string, error) }
+		expected string
+	}{
+		{
+			name:     "token lifetime",
+			builder:  TokenLifetimePolicyDefinition{AccessTokenLifetime: 2 * time.Hour},
+			expected: `{"TokenLifetimePolicy":{"Version":1,"AccessTokenLifetime":"02:00:00"}}`,
+		},
+		{
+			name:     "token lifetime maximum",
+			builder:  TokenLifetimePolicyDefinition{AccessTokenLifetime: TokenLifetimeMaximum},
+			expected: `{"TokenLifetimePolicy":{"Version":1,"AccessTokenLifetime":"1.00:00:00"}}`,
+		},
+		{
+			name:     "home realm discovery",
+			builder:  HomeRealmDiscoveryPolicyDefinition{AccelerateToFederatedDomain: true, PreferredDomain: "federated.contoso.com", AlternateIdLogin: true},
+			expected: `{"HomeRealmDiscoveryPolicy":{"AccelerateToFederatedDomain":true,"PreferredDomain":"federated.contoso.com","AlternateIdLogin":{"Enabled":true}}}`,
+		},
+		{
+			name: "activity based timeout",
+			builder: ActivityBasedTimeoutPolicyDefinition{ApplicationPolicies: []ActivityBasedTimeoutApplicationPolicy{
+				{ApplicationId: ActivityBasedTimeoutDefaultApplication, WebSessionIdleTimeout: 90 * time.Minute},
+			}},
+			expected: `{"ActivityBasedTimeoutPolicy":{"Version":1,"ApplicationPolicies":[{"ApplicationId":"default","WebSessionIdleTimeout":"01:30:00"}]}}`,
+		},
+	} {
+		definition, err := tc.builder.Definition()
+		if err != nil {
+			t.Errorf("%s: Definition(): %v", tc.name, err)
+			continue
+		}
+		if len(*definition) != 1 || (*definition)[0] != tc.expected {
+			t.Errorf("%s: unexpected definition %v, expected %s", tc.name, *definition, tc.expected)
+		}
+	}
+
+	for _, tc := range []struct {
+		name    string
+		builder interface{ Definition() (*[]string, error) }
+	}{
+		{"token lifetime too short", TokenLifetimePolicyDefinition{AccessTokenLifetime: 5 * time.Minute}},
+		{"preferred domain without acceleration", HomeRealmDiscoveryPolicyDefinition{PreferredDomain: "federated.contoso.com"}},
+		{"no application policies", ActivityBasedTimeoutPolicyDefinition{}},
+		{"duplicate application policies", ActivityBasedTimeoutPolicyDefinition{ApplicationPolicies: []ActivityBasedTimeoutApplicationPolicy{
+			{ApplicationId: "default", WebSessionIdleTimeout: time.Hour},
+			{ApplicationId: "default", WebSessionIdleTimeout: 2 * time.Hour},
+		}}},
+	} {
+		if _, err := tc.builder.Definition(); err == nil {
+			t.Errorf("%s: expected an error", tc.name)
+		}
+	}
+}
+
+func TestTokenLifetimePolicyClient_ListAppliesTo(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1.0/policies/tokenLifetimePolicies/policy-1/appliesTo" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"value":[{"@odata.type":"#microsoft.graph.application","id":"app-1"}]}`))
+	}))
+	defer ts.Close()
+
+	objects, _, err := NewTokenLifetimePolicyClient(WithEndpoint(ts.URL), WithRetryMax(0)).ListAppliesTo(context.Background(), "policy-1")
+	if err != nil {
+		t.Fatalf("ListAppliesTo(): %v", err)
+	}
+	if len(*objects) != 1 || *(*objects)[0].ID() != "app-1" {
+		t.Errorf("unexpected objects: %+v", *objects)
+	}
+}
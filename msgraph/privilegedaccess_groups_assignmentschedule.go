@@ -14,9 +14,9 @@ type PrivilegedAccessGroupAssignmentScheduleClient struct {
 	BaseClient Client
 }
 
-func NewPrivilegedAccessGroupAssignmentScheduleClient() *PrivilegedAccessGroupAssignmentScheduleClient {
+func NewPrivilegedAccessGroupAssignmentScheduleClient(opts ...ClientOption) *PrivilegedAccessGroupAssignmentScheduleClient {
 	return &PrivilegedAccessGroupAssignmentScheduleClient{
-		BaseClient: NewClient(VersionBeta),
+		BaseClient: NewClient(VersionBeta, opts...),
 	}
 }
 
@@ -14,9 +14,9 @@ type PrivilegedAccessGroupAssignmentScheduleInstancesClient struct {
 	BaseClient Client
 }
 
-func NewPrivilegedAccessGroupAssignmentScheduleInstancesClient() *PrivilegedAccessGroupAssignmentScheduleInstancesClient {
+func NewPrivilegedAccessGroupAssignmentScheduleInstancesClient(opts ...ClientOption) *PrivilegedAccessGroupAssignmentScheduleInstancesClient {
 	return &PrivilegedAccessGroupAssignmentScheduleInstancesClient{
-		BaseClient: NewClient(VersionBeta),
+		BaseClient: NewClient(VersionBeta, opts...),
 	}
 }
 
@@ -14,9 +14,9 @@ type PrivilegedAccessGroupAssignmentScheduleRequestsClient struct {
 	BaseClient Client
 }
 
-func NewPrivilegedAccessGroupAssignmentScheduleRequestsClient() *PrivilegedAccessGroupAssignmentScheduleRequestsClient {
+func NewPrivilegedAccessGroupAssignmentScheduleRequestsClient(opts ...ClientOption) *PrivilegedAccessGroupAssignmentScheduleRequestsClient {
 	return &PrivilegedAccessGroupAssignmentScheduleRequestsClient{
-		BaseClient: NewClient(VersionBeta),
+		BaseClient: NewClient(VersionBeta, opts...),
 	}
 }
 
@@ -14,9 +14,9 @@ type PrivilegedAccessGroupEligibilityScheduleClient struct {
 	BaseClient Client
 }
 
-func NewPrivilegedAccessGroupEligibilityScheduleClient() *PrivilegedAccessGroupEligibilityScheduleClient {
+func NewPrivilegedAccessGroupEligibilityScheduleClient(opts ...ClientOption) *PrivilegedAccessGroupEligibilityScheduleClient {
 	return &PrivilegedAccessGroupEligibilityScheduleClient{
-		BaseClient: NewClient(VersionBeta),
+		BaseClient: NewClient(VersionBeta, opts...),
 	}
 }
 
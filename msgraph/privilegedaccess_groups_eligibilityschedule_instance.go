@@ -14,9 +14,9 @@ type PrivilegedAccessGroupEligibilityScheduleInstancesClient struct {
 	BaseClient Client
 }
 
-func NewPrivilegedAccessGroupEligibilityScheduleInstancesClient() *PrivilegedAccessGroupEligibilityScheduleInstancesClient {
+func NewPrivilegedAccessGroupEligibilityScheduleInstancesClient(opts ...ClientOption) *PrivilegedAccessGroupEligibilityScheduleInstancesClient {
 	return &PrivilegedAccessGroupEligibilityScheduleInstancesClient{
-		BaseClient: NewClient(VersionBeta),
+		BaseClient: NewClient(VersionBeta, opts...),
 	}
 }
 
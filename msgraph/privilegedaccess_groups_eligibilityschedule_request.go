@@ -14,9 +14,9 @@ type PrivilegedAccessGroupEligibilityScheduleRequestsClient struct {
 	BaseClient Client
 }
 
-func NewPrivilegedAccessGroupEligibilityScheduleRequestsClient() *PrivilegedAccessGroupEligibilityScheduleRequestsClient {
+func NewPrivilegedAccessGroupEligibilityScheduleRequestsClient(opts ...ClientOption) *PrivilegedAccessGroupEligibilityScheduleRequestsClient {
 	return &PrivilegedAccessGroupEligibilityScheduleRequestsClient{
-		BaseClient: NewClient(VersionBeta),
+		BaseClient: NewClient(VersionBeta, opts...),
 	}
 }
 
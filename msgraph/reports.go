@@ -16,13 +16,19 @@ type ReportsClient struct {
 }
 
 // NewReportsClient returns a new ReportsClient.
-func NewReportsClient() *ReportsClient {
+func NewReportsClient(opts ...ClientOption) *ReportsClient {
 	return &ReportsClient{
-		BaseClient: NewClient(VersionBeta),
+		BaseClient: NewClient(VersionBeta, opts...),
 	}
 }
 
+// GetCredentialUserRegistrationCount retrieves the number of users registered for each credential type.
+// This operation is only available in the beta API.
 func (c *ReportsClient) GetCredentialUserRegistrationCount(ctx context.Context, query odata.Query) (*[]CredentialUserRegistrationCount, int, error) {
+	if err := c.BaseClient.requireBeta("ReportsClient.GetCredentialUserRegistrationCount()"); err != nil {
+		return nil, 0, err
+	}
+
 	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
 		DisablePaging:    query.Top > 0,
 		OData:            query,
@@ -51,7 +57,13 @@ func (c *ReportsClient) GetCredentialUserRegistrationCount(ctx context.Context,
 	return &data.CredentialUserRegistrationCount, status, nil
 }
 
+// GetCredentialUserRegistrationDetails retrieves the credential registration details for users.
+// This operation is only available in the beta API.
 func (c *ReportsClient) GetCredentialUserRegistrationDetails(ctx context.Context, query odata.Query) (*[]CredentialUserRegistrationDetails, int, error) {
+	if err := c.BaseClient.requireBeta("ReportsClient.GetCredentialUserRegistrationDetails()"); err != nil {
+		return nil, 0, err
+	}
+
 	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
 		DisablePaging:    query.Top > 0,
 		OData:            query,
@@ -80,7 +92,13 @@ func (c *ReportsClient) GetCredentialUserRegistrationDetails(ctx context.Context
 	return &data.CredentialUserRegistrationDetails, status, nil
 }
 
+// GetUserCredentialUsageDetails retrieves the self-service password reset and MFA usage details for users.
+// This operation is only available in the beta API.
 func (c *ReportsClient) GetUserCredentialUsageDetails(ctx context.Context, query odata.Query) (*[]UserCredentialUsageDetails, int, error) {
+	if err := c.BaseClient.requireBeta("ReportsClient.GetUserCredentialUsageDetails()"); err != nil {
+		return nil, 0, err
+	}
+
 	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
 		DisablePaging:    query.Top > 0,
 		OData:            query,
@@ -109,7 +127,13 @@ func (c *ReportsClient) GetUserCredentialUsageDetails(ctx context.Context, query
 	return &data.UserCredentialUsageDetails, status, nil
 }
 
+// GetCredentialUsageSummary retrieves a summary of credential usage for the specified period.
+// This operation is only available in the beta API.
 func (c *ReportsClient) GetCredentialUsageSummary(ctx context.Context, period CredentialUsageSummaryPeriod, query odata.Query) (*[]CredentialUsageSummary, int, error) {
+	if err := c.BaseClient.requireBeta("ReportsClient.GetCredentialUsageSummary()"); err != nil {
+		return nil, 0, err
+	}
+
 	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
 		DisablePaging:    query.Top > 0,
 		OData:            query,
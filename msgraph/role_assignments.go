@@ -16,9 +16,9 @@ type RoleAssignmentsClient struct {
 }
 
 // NewRoleAssignmentsClient returns a new RoleAssignmentsClient
-func NewRoleAssignmentsClient() *RoleAssignmentsClient {
+func NewRoleAssignmentsClient(opts ...ClientOption) *RoleAssignmentsClient {
 	return &RoleAssignmentsClient{
-		BaseClient: NewClient(Version10),
+		BaseClient: NewClient(Version10, opts...),
 	}
 }
 
@@ -16,9 +16,9 @@ type RoleDefinitionsClient struct {
 }
 
 // NewRoleDefinitionsClient returns a new RoleDefinitionsClient
-func NewRoleDefinitionsClient() *RoleDefinitionsClient {
+func NewRoleDefinitionsClient(opts ...ClientOption) *RoleDefinitionsClient {
 	return &RoleDefinitionsClient{
-		BaseClient: NewClient(Version10),
+		BaseClient: NewClient(Version10, opts...),
 	}
 }
 
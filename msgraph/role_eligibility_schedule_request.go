@@ -16,9 +16,9 @@ type RoleEligibilityScheduleRequestClient struct {
 }
 
 // NewRoleEligibilityScheduleRequest returns a new RoleEligibilityScheduleRequestClient
-func NewRoleEligibilityScheduleRequestClient() *RoleEligibilityScheduleRequestClient {
+func NewRoleEligibilityScheduleRequestClient(opts ...ClientOption) *RoleEligibilityScheduleRequestClient {
 	return &RoleEligibilityScheduleRequestClient{
-		BaseClient: NewClient(Version10),
+		BaseClient: NewClient(Version10, opts...),
 	}
 }
 
@@ -14,9 +14,9 @@ type RoleManagementPolicyClient struct {
 	BaseClient Client
 }
 
-func NewRoleManagementPolicyClient() *RoleManagementPolicyClient {
+func NewRoleManagementPolicyClient(opts ...ClientOption) *RoleManagementPolicyClient {
 	return &RoleManagementPolicyClient{
-		BaseClient: NewClient(VersionBeta),
+		BaseClient: NewClient(VersionBeta, opts...),
 	}
 }
 
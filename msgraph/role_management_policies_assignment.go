@@ -14,9 +14,9 @@ type RoleManagementPolicyAssignmentClient struct {
 	BaseClient Client
 }
 
-func NewRoleManagementPolicyAssignmentClient() *RoleManagementPolicyAssignmentClient {
+func NewRoleManagementPolicyAssignmentClient(opts ...ClientOption) *RoleManagementPolicyAssignmentClient {
 	return &RoleManagementPolicyAssignmentClient{
-		BaseClient: NewClient(VersionBeta),
+		BaseClient: NewClient(VersionBeta, opts...),
 	}
 }
 
@@ -14,9 +14,9 @@ type RoleManagementPolicyRuleClient struct {
 	BaseClient Client
 }
 
-func NewRoleManagementPolicyRuleClient() *RoleManagementPolicyRuleClient {
+func NewRoleManagementPolicyRuleClient(opts ...ClientOption) *RoleManagementPolicyRuleClient {
 	return &RoleManagementPolicyRuleClient{
-		BaseClient: NewClient(VersionBeta),
+		BaseClient: NewClient(VersionBeta, opts...),
 	}
 }
 
@@ -16,9 +16,9 @@ type SchemaExtensionsClient struct {
 }
 
 // NewSchemaExtensionsClient returns a new SchemaExtensionsClient.
-func NewSchemaExtensionsClient() *SchemaExtensionsClient {
+func NewSchemaExtensionsClient(opts ...ClientOption) *SchemaExtensionsClient {
 	return &SchemaExtensionsClient{
-		BaseClient: NewClient(VersionBeta),
+		BaseClient: NewClient(VersionBeta, opts...),
 	}
 }
 
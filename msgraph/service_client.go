@@ -0,0 +1,368 @@
+package msgraph
+
+// ServiceClient provides access to clients for all supported entities, which share a single configuration and
+// HTTP transport. Options applied to the ServiceClient are applied to every entity client it returns.
+type ServiceClient struct {
+	base Client
+}
+
+// NewServiceClient returns a new ServiceClient configured with the specified options. Unless an API version is
+// specified with WithApiVersion, each entity client uses its own default API version.
+func NewServiceClient(opts ...ClientOption) *ServiceClient {
+	return &ServiceClient{
+		base: NewClient("", opts...),
+	}
+}
+
+// BaseClient returns a copy of the shared base client configuration.
+func (s *ServiceClient) BaseClient() Client {
+	return s.base
+}
+
+// clientOptions returns options that apply the shared configuration to an entity client, followed by any
+// client-specific options. When client-specific options are given, the entity client receives its own copy of the
+// retryable client so that those options do not affect other clients, although the underlying transport is shared.
+func (s *ServiceClient) clientOptions(opts []ClientOption) []ClientOption {
+	shared := func(c *Client) {
+		apiVersion := c.ApiVersion
+		*c = s.base
+		if c.ApiVersion == "" {
+			c.ApiVersion = apiVersion
+		}
+		if len(opts) > 0 {
+			c.RetryableClient = copyRetryableClient(s.base.RetryableClient)
+			c.HttpClient = c.RetryableClient.StandardClient()
+		}
+	}
+	return append([]ClientOption{shared}, opts...)
+}
+
+// AccessPackageAssignmentPolicy returns a AccessPackageAssignmentPolicyClient using the shared configuration.
+func (s *ServiceClient) AccessPackageAssignmentPolicy(opts ...ClientOption) *AccessPackageAssignmentPolicyClient {
+	return NewAccessPackageAssignmentPolicyClient(s.clientOptions(opts)...)
+}
+
+// AccessPackageAssignmentRequest returns a AccessPackageAssignmentRequestClient using the shared configuration.
+func (s *ServiceClient) AccessPackageAssignmentRequest(opts ...ClientOption) *AccessPackageAssignmentRequestClient {
+	return NewAccessPackageAssignmentRequestClient(s.clientOptions(opts)...)
+}
+
+// AccessPackageCatalog returns a AccessPackageCatalogClient using the shared configuration.
+func (s *ServiceClient) AccessPackageCatalog(opts ...ClientOption) *AccessPackageCatalogClient {
+	return NewAccessPackageCatalogClient(s.clientOptions(opts)...)
+}
+
+// AccessPackage returns a AccessPackageClient using the shared configuration.
+func (s *ServiceClient) AccessPackage(opts ...ClientOption) *AccessPackageClient {
+	return NewAccessPackageClient(s.clientOptions(opts)...)
+}
+
+// AccessPackageResource returns a AccessPackageResourceClient using the shared configuration.
+func (s *ServiceClient) AccessPackageResource(opts ...ClientOption) *AccessPackageResourceClient {
+	return NewAccessPackageResourceClient(s.clientOptions(opts)...)
+}
+
+// AccessPackageResourceRequest returns a AccessPackageResourceRequestClient using the shared configuration.
+func (s *ServiceClient) AccessPackageResourceRequest(opts ...ClientOption) *AccessPackageResourceRequestClient {
+	return NewAccessPackageResourceRequestClient(s.clientOptions(opts)...)
+}
+
+// AccessPackageResourceRole returns a AccessPackageResourceRoleClient using the shared configuration.
+func (s *ServiceClient) AccessPackageResourceRole(opts ...ClientOption) *AccessPackageResourceRoleClient {
+	return NewAccessPackageResourceRoleClient(s.clientOptions(opts)...)
+}
+
+// AccessPackageResourceRoleScope returns a AccessPackageResourceRoleScopeClient using the shared configuration.
+func (s *ServiceClient) AccessPackageResourceRoleScope(opts ...ClientOption) *AccessPackageResourceRoleScopeClient {
+	return NewAccessPackageResourceRoleScopeClient(s.clientOptions(opts)...)
+}
+
+// ActivityBasedTimeoutPolicy returns a ActivityBasedTimeoutPolicyClient using the shared configuration.
+func (s *ServiceClient) ActivityBasedTimeoutPolicy(opts ...ClientOption) *ActivityBasedTimeoutPolicyClient {
+	return NewActivityBasedTimeoutPolicyClient(s.clientOptions(opts)...)
+}
+
+// AdministrativeUnits returns a AdministrativeUnitsClient using the shared configuration.
+func (s *ServiceClient) AdministrativeUnits(opts ...ClientOption) *AdministrativeUnitsClient {
+	return NewAdministrativeUnitsClient(s.clientOptions(opts)...)
+}
+
+// AppRoleAssignedTo returns a AppRoleAssignedToClient using the shared configuration.
+func (s *ServiceClient) AppRoleAssignedTo(opts ...ClientOption) *AppRoleAssignedToClient {
+	return NewAppRoleAssignedToClient(s.clientOptions(opts)...)
+}
+
+// ApplicationProxyConnectorGroups returns a ApplicationProxyConnectorGroupsClient using the shared configuration.
+func (s *ServiceClient) ApplicationProxyConnectorGroups(opts ...ClientOption) *ApplicationProxyConnectorGroupsClient {
+	return NewApplicationProxyConnectorGroupsClient(s.clientOptions(opts)...)
+}
+
+// ApplicationProxyConnectors returns a ApplicationProxyConnectorsClient using the shared configuration.
+func (s *ServiceClient) ApplicationProxyConnectors(opts ...ClientOption) *ApplicationProxyConnectorsClient {
+	return NewApplicationProxyConnectorsClient(s.clientOptions(opts)...)
+}
+
+// ApplicationTemplates returns a ApplicationTemplatesClient using the shared configuration.
+func (s *ServiceClient) ApplicationTemplates(opts ...ClientOption) *ApplicationTemplatesClient {
+	return NewApplicationTemplatesClient(s.clientOptions(opts)...)
+}
+
+// Applications returns a ApplicationsClient using the shared configuration.
+func (s *ServiceClient) Applications(opts ...ClientOption) *ApplicationsClient {
+	return NewApplicationsClient(s.clientOptions(opts)...)
+}
+
+// AttributeSet returns a AttributeSetClient using the shared configuration.
+func (s *ServiceClient) AttributeSet(opts ...ClientOption) *AttributeSetClient {
+	return NewAttributeSetClient(s.clientOptions(opts)...)
+}
+
+// AuthenticationMethods returns a AuthenticationMethodsClient using the shared configuration.
+func (s *ServiceClient) AuthenticationMethods(opts ...ClientOption) *AuthenticationMethodsClient {
+	return NewAuthenticationMethodsClient(s.clientOptions(opts)...)
+}
+
+// AuthenticationStrengthPolicies returns a AuthenticationStrengthPoliciesClient using the shared configuration.
+func (s *ServiceClient) AuthenticationStrengthPolicies(opts ...ClientOption) *AuthenticationStrengthPoliciesClient {
+	return NewAuthenticationStrengthPoliciesClient(s.clientOptions(opts)...)
+}
+
+// B2CUserFlow returns a B2CUserFlowClient using the shared configuration.
+func (s *ServiceClient) B2CUserFlow(opts ...ClientOption) *B2CUserFlowClient {
+	return NewB2CUserFlowClient(s.clientOptions(opts)...)
+}
+
+// ClaimsMappingPolicy returns a ClaimsMappingPolicyClient using the shared configuration.
+func (s *ServiceClient) ClaimsMappingPolicy(opts ...ClientOption) *ClaimsMappingPolicyClient {
+	return NewClaimsMappingPolicyClient(s.clientOptions(opts)...)
+}
+
+// ConditionalAccessPolicies returns a ConditionalAccessPoliciesClient using the shared configuration.
+func (s *ServiceClient) ConditionalAccessPolicies(opts ...ClientOption) *ConditionalAccessPoliciesClient {
+	return NewConditionalAccessPoliciesClient(s.clientOptions(opts)...)
+}
+
+// ConnectedOrganization returns a ConnectedOrganizationClient using the shared configuration.
+func (s *ServiceClient) ConnectedOrganization(opts ...ClientOption) *ConnectedOrganizationClient {
+	return NewConnectedOrganizationClient(s.clientOptions(opts)...)
+}
+
+// CustomSecurityAttributeDefinition returns a CustomSecurityAttributeDefinitionClient using the shared configuration.
+func (s *ServiceClient) CustomSecurityAttributeDefinition(opts ...ClientOption) *CustomSecurityAttributeDefinitionClient {
+	return NewCustomSecurityAttributeDefinitionClient(s.clientOptions(opts)...)
+}
+
+// DelegatedPermissionGrants returns a DelegatedPermissionGrantsClient using the shared configuration.
+func (s *ServiceClient) DelegatedPermissionGrants(opts ...ClientOption) *DelegatedPermissionGrantsClient {
+	return NewDelegatedPermissionGrantsClient(s.clientOptions(opts)...)
+}
+
+// DirectoryAuditReports returns a DirectoryAuditReportsClient using the shared configuration.
+func (s *ServiceClient) DirectoryAuditReports(opts ...ClientOption) *DirectoryAuditReportsClient {
+	return NewDirectoryAuditReportsClient(s.clientOptions(opts)...)
+}
+
+// DirectoryObjects returns a DirectoryObjectsClient using the shared configuration.
+func (s *ServiceClient) DirectoryObjects(opts ...ClientOption) *DirectoryObjectsClient {
+	return NewDirectoryObjectsClient(s.clientOptions(opts)...)
+}
+
+// DirectoryRoleTemplates returns a DirectoryRoleTemplatesClient using the shared configuration.
+func (s *ServiceClient) DirectoryRoleTemplates(opts ...ClientOption) *DirectoryRoleTemplatesClient {
+	return NewDirectoryRoleTemplatesClient(s.clientOptions(opts)...)
+}
+
+// DirectoryRoles returns a DirectoryRolesClient using the shared configuration.
+func (s *ServiceClient) DirectoryRoles(opts ...ClientOption) *DirectoryRolesClient {
+	return NewDirectoryRolesClient(s.clientOptions(opts)...)
+}
+
+// DirectorySettings returns a DirectorySettingsClient using the shared configuration.
+func (s *ServiceClient) DirectorySettings(opts ...ClientOption) *DirectorySettingsClient {
+	return NewDirectorySettingsClient(s.clientOptions(opts)...)
+}
+
+// Domains returns a DomainsClient using the shared configuration.
+func (s *ServiceClient) Domains(opts ...ClientOption) *DomainsClient {
+	return NewDomainsClient(s.clientOptions(opts)...)
+}
+
+// EntitlementRoleAssignments returns a EntitlementRoleAssignmentsClient using the shared configuration.
+func (s *ServiceClient) EntitlementRoleAssignments(opts ...ClientOption) *EntitlementRoleAssignmentsClient {
+	return NewEntitlementRoleAssignmentsClient(s.clientOptions(opts)...)
+}
+
+// EntitlementRoleDefinitions returns a EntitlementRoleDefinitionsClient using the shared configuration.
+func (s *ServiceClient) EntitlementRoleDefinitions(opts ...ClientOption) *EntitlementRoleDefinitionsClient {
+	return NewEntitlementRoleDefinitionsClient(s.clientOptions(opts)...)
+}
+
+// GroupLifecyclePolicies returns a GroupLifecyclePoliciesClient using the shared configuration.
+func (s *ServiceClient) GroupLifecyclePolicies(opts ...ClientOption) *GroupLifecyclePoliciesClient {
+	return NewGroupLifecyclePoliciesClient(s.clientOptions(opts)...)
+}
+
+// GroupsAppRoleAssignments returns a AppRoleAssignmentsClient using the shared configuration.
+func (s *ServiceClient) GroupsAppRoleAssignments(opts ...ClientOption) *AppRoleAssignmentsClient {
+	return NewGroupsAppRoleAssignmentsClient(s.clientOptions(opts)...)
+}
+
+// Groups returns a GroupsClient using the shared configuration.
+func (s *ServiceClient) Groups(opts ...ClientOption) *GroupsClient {
+	return NewGroupsClient(s.clientOptions(opts)...)
+}
+
+// HomeRealmDiscoveryPolicy returns a HomeRealmDiscoveryPolicyClient using the shared configuration.
+func (s *ServiceClient) HomeRealmDiscoveryPolicy(opts ...ClientOption) *HomeRealmDiscoveryPolicyClient {
+	return NewHomeRealmDiscoveryPolicyClient(s.clientOptions(opts)...)
+}
+
+// IdentityProviders returns a IdentityProvidersClient using the shared configuration.
+func (s *ServiceClient) IdentityProviders(opts ...ClientOption) *IdentityProvidersClient {
+	return NewIdentityProvidersClient(s.clientOptions(opts)...)
+}
+
+// Invitations returns a InvitationsClient using the shared configuration.
+func (s *ServiceClient) Invitations(opts ...ClientOption) *InvitationsClient {
+	return NewInvitationsClient(s.clientOptions(opts)...)
+}
+
+// Me returns a MeClient using the shared configuration.
+func (s *ServiceClient) Me(opts ...ClientOption) *MeClient {
+	return NewMeClient(s.clientOptions(opts)...)
+}
+
+// NamedLocations returns a NamedLocationsClient using the shared configuration.
+func (s *ServiceClient) NamedLocations(opts ...ClientOption) *NamedLocationsClient {
+	return NewNamedLocationsClient(s.clientOptions(opts)...)
+}
+
+// PrivilegedAccessGroupAssignmentSchedule returns a PrivilegedAccessGroupAssignmentScheduleClient using the shared configuration.
+func (s *ServiceClient) PrivilegedAccessGroupAssignmentSchedule(opts ...ClientOption) *PrivilegedAccessGroupAssignmentScheduleClient {
+	return NewPrivilegedAccessGroupAssignmentScheduleClient(s.clientOptions(opts)...)
+}
+
+// PrivilegedAccessGroupAssignmentScheduleInstances returns a PrivilegedAccessGroupAssignmentScheduleInstancesClient using the shared configuration.
+func (s *ServiceClient) PrivilegedAccessGroupAssignmentScheduleInstances(opts ...ClientOption) *PrivilegedAccessGroupAssignmentScheduleInstancesClient {
+	return NewPrivilegedAccessGroupAssignmentScheduleInstancesClient(s.clientOptions(opts)...)
+}
+
+// PrivilegedAccessGroupAssignmentScheduleRequests returns a PrivilegedAccessGroupAssignmentScheduleRequestsClient using the shared configuration.
+func (s *ServiceClient) PrivilegedAccessGroupAssignmentScheduleRequests(opts ...ClientOption) *PrivilegedAccessGroupAssignmentScheduleRequestsClient {
+	return NewPrivilegedAccessGroupAssignmentScheduleRequestsClient(s.clientOptions(opts)...)
+}
+
+// PrivilegedAccessGroupEligibilitySchedule returns a PrivilegedAccessGroupEligibilityScheduleClient using the shared configuration.
+func (s *ServiceClient) PrivilegedAccessGroupEligibilitySchedule(opts ...ClientOption) *PrivilegedAccessGroupEligibilityScheduleClient {
+	return NewPrivilegedAccessGroupEligibilityScheduleClient(s.clientOptions(opts)...)
+}
+
+// PrivilegedAccessGroupEligibilityScheduleInstances returns a PrivilegedAccessGroupEligibilityScheduleInstancesClient using the shared configuration.
+func (s *ServiceClient) PrivilegedAccessGroupEligibilityScheduleInstances(opts ...ClientOption) *PrivilegedAccessGroupEligibilityScheduleInstancesClient {
+	return NewPrivilegedAccessGroupEligibilityScheduleInstancesClient(s.clientOptions(opts)...)
+}
+
+// PrivilegedAccessGroupEligibilityScheduleRequests returns a PrivilegedAccessGroupEligibilityScheduleRequestsClient using the shared configuration.
+func (s *ServiceClient) PrivilegedAccessGroupEligibilityScheduleRequests(opts ...ClientOption) *PrivilegedAccessGroupEligibilityScheduleRequestsClient {
+	return NewPrivilegedAccessGroupEligibilityScheduleRequestsClient(s.clientOptions(opts)...)
+}
+
+// Reports returns a ReportsClient using the shared configuration.
+func (s *ServiceClient) Reports(opts ...ClientOption) *ReportsClient {
+	return NewReportsClient(s.clientOptions(opts)...)
+}
+
+// RoleAssignments returns a RoleAssignmentsClient using the shared configuration.
+func (s *ServiceClient) RoleAssignments(opts ...ClientOption) *RoleAssignmentsClient {
+	return NewRoleAssignmentsClient(s.clientOptions(opts)...)
+}
+
+// RoleDefinitions returns a RoleDefinitionsClient using the shared configuration.
+func (s *ServiceClient) RoleDefinitions(opts ...ClientOption) *RoleDefinitionsClient {
+	return NewRoleDefinitionsClient(s.clientOptions(opts)...)
+}
+
+// RoleEligibilityScheduleRequest returns a RoleEligibilityScheduleRequestClient using the shared configuration.
+func (s *ServiceClient) RoleEligibilityScheduleRequest(opts ...ClientOption) *RoleEligibilityScheduleRequestClient {
+	return NewRoleEligibilityScheduleRequestClient(s.clientOptions(opts)...)
+}
+
+// RoleManagementPolicyAssignment returns a RoleManagementPolicyAssignmentClient using the shared configuration.
+func (s *ServiceClient) RoleManagementPolicyAssignment(opts ...ClientOption) *RoleManagementPolicyAssignmentClient {
+	return NewRoleManagementPolicyAssignmentClient(s.clientOptions(opts)...)
+}
+
+// RoleManagementPolicy returns a RoleManagementPolicyClient using the shared configuration.
+func (s *ServiceClient) RoleManagementPolicy(opts ...ClientOption) *RoleManagementPolicyClient {
+	return NewRoleManagementPolicyClient(s.clientOptions(opts)...)
+}
+
+// RoleManagementPolicyRule returns a RoleManagementPolicyRuleClient using the shared configuration.
+func (s *ServiceClient) RoleManagementPolicyRule(opts ...ClientOption) *RoleManagementPolicyRuleClient {
+	return NewRoleManagementPolicyRuleClient(s.clientOptions(opts)...)
+}
+
+// SchemaExtensions returns a SchemaExtensionsClient using the shared configuration.
+func (s *ServiceClient) SchemaExtensions(opts ...ClientOption) *SchemaExtensionsClient {
+	return NewSchemaExtensionsClient(s.clientOptions(opts)...)
+}
+
+// ServicePrincipalsAppRoleAssignments returns a AppRoleAssignmentsClient using the shared configuration.
+func (s *ServiceClient) ServicePrincipalsAppRoleAssignments(opts ...ClientOption) *AppRoleAssignmentsClient {
+	return NewServicePrincipalsAppRoleAssignmentsClient(s.clientOptions(opts)...)
+}
+
+// ServicePrincipals returns a ServicePrincipalsClient using the shared configuration.
+func (s *ServiceClient) ServicePrincipals(opts ...ClientOption) *ServicePrincipalsClient {
+	return NewServicePrincipalsClient(s.clientOptions(opts)...)
+}
+
+// SignInReports returns a SignInReportsClient using the shared configuration.
+func (s *ServiceClient) SignInReports(opts ...ClientOption) *SignInReportsClient {
+	return NewSignInReportsClient(s.clientOptions(opts)...)
+}
+
+// SubscribedSkus returns a SubscribedSkusClient using the shared configuration.
+func (s *ServiceClient) SubscribedSkus(opts ...ClientOption) *SubscribedSkusClient {
+	return NewSubscribedSkusClient(s.clientOptions(opts)...)
+}
+
+// SynchronizationJob returns a SynchronizationJobClient using the shared configuration.
+func (s *ServiceClient) SynchronizationJob(opts ...ClientOption) *SynchronizationJobClient {
+	return NewSynchronizationJobClient(s.clientOptions(opts)...)
+}
+
+// TermsOfUseAgreement returns a TermsOfUseAgreementClient using the shared configuration.
+func (s *ServiceClient) TermsOfUseAgreement(opts ...ClientOption) *TermsOfUseAgreementClient {
+	return NewTermsOfUseAgreementClient(s.clientOptions(opts)...)
+}
+
+// TokenIssuancePolicy returns a TokenIssuancePolicyClient using the shared configuration.
+func (s *ServiceClient) TokenIssuancePolicy(opts ...ClientOption) *TokenIssuancePolicyClient {
+	return NewTokenIssuancePolicyClient(s.clientOptions(opts)...)
+}
+
+// TokenLifetimePolicy returns a TokenLifetimePolicyClient using the shared configuration.
+func (s *ServiceClient) TokenLifetimePolicy(opts ...ClientOption) *TokenLifetimePolicyClient {
+	return NewTokenLifetimePolicyClient(s.clientOptions(opts)...)
+}
+
+// UserFlowAttributes returns a UserFlowAttributesClient using the shared configuration.
+func (s *ServiceClient) UserFlowAttributes(opts ...ClientOption) *UserFlowAttributesClient {
+	return NewUserFlowAttributesClient(s.clientOptions(opts)...)
+}
+
+// UsersAppRoleAssignments returns a AppRoleAssignmentsClient using the shared configuration.
+func (s *ServiceClient) UsersAppRoleAssignments(opts ...ClientOption) *AppRoleAssignmentsClient {
+	return NewUsersAppRoleAssignmentsClient(s.clientOptions(opts)...)
+}
+
+// Users returns a UsersClient using the shared configuration.
+func (s *ServiceClient) Users(opts ...ClientOption) *UsersClient {
+	return NewUsersClient(s.clientOptions(opts)...)
+}
+
+// WindowsAutopilotDeploymentProfiles returns a WindowsAutopilotDeploymentProfilesClient using the shared configuration.
+func (s *ServiceClient) WindowsAutopilotDeploymentProfiles(opts ...ClientOption) *WindowsAutopilotDeploymentProfilesClient {
+	return NewWindowsAutopilotDeploymentProfilesClient(s.clientOptions(opts)...)
+}
@@ -0,0 +1,63 @@
+package msgraph
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+)
+
+func TestServiceClient(t *testing.T) {
+	paths := make(chan string, 2)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ua := r.Header.Get("User-Agent"); ua != "test-agent" {
+			t.Errorf("got user agent %q, want %q", ua, "test-agent")
+		}
+		paths <- r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"value":[]}`))
+	}))
+	defer ts.Close()
+
+	svc := NewServiceClient(
+		WithEndpoint(ts.URL),
+		WithUserAgent("test-agent"),
+		WithRetryMax(3),
+		WithMaxConcurrentRequests(1),
+	)
+
+	users := svc.Users()
+	domains := svc.Domains()
+	if users.BaseClient.ApiVersion != VersionBeta || domains.BaseClient.ApiVersion != Version10 {
+		t.Fatalf("expected default API versions to be retained, got %q and %q", users.BaseClient.ApiVersion, domains.BaseClient.ApiVersion)
+	}
+	if users.BaseClient.RetryableClient != domains.BaseClient.RetryableClient {
+		t.Fatal("expected entity clients to share a retryable client")
+	}
+	if users.BaseClient.RetryableClient.RetryMax != 3 {
+		t.Fatalf("got RetryMax %d, want 3", users.BaseClient.RetryableClient.RetryMax)
+	}
+
+	groups := svc.Groups(WithApiVersion(Version10), WithRetryMax(1))
+	if groups.BaseClient.ApiVersion != Version10 {
+		t.Fatalf("got API version %q, want %q", groups.BaseClient.ApiVersion, Version10)
+	}
+	if svc.BaseClient().RetryableClient.RetryMax != 3 {
+		t.Fatal("expected client-specific options not to affect the shared configuration")
+	}
+
+	if _, _, err := users.List(context.Background(), odata.Query{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := groups.List(context.Background(), odata.Query{}); err != nil {
+		t.Fatal(err)
+	}
+	if p := <-paths; p != "/beta/users" {
+		t.Fatalf("got path %q, want %q", p, "/beta/users")
+	}
+	if p := <-paths; p != "/v1.0/groups" {
+		t.Fatalf("got path %q, want %q", p, "/v1.0/groups")
+	}
+}
@@ -17,9 +17,9 @@ type ServicePrincipalsClient struct {
 }
 
 // NewServicePrincipalsClient returns a new ServicePrincipalsClient.
-func NewServicePrincipalsClient() *ServicePrincipalsClient {
+func NewServicePrincipalsClient(opts ...ClientOption) *ServicePrincipalsClient {
 	return &ServicePrincipalsClient{
-		BaseClient: NewClient(VersionBeta),
+		BaseClient: NewClient(VersionBeta, opts...),
 	}
 }
 
@@ -57,6 +57,10 @@ func (c *ServicePrincipalsClient) List(ctx context.Context, query odata.Query) (
 func (c *ServicePrincipalsClient) Create(ctx context.Context, servicePrincipal ServicePrincipal) (*ServicePrincipal, int, error) {
 	var status int
 
+	if err := c.BaseClient.validateFields("ServicePrincipalsClient.Create()", servicePrincipal); err != nil {
+		return nil, status, err
+	}
+
 	body, err := json.Marshal(servicePrincipal)
 	if err != nil {
 		return nil, status, fmt.Errorf("json.Marshal(): %v", err)
@@ -139,6 +143,10 @@ func (c *ServicePrincipalsClient) Update(ctx context.Context, servicePrincipal S
 		return status, errors.New("cannot update service principal with nil ID")
 	}
 
+	if err := c.BaseClient.validateFields("ServicePrincipalsClient.Update()", servicePrincipal); err != nil {
+		return status, err
+	}
+
 	body, err := json.Marshal(servicePrincipal)
 	if err != nil {
 		return status, fmt.Errorf("json.Marshal(): %v", err)
@@ -159,6 +167,91 @@ func (c *ServicePrincipalsClient) Update(ctx context.Context, servicePrincipal S
 	return status, nil
 }
 
+// GetByAppId retrieves a Service Principal using its appId alternate key.
+func (c *ServicePrincipalsClient) GetByAppId(ctx context.Context, appId string, query odata.Query) (*ServicePrincipal, int, error) {
+	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		OData:                  query,
+		ValidStatusCodes:       []int{http.StatusOK},
+		Uri: Uri{
+			Entity: alternateKey("servicePrincipals", "appId", appId),
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("ServicePrincipalsClient.BaseClient.Get(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var servicePrincipal ServicePrincipal
+	if err := json.Unmarshal(respBody, &servicePrincipal); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &servicePrincipal, status, nil
+}
+
+// UpsertByAppId creates or updates the Service Principal for the appId of the provided service principal. The returned
+// bool is true when a new service principal was created, and false when an existing service principal was updated.
+// Either way, the returned ServicePrincipal is the service principal as it exists after the upsert.
+func (c *ServicePrincipalsClient) UpsertByAppId(ctx context.Context, servicePrincipal ServicePrincipal) (*ServicePrincipal, bool, int, error) {
+	var status int
+
+	if servicePrincipal.AppId == nil || *servicePrincipal.AppId == "" {
+		return nil, false, status, errors.New("ServicePrincipalsClient.UpsertByAppId(): cannot upsert service principal with nil or empty appId")
+	}
+
+	appId := *servicePrincipal.AppId
+	servicePrincipal.DirectoryObject = DirectoryObject{}
+	servicePrincipal.AppId = nil
+
+	if err := c.BaseClient.validateFields("ServicePrincipalsClient.UpsertByAppId()", servicePrincipal); err != nil {
+		return nil, false, status, err
+	}
+
+	body, err := json.Marshal(servicePrincipal)
+	if err != nil {
+		return nil, false, status, fmt.Errorf("json.Marshal(): %v", err)
+	}
+
+	resp, status, _, err := c.BaseClient.Patch(ctx, PatchHttpRequestInput{
+		Body:             body,
+		Prefer:           preferCreateIfMissing,
+		ValidStatusCodes: []int{http.StatusCreated, http.StatusNoContent},
+		Uri: Uri{
+			Entity: alternateKey("servicePrincipals", "appId", appId),
+		},
+	})
+	if err != nil {
+		return nil, false, status, fmt.Errorf("ServicePrincipalsClient.BaseClient.Patch(): %v", err)
+	}
+
+	if status == http.StatusNoContent {
+		updated, status, err := c.GetByAppId(ctx, appId, odata.Query{})
+		if err != nil {
+			return nil, false, status, fmt.Errorf("retrieving updated service principal: %v", err)
+		}
+		return updated, false, status, nil
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, true, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var newServicePrincipal ServicePrincipal
+	if err := json.Unmarshal(respBody, &newServicePrincipal); err != nil {
+		return nil, true, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &newServicePrincipal, true, status, nil
+}
+
 // Delete removes a Service Principal.
 func (c *ServicePrincipalsClient) Delete(ctx context.Context, id string) (int, error) {
 	_, status, _, err := c.BaseClient.Delete(ctx, DeleteHttpRequestInput{
@@ -563,6 +656,83 @@ func (c *ServicePrincipalsClient) RemovePassword(ctx context.Context, servicePri
 	return status, nil
 }
 
+// AddKey appends a new certificate credential to a Service Principal, optionally accompanied by a password credential when
+// the key type is X509CertAndPassword. proof is a token signed with an existing certificate of the service principal, as
+// built by NewProofOfPossessionToken. This enables a service principal to roll its own certificate without
+// additional privileges. To add the first certificate, use Update instead.
+func (c *ServicePrincipalsClient) AddKey(ctx context.Context, servicePrincipalId string, keyCredential KeyCredential, passwordCredential *PasswordCredential, proof string) (*KeyCredential, int, error) {
+	var status int
+
+	body, err := json.Marshal(struct {
+		KeyCredential      KeyCredential       `json:"keyCredential"`
+		PasswordCredential *PasswordCredential `json:"passwordCredential"`
+		Proof              string              `json:"proof"`
+	}{
+		KeyCredential:      keyCredential,
+		PasswordCredential: passwordCredential,
+		Proof:              proof,
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("json.Marshal(): %v", err)
+	}
+
+	resp, status, _, err := c.BaseClient.Post(ctx, PostHttpRequestInput{
+		Body:                   body,
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusOK, http.StatusCreated},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/servicePrincipals/%s/addKey", servicePrincipalId),
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("ServicePrincipalsClient.BaseClient.Post(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var newKeyCredential KeyCredential
+	if err := json.Unmarshal(respBody, &newKeyCredential); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &newKeyCredential, status, nil
+}
+
+// RemoveKey removes a certificate credential from a Service Principal. proof is a token signed with an existing certificate
+// of the service principal, as built by NewProofOfPossessionToken.
+func (c *ServicePrincipalsClient) RemoveKey(ctx context.Context, servicePrincipalId string, keyId string, proof string) (int, error) {
+	var status int
+
+	body, err := json.Marshal(struct {
+		KeyId string `json:"keyId"`
+		Proof string `json:"proof"`
+	}{
+		KeyId: keyId,
+		Proof: proof,
+	})
+	if err != nil {
+		return status, fmt.Errorf("json.Marshal(): %v", err)
+	}
+
+	_, status, _, err = c.BaseClient.Post(ctx, PostHttpRequestInput{
+		Body:                   body,
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusOK, http.StatusNoContent},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/servicePrincipals/%s/removeKey", servicePrincipalId),
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("ServicePrincipalsClient.BaseClient.Post(): %v", err)
+	}
+
+	return status, nil
+}
+
 // AddTokenSigningCertificate appends a new self signed certificate (keys and password) to a Service Principal.
 func (c *ServicePrincipalsClient) AddTokenSigningCertificate(ctx context.Context, servicePrincipalId string, keyCredential KeyCredential) (*KeyCredential, int, error) {
 	var status int
@@ -879,3 +1049,247 @@ func (c *ServicePrincipalsClient) RemoveTokenIssuancePolicy(ctx context.Context,
 
 	return status, nil
 }
+
+// AssignTokenLifetimePolicy assigns tokenLifetimePolicies to a service principal
+func (c *ServicePrincipalsClient) AssignTokenLifetimePolicy(ctx context.Context, servicePrincipalId string, policies *[]TokenLifetimePolicy) (int, error) {
+	var status int
+
+	if policies == nil {
+		return status, errors.New("cannot update service principal with nil TokenLifetimePolicies")
+	}
+
+	for _, policy := range *policies {
+		// don't fail if the policy is already assigned
+		checkPolicyAlreadyExists := func(resp *http.Response, o *odata.OData) bool {
+			if resp != nil && resp.StatusCode == http.StatusBadRequest && o != nil && o.Error != nil {
+				return o.Error.Match(odata.ErrorAddedObjectReferencesAlreadyExist)
+			}
+			return false
+		}
+
+		body, err := json.Marshal(DirectoryObject{ODataId: policy.ODataId})
+		if err != nil {
+			return status, fmt.Errorf("json.Marshal(): %v", err)
+		}
+
+		_, status, _, err = c.BaseClient.Post(ctx, PostHttpRequestInput{
+			Body:                   body,
+			ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+			ValidStatusCodes:       []int{http.StatusNoContent},
+			ValidStatusFunc:        checkPolicyAlreadyExists,
+			Uri: Uri{
+				Entity: fmt.Sprintf("/servicePrincipals/%s/tokenLifetimePolicies/$ref", servicePrincipalId),
+			},
+		})
+		if err != nil {
+			return status, fmt.Errorf("ServicePrincipalsClient.BaseClient.Post(): %v", err)
+		}
+	}
+
+	return status, nil
+}
+
+// ListTokenLifetimePolicy retrieves the tokenLifetimePolicies assigned to the specified ServicePrincipal.
+func (c *ServicePrincipalsClient) ListTokenLifetimePolicy(ctx context.Context, servicePrincipalId string) (*[]TokenLifetimePolicy, int, error) {
+	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusOK},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/servicePrincipals/%s/tokenLifetimePolicies", servicePrincipalId),
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("ServicePrincipalsClient.BaseClient.Get(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var data struct {
+		Policies []TokenLifetimePolicy `json:"value"`
+	}
+
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &data.Policies, status, nil
+}
+
+// RemoveTokenLifetimePolicy removes a tokenLifetimePolicy from a service principal
+func (c *ServicePrincipalsClient) RemoveTokenLifetimePolicy(ctx context.Context, servicePrincipalId string, policyIds *[]string) (int, error) {
+	var status int
+
+	if policyIds == nil {
+		return status, errors.New("cannot remove, nil TokenLifetimePolicyIds")
+	}
+
+	assignedPolicies, _, err := c.ListTokenLifetimePolicy(ctx, servicePrincipalId)
+	if err != nil {
+		return status, fmt.Errorf("ServicePrincipalsClient.BaseClient.ListTokenLifetimePolicy(): %v", err)
+	}
+
+	if len(*assignedPolicies) == 0 {
+		return http.StatusNoContent, nil
+	}
+
+	mapTokenLifetimePolicy := map[string]TokenLifetimePolicy{}
+	for _, v := range *assignedPolicies {
+		mapTokenLifetimePolicy[*v.ID()] = v
+	}
+
+	for _, policyId := range *policyIds {
+
+		// Check if policy is currently assigned
+		_, ok := mapTokenLifetimePolicy[policyId]
+		if !ok {
+			continue
+		}
+
+		checkPolicyStatus := func(resp *http.Response, o *odata.OData) bool {
+			if resp != nil && resp.StatusCode == http.StatusNotFound && o != nil && o.Error != nil {
+				return o.Error.Match(odata.ErrorResourceDoesNotExist)
+			}
+			return false
+		}
+
+		_, status, _, err = c.BaseClient.Delete(ctx, DeleteHttpRequestInput{
+			ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+			ValidStatusCodes:       []int{http.StatusNoContent},
+			ValidStatusFunc:        checkPolicyStatus,
+			Uri: Uri{
+				Entity: fmt.Sprintf("/servicePrincipals/%s/tokenLifetimePolicies/%s/$ref", servicePrincipalId, policyId),
+			},
+		})
+		if err != nil {
+			return status, fmt.Errorf("ServicePrincipalsClient.BaseClient.Delete(): %v", err)
+		}
+	}
+
+	return status, nil
+}
+
+// AssignHomeRealmDiscoveryPolicy assigns homeRealmDiscoveryPolicies to a service principal
+func (c *ServicePrincipalsClient) AssignHomeRealmDiscoveryPolicy(ctx context.Context, servicePrincipalId string, policies *[]HomeRealmDiscoveryPolicy) (int, error) {
+	var status int
+
+	if policies == nil {
+		return status, errors.New("cannot update service principal with nil HomeRealmDiscoveryPolicies")
+	}
+
+	for _, policy := range *policies {
+		// don't fail if the policy is already assigned
+		checkPolicyAlreadyExists := func(resp *http.Response, o *odata.OData) bool {
+			if resp != nil && resp.StatusCode == http.StatusBadRequest && o != nil && o.Error != nil {
+				return o.Error.Match(odata.ErrorAddedObjectReferencesAlreadyExist)
+			}
+			return false
+		}
+
+		body, err := json.Marshal(DirectoryObject{ODataId: policy.ODataId})
+		if err != nil {
+			return status, fmt.Errorf("json.Marshal(): %v", err)
+		}
+
+		_, status, _, err = c.BaseClient.Post(ctx, PostHttpRequestInput{
+			Body:                   body,
+			ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+			ValidStatusCodes:       []int{http.StatusNoContent},
+			ValidStatusFunc:        checkPolicyAlreadyExists,
+			Uri: Uri{
+				Entity: fmt.Sprintf("/servicePrincipals/%s/homeRealmDiscoveryPolicies/$ref", servicePrincipalId),
+			},
+		})
+		if err != nil {
+			return status, fmt.Errorf("ServicePrincipalsClient.BaseClient.Post(): %v", err)
+		}
+	}
+
+	return status, nil
+}
+
+// ListHomeRealmDiscoveryPolicy retrieves the homeRealmDiscoveryPolicies assigned to the specified ServicePrincipal.
+func (c *ServicePrincipalsClient) ListHomeRealmDiscoveryPolicy(ctx context.Context, servicePrincipalId string) (*[]HomeRealmDiscoveryPolicy, int, error) {
+	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusOK},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/servicePrincipals/%s/homeRealmDiscoveryPolicies", servicePrincipalId),
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("ServicePrincipalsClient.BaseClient.Get(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var data struct {
+		Policies []HomeRealmDiscoveryPolicy `json:"value"`
+	}
+
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &data.Policies, status, nil
+}
+
+// RemoveHomeRealmDiscoveryPolicy removes a homeRealmDiscoveryPolicy from a service principal
+func (c *ServicePrincipalsClient) RemoveHomeRealmDiscoveryPolicy(ctx context.Context, servicePrincipalId string, policyIds *[]string) (int, error) {
+	var status int
+
+	if policyIds == nil {
+		return status, errors.New("cannot remove, nil HomeRealmDiscoveryPolicyIds")
+	}
+
+	assignedPolicies, _, err := c.ListHomeRealmDiscoveryPolicy(ctx, servicePrincipalId)
+	if err != nil {
+		return status, fmt.Errorf("ServicePrincipalsClient.BaseClient.ListHomeRealmDiscoveryPolicy(): %v", err)
+	}
+
+	if len(*assignedPolicies) == 0 {
+		return http.StatusNoContent, nil
+	}
+
+	mapHomeRealmDiscoveryPolicy := map[string]HomeRealmDiscoveryPolicy{}
+	for _, v := range *assignedPolicies {
+		mapHomeRealmDiscoveryPolicy[*v.ID()] = v
+	}
+
+	for _, policyId := range *policyIds {
+
+		// Check if policy is currently assigned
+		_, ok := mapHomeRealmDiscoveryPolicy[policyId]
+		if !ok {
+			continue
+		}
+
+		checkPolicyStatus := func(resp *http.Response, o *odata.OData) bool {
+			if resp != nil && resp.StatusCode == http.StatusNotFound && o != nil && o.Error != nil {
+				return o.Error.Match(odata.ErrorResourceDoesNotExist)
+			}
+			return false
+		}
+
+		_, status, _, err = c.BaseClient.Delete(ctx, DeleteHttpRequestInput{
+			ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+			ValidStatusCodes:       []int{http.StatusNoContent},
+			ValidStatusFunc:        checkPolicyStatus,
+			Uri: Uri{
+				Entity: fmt.Sprintf("/servicePrincipals/%s/homeRealmDiscoveryPolicies/%s/$ref", servicePrincipalId, policyId),
+			},
+		})
+		if err != nil {
+			return status, fmt.Errorf("ServicePrincipalsClient.BaseClient.Delete(): %v", err)
+		}
+	}
+
+	return status, nil
+}
@@ -16,9 +16,9 @@ type SignInReportsClient struct {
 }
 
 // NewSignInReportsClient returns a new SignInReportsClient.
-func NewSignInReportsClient() *SignInReportsClient {
+func NewSignInReportsClient(opts ...ClientOption) *SignInReportsClient {
 	return &SignInReportsClient{
-		BaseClient: NewClient(VersionBeta),
+		BaseClient: NewClient(VersionBeta, opts...),
 	}
 }
 
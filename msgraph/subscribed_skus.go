@@ -0,0 +1,110 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+)
+
+// SubscribedSkusClient performs operations on the SubscribedSkus that an organization has acquired.
+type SubscribedSkusClient struct {
+	BaseClient Client
+}
+
+// NewSubscribedSkusClient returns a new SubscribedSkusClient.
+func NewSubscribedSkusClient(opts ...ClientOption) *SubscribedSkusClient {
+	return &SubscribedSkusClient{
+		BaseClient: NewClient(Version10, opts...),
+	}
+}
+
+// List returns a list of SubscribedSkus, optionally queried using OData.
+func (c *SubscribedSkusClient) List(ctx context.Context, query odata.Query) (*[]SubscribedSku, int, error) {
+	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+		DisablePaging:    query.Top > 0,
+		OData:            query,
+		ValidStatusCodes: []int{http.StatusOK},
+		Uri: Uri{
+			Entity: "/subscribedSkus",
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("SubscribedSkusClient.BaseClient.Get(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var data struct {
+		SubscribedSkus []SubscribedSku `json:"value"`
+	}
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &data.SubscribedSkus, status, nil
+}
+
+// Get retrieves a SubscribedSku. The ID is made up of the tenant ID and the SKU ID, i.e. "{tenantId}_{skuId}".
+func (c *SubscribedSkusClient) Get(ctx context.Context, id string, query odata.Query) (*SubscribedSku, int, error) {
+	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+		OData:            query,
+		ValidStatusCodes: []int{http.StatusOK},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/subscribedSkus/%s", id),
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("SubscribedSkusClient.BaseClient.Get(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var sku SubscribedSku
+	if err := json.Unmarshal(respBody, &sku); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &sku, status, nil
+}
+
+// ResolveSkuIds returns the SKU IDs for the specified SKU part numbers, i.e. "ENTERPRISEPACK", keyed by part number.
+// Part numbers are matched case-insensitively, and an error is returned if any are not subscribed to by the organization.
+func (c *SubscribedSkusClient) ResolveSkuIds(ctx context.Context, skuPartNumbers ...string) (map[string]string, int, error) {
+	skus, status, err := c.List(ctx, odata.Query{})
+	if err != nil {
+		return nil, status, err
+	}
+
+	skuIds := make(map[string]string, len(skuPartNumbers))
+	missing := make([]string, 0)
+	for _, partNumber := range skuPartNumbers {
+		for _, sku := range *skus {
+			if sku.SkuPartNumber != nil && sku.SkuId != nil && strings.EqualFold(*sku.SkuPartNumber, partNumber) {
+				skuIds[partNumber] = *sku.SkuId
+				break
+			}
+		}
+		if _, ok := skuIds[partNumber]; !ok {
+			missing = append(missing, partNumber)
+		}
+	}
+
+	if len(missing) > 0 {
+		return skuIds, status, fmt.Errorf("no subscribed SKUs found with part number(s): %s", strings.Join(missing, ", "))
+	}
+
+	return skuIds, status, nil
+}
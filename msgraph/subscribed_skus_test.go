@@ -0,0 +1,58 @@
+package msgraph_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+	"github.com/manicminer/hamilton/internal/test"
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+func TestSubscribedSkusClient(t *testing.T) {
+	c := test.NewTest(t)
+	defer c.CancelFunc()
+
+	skus := testSubscribedSkusClient_List(t, c)
+	if len(*skus) == 0 {
+		t.Skip("skipping as the tenant has no subscribed SKUs")
+	}
+
+	sku := testSubscribedSkusClient_Get(t, c, *(*skus)[0].ID)
+	testSubscribedSkusClient_ResolveSkuIds(t, c, *sku.SkuPartNumber)
+}
+
+func testSubscribedSkusClient_List(t *testing.T, c *test.Test) (skus *[]msgraph.SubscribedSku) {
+	skus, _, err := c.SubscribedSkusClient.List(c.Context, odata.Query{})
+	if err != nil {
+		t.Fatalf("SubscribedSkusClient.List(): %v", err)
+	}
+	if skus == nil {
+		t.Fatal("SubscribedSkusClient.List(): skus was nil")
+	}
+	return
+}
+
+func testSubscribedSkusClient_Get(t *testing.T, c *test.Test, id string) (sku *msgraph.SubscribedSku) {
+	sku, status, err := c.SubscribedSkusClient.Get(c.Context, id, odata.Query{})
+	if err != nil {
+		t.Fatalf("SubscribedSkusClient.Get(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("SubscribedSkusClient.Get(): invalid status: %d", status)
+	}
+	if sku == nil {
+		t.Fatal("SubscribedSkusClient.Get(): sku was nil")
+	}
+	return
+}
+
+func testSubscribedSkusClient_ResolveSkuIds(t *testing.T, c *test.Test, skuPartNumbers ...string) (skuIds map[string]string) {
+	skuIds, _, err := c.SubscribedSkusClient.ResolveSkuIds(c.Context, skuPartNumbers...)
+	if err != nil {
+		t.Fatalf("SubscribedSkusClient.ResolveSkuIds(): %v", err)
+	}
+	if len(skuIds) != len(skuPartNumbers) {
+		t.Fatalf("SubscribedSkusClient.ResolveSkuIds(): expected %d SKU IDs, got %d", len(skuPartNumbers), len(skuIds))
+	}
+	return
+}
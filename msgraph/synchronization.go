@@ -16,9 +16,9 @@ type SynchronizationJobClient struct {
 }
 
 // NewSynchronizationJobClient returns a new SynchronizationJobClient
-func NewSynchronizationJobClient() *SynchronizationJobClient {
+func NewSynchronizationJobClient(opts ...ClientOption) *SynchronizationJobClient {
 	return &SynchronizationJobClient{
-		BaseClient: NewClient(VersionBeta),
+		BaseClient: NewClient(VersionBeta, opts...),
 	}
 }
 
@@ -16,9 +16,9 @@ type TermsOfUseAgreementClient struct {
 }
 
 // NewTermsOfUseAgreementClient returns a new TermsOfUseAgreementClient
-func NewTermsOfUseAgreementClient() *TermsOfUseAgreementClient {
+func NewTermsOfUseAgreementClient(opts ...ClientOption) *TermsOfUseAgreementClient {
 	return &TermsOfUseAgreementClient{
-		BaseClient: NewClient(VersionBeta),
+		BaseClient: NewClient(VersionBeta, opts...),
 	}
 }
 
@@ -15,9 +15,9 @@ type TokenIssuancePolicyClient struct {
 }
 
 // NewTokenIssuancePolicyClient returns a new TokenIssuancePolicyClient
-func NewTokenIssuancePolicyClient() *TokenIssuancePolicyClient {
+func NewTokenIssuancePolicyClient(opts ...ClientOption) *TokenIssuancePolicyClient {
 	return &TokenIssuancePolicyClient{
-		BaseClient: NewClient(Version10),
+		BaseClient: NewClient(Version10, opts...),
 	}
 }
 
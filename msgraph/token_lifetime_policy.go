@@ -0,0 +1,201 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+)
+
+// TokenLifetimePolicyClient performs operations on TokenLifetimePolicies.
+type TokenLifetimePolicyClient struct {
+	BaseClient Client
+}
+
+// NewTokenLifetimePolicyClient returns a new TokenLifetimePolicyClient
+func NewTokenLifetimePolicyClient(opts ...ClientOption) *TokenLifetimePolicyClient {
+	return &TokenLifetimePolicyClient{
+		BaseClient: NewClient(Version10, opts...),
+	}
+}
+
+// Create creates a new TokenLifetimePolicy.
+func (c *TokenLifetimePolicyClient) Create(ctx context.Context, policy TokenLifetimePolicy) (*TokenLifetimePolicy, int, error) {
+	var status int
+
+	body, err := json.Marshal(policy)
+	if err != nil {
+		return nil, status, fmt.Errorf("json.Marshal(): %v", err)
+	}
+
+	resp, status, _, err := c.BaseClient.Post(ctx, PostHttpRequestInput{
+		Body:             body,
+		OData:            odata.Query{Metadata: odata.MetadataFull},
+		ValidStatusCodes: []int{http.StatusCreated},
+		Uri: Uri{
+			Entity: "/policies/tokenLifetimePolicies",
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("TokenLifetimePolicyClient.BaseClient.Post(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var newPolicy TokenLifetimePolicy
+	if err := json.Unmarshal(respBody, &newPolicy); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &newPolicy, status, nil
+}
+
+// List returns a list of TokenLifetimePolicy, optionally queried using OData.
+func (c *TokenLifetimePolicyClient) List(ctx context.Context, query odata.Query) (*[]TokenLifetimePolicy, int, error) {
+	query.Metadata = odata.MetadataFull
+
+	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+		DisablePaging:    query.Top > 0,
+		OData:            query,
+		ValidStatusCodes: []int{http.StatusOK},
+		Uri: Uri{
+			Entity: "/policies/tokenLifetimePolicies",
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("TokenLifetimePolicyClient.BaseClient.Get(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var data struct {
+		TokenLifetimePolicies []TokenLifetimePolicy `json:"value"`
+	}
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &data.TokenLifetimePolicies, status, nil
+}
+
+// Get retrieves a TokenLifetimePolicy.
+func (c *TokenLifetimePolicyClient) Get(ctx context.Context, id string, query odata.Query) (*TokenLifetimePolicy, int, error) {
+	query.Metadata = odata.MetadataFull
+
+	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		OData:                  query,
+		ValidStatusCodes:       []int{http.StatusOK},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/policies/tokenLifetimePolicies/%s", id),
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("TokenLifetimePolicyClient.BaseClient.Get(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var tokenLifetimePolicy TokenLifetimePolicy
+	if err := json.Unmarshal(respBody, &tokenLifetimePolicy); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &tokenLifetimePolicy, status, nil
+}
+
+// Update amends an existing TokenLifetimePolicy.
+func (c *TokenLifetimePolicyClient) Update(ctx context.Context, tokenLifetimePolicy TokenLifetimePolicy) (int, error) {
+	var status int
+
+	if tokenLifetimePolicy.ID() == nil {
+		return status, fmt.Errorf("cannot update TokenLifetimePolicy with nil ID")
+	}
+
+	tokenLifetimePolicyId := *tokenLifetimePolicy.ID()
+	tokenLifetimePolicy.Id = nil
+	tokenLifetimePolicy.ObjectId = nil
+
+	body, err := json.Marshal(tokenLifetimePolicy)
+	if err != nil {
+		return status, fmt.Errorf("json.Marshal(): %v", err)
+	}
+
+	_, status, _, err = c.BaseClient.Patch(ctx, PatchHttpRequestInput{
+		Body:                   body,
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes: []int{
+			http.StatusOK,
+			http.StatusNoContent,
+		},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/policies/tokenLifetimePolicies/%s", tokenLifetimePolicyId),
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("TokenLifetimePolicyClient.BaseClient.Patch(): %v", err)
+	}
+
+	return status, nil
+}
+
+// Delete removes a TokenLifetimePolicy.
+func (c *TokenLifetimePolicyClient) Delete(ctx context.Context, id string) (int, error) {
+	_, status, _, err := c.BaseClient.Delete(ctx, DeleteHttpRequestInput{
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusNoContent},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/policies/tokenLifetimePolicies/%s", id),
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("TokenLifetimePolicyClient.BaseClient.Delete(): %v", err)
+	}
+
+	return status, nil
+}
+
+// ListAppliesTo returns the directory objects, such as Applications and Service Principals, that a TokenLifetimePolicy is
+// assigned to.
+func (c *TokenLifetimePolicyClient) ListAppliesTo(ctx context.Context, id string) (*[]DirectoryObject, int, error) {
+	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusOK},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/policies/tokenLifetimePolicies/%s/appliesTo", id),
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("TokenLifetimePolicyClient.BaseClient.Get(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var data struct {
+		DirectoryObjects []DirectoryObject `json:"value"`
+	}
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &data.DirectoryObjects, status, nil
+}
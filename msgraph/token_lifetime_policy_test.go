@@ -0,0 +1,217 @@
+package msgraph_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+	"github.com/manicminer/hamilton/internal/test"
+	"github.com/manicminer/hamilton/internal/utils"
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+func TestTokenLifetimePolicyClient(t *testing.T) {
+	c := test.NewTest(t)
+	defer c.CancelFunc()
+
+	definition, err := msgraph.TokenLifetimePolicyDefinition{AccessTokenLifetime: 2 * time.Hour}.Definition()
+	if err != nil {
+		t.Fatalf("TokenLifetimePolicyDefinition.Definition(): %v", err)
+	}
+	policy := testTokenLifetimePolicyClient_Create(t, c, msgraph.TokenLifetimePolicy{
+		DisplayName: utils.StringPtr(fmt.Sprintf("test-token-lifetime-policy-%s", c.RandomString)),
+		Definition:  definition,
+	})
+	testTokenLifetimePolicyClient_List(t, c)
+	testTokenLifetimePolicyClient_Get(t, c, *policy.ID())
+	policy.DisplayName = utils.StringPtr(fmt.Sprintf("test-token-lifetime-policy-updated-%s", c.RandomString))
+	testTokenLifetimePolicyClient_Update(t, c, *policy)
+
+	app := testApplicationsClient_Create(t, c, msgraph.Application{
+		DisplayName: utils.StringPtr(fmt.Sprintf("test-token-lifetime-policy-%s", c.RandomString)),
+	})
+	sp := testServicePrincipalsClient_Create(t, c, msgraph.ServicePrincipal{
+		AccountEnabled: utils.BoolPtr(true),
+		AppId:          app.AppId,
+		DisplayName:    app.DisplayName,
+	})
+
+	testApplicationsClient_AssignTokenLifetimePolicy(t, c, *app.ID(), *policy)
+	testApplicationsClient_ListTokenLifetimePolicy(t, c, *app.ID(), []string{*policy.ID()})
+	testServicePrincipalsClient_AssignTokenLifetimePolicy(t, c, *sp.ID(), *policy)
+	testServicePrincipalsClient_ListTokenLifetimePolicy(t, c, *sp.ID(), []string{*policy.ID()})
+	testTokenLifetimePolicyClient_ListAppliesTo(t, c, *policy.ID(), []string{*app.ID(), *sp.ID()})
+
+	testApplicationsClient_RemoveTokenLifetimePolicy(t, c, *app.ID(), []string{*policy.ID()})
+	testApplicationsClient_ListTokenLifetimePolicy(t, c, *app.ID(), []string{})
+	testServicePrincipalsClient_RemoveTokenLifetimePolicy(t, c, *sp.ID(), []string{*policy.ID()})
+	// A second call tests that a remove call on an empty assignment list returns ok
+	testServicePrincipalsClient_RemoveTokenLifetimePolicy(t, c, *sp.ID(), []string{*policy.ID()})
+	testServicePrincipalsClient_ListTokenLifetimePolicy(t, c, *sp.ID(), []string{})
+
+	testServicePrincipalsClient_Delete(t, c, *sp.ID())
+	testApplicationsClient_Delete(t, c, *app.ID())
+	testTokenLifetimePolicyClient_Delete(t, c, *policy.ID())
+}
+
+func testTokenLifetimePolicyClient_Create(t *testing.T, c *test.Test, p msgraph.TokenLifetimePolicy) (policy *msgraph.TokenLifetimePolicy) {
+	policy, status, err := c.TokenLifetimePolicyClient.Create(c.Context, p)
+	if err != nil {
+		t.Fatalf("TokenLifetimePolicyClient.Create(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("TokenLifetimePolicyClient.Create(): invalid status: %d", status)
+	}
+	if policy == nil {
+		t.Fatal("TokenLifetimePolicyClient.Create(): policy was nil")
+	}
+	if policy.ID() == nil {
+		t.Fatal("TokenLifetimePolicyClient.Create(): policy.ID was nil")
+	}
+	return
+}
+
+func testTokenLifetimePolicyClient_List(t *testing.T, c *test.Test) (policies *[]msgraph.TokenLifetimePolicy) {
+	policies, _, err := c.TokenLifetimePolicyClient.List(c.Context, odata.Query{Top: 10})
+	if err != nil {
+		t.Fatalf("TokenLifetimePolicyClient.List(): %v", err)
+	}
+	if policies == nil {
+		t.Fatal("TokenLifetimePolicyClient.List(): policies was nil")
+	}
+	return
+}
+
+func testTokenLifetimePolicyClient_Get(t *testing.T, c *test.Test, id string) (policy *msgraph.TokenLifetimePolicy) {
+	policy, status, err := c.TokenLifetimePolicyClient.Get(c.Context, id, odata.Query{})
+	if err != nil {
+		t.Fatalf("TokenLifetimePolicyClient.Get(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("TokenLifetimePolicyClient.Get(): invalid status: %d", status)
+	}
+	if policy == nil {
+		t.Fatal("TokenLifetimePolicyClient.Get(): policy was nil")
+	}
+	return
+}
+
+func testTokenLifetimePolicyClient_Update(t *testing.T, c *test.Test, p msgraph.TokenLifetimePolicy) {
+	status, err := c.TokenLifetimePolicyClient.Update(c.Context, p)
+	if err != nil {
+		t.Fatalf("TokenLifetimePolicyClient.Update(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("TokenLifetimePolicyClient.Update(): invalid status: %d", status)
+	}
+}
+
+func testTokenLifetimePolicyClient_ListAppliesTo(t *testing.T, c *test.Test, id string, expected []string) {
+	objects, _, err := c.TokenLifetimePolicyClient.ListAppliesTo(c.Context, id)
+	if err != nil {
+		t.Fatalf("TokenLifetimePolicyClient.ListAppliesTo(): %v", err)
+	}
+	if objects == nil {
+		t.Fatal("TokenLifetimePolicyClient.ListAppliesTo(): objects was nil")
+	}
+	testPolicyObjectIds(t, "TokenLifetimePolicyClient.ListAppliesTo()", *objects, expected)
+}
+
+func testTokenLifetimePolicyClient_Delete(t *testing.T, c *test.Test, id string) {
+	status, err := c.TokenLifetimePolicyClient.Delete(c.Context, id)
+	if err != nil {
+		t.Fatalf("TokenLifetimePolicyClient.Delete(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("TokenLifetimePolicyClient.Delete(): invalid status: %d", status)
+	}
+}
+
+func testApplicationsClient_AssignTokenLifetimePolicy(t *testing.T, c *test.Test, id string, policy msgraph.TokenLifetimePolicy) {
+	status, err := c.ApplicationsClient.AssignTokenLifetimePolicy(c.Context, id, &[]msgraph.TokenLifetimePolicy{policy})
+	if err != nil {
+		t.Fatalf("ApplicationsClient.AssignTokenLifetimePolicy(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("ApplicationsClient.AssignTokenLifetimePolicy(): invalid status: %d", status)
+	}
+}
+
+func testApplicationsClient_ListTokenLifetimePolicy(t *testing.T, c *test.Test, id string, expected []string) {
+	policies, _, err := c.ApplicationsClient.ListTokenLifetimePolicy(c.Context, id)
+	if err != nil {
+		t.Fatalf("ApplicationsClient.ListTokenLifetimePolicy(): %v", err)
+	}
+	if policies == nil {
+		t.Fatal("ApplicationsClient.ListTokenLifetimePolicy(): policies was nil")
+	}
+	objects := make([]msgraph.DirectoryObject, 0, len(*policies))
+	for _, p := range *policies {
+		objects = append(objects, p.DirectoryObject)
+	}
+	testPolicyObjectIds(t, "ApplicationsClient.ListTokenLifetimePolicy()", objects, expected)
+}
+
+func testApplicationsClient_RemoveTokenLifetimePolicy(t *testing.T, c *test.Test, id string, policyIds []string) {
+	status, err := c.ApplicationsClient.RemoveTokenLifetimePolicy(c.Context, id, &policyIds)
+	if err != nil {
+		t.Fatalf("ApplicationsClient.RemoveTokenLifetimePolicy(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("ApplicationsClient.RemoveTokenLifetimePolicy(): invalid status: %d", status)
+	}
+}
+
+func testServicePrincipalsClient_AssignTokenLifetimePolicy(t *testing.T, c *test.Test, id string, policy msgraph.TokenLifetimePolicy) {
+	status, err := c.ServicePrincipalsClient.AssignTokenLifetimePolicy(c.Context, id, &[]msgraph.TokenLifetimePolicy{policy})
+	if err != nil {
+		t.Fatalf("ServicePrincipalsClient.AssignTokenLifetimePolicy(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("ServicePrincipalsClient.AssignTokenLifetimePolicy(): invalid status: %d", status)
+	}
+}
+
+func testServicePrincipalsClient_ListTokenLifetimePolicy(t *testing.T, c *test.Test, id string, expected []string) {
+	policies, _, err := c.ServicePrincipalsClient.ListTokenLifetimePolicy(c.Context, id)
+	if err != nil {
+		t.Fatalf("ServicePrincipalsClient.ListTokenLifetimePolicy(): %v", err)
+	}
+	if policies == nil {
+		t.Fatal("ServicePrincipalsClient.ListTokenLifetimePolicy(): policies was nil")
+	}
+	objects := make([]msgraph.DirectoryObject, 0, len(*policies))
+	for _, p := range *policies {
+		objects = append(objects, p.DirectoryObject)
+	}
+	testPolicyObjectIds(t, "ServicePrincipalsClient.ListTokenLifetimePolicy()", objects, expected)
+}
+
+func testServicePrincipalsClient_RemoveTokenLifetimePolicy(t *testing.T, c *test.Test, id string, policyIds []string) {
+	status, err := c.ServicePrincipalsClient.RemoveTokenLifetimePolicy(c.Context, id, &policyIds)
+	if err != nil {
+		t.Fatalf("ServicePrincipalsClient.RemoveTokenLifetimePolicy(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("ServicePrincipalsClient.RemoveTokenLifetimePolicy(): invalid status: %d", status)
+	}
+}
+
+// testPolicyObjectIds checks that objects contains exactly the expected object IDs, in any order.
+func testPolicyObjectIds(t *testing.T, method string, objects []msgraph.DirectoryObject, expected []string) {
+	if len(objects) != len(expected) {
+		t.Fatalf("%s: expected %d objects, got %d", method, len(expected), len(objects))
+	}
+	found := make(map[string]bool, len(objects))
+	for _, o := range objects {
+		if id := o.ID(); id != nil {
+			found[*id] = true
+		}
+	}
+	for _, id := range expected {
+		if !found[id] {
+			t.Fatalf("%s: expected object %q was not returned", method, id)
+		}
+	}
+}
@@ -0,0 +1,16 @@
+package msgraph
+
+import (
+	"fmt"
+	"strings"
+)
+
+// preferCreateIfMissing is the Prefer header value which causes a PATCH request addressed by an alternate key to create
+// the entity when it does not exist.
+const preferCreateIfMissing = "create-if-missing"
+
+// alternateKey returns an entity path segment which addresses an entity by an alternate key, e.g.
+// applications(uniqueName='my-app').
+func alternateKey(collection, property, value string) string {
+	return fmt.Sprintf("/%s(%s='%s')", collection, property, strings.ReplaceAll(value, "'", "''"))
+}
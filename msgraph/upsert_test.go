@@ -0,0 +1,106 @@
+package msgraph
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/manicminer/hamilton/internal/utils"
+)
+
+func TestApplicationsClient_Upsert(t *testing.T) {
+	exists := false
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/beta/applications(uniqueName='payroll''s-api')" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case http.MethodPatch:
+			if prefer := r.Header.Get("Prefer"); prefer != "create-if-missing" {
+				t.Errorf("expected Prefer header create-if-missing, got %q", prefer)
+			}
+			body, _ := io.ReadAll(r.Body)
+			if strings.Contains(string(body), "uniqueName") || strings.Contains(string(body), `"id"`) {
+				t.Errorf("unexpected key in request body: %s", body)
+			}
+			if exists {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			exists = true
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":"app-1","uniqueName":"payroll's-api","displayName":"Payroll"}`))
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id":"app-1","uniqueName":"payroll's-api","displayName":"Payroll v2"}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewApplicationsClient(WithEndpoint(ts.URL), WithRetryMax(0))
+	application := Application{
+		DirectoryObject: DirectoryObject{Id: utils.StringPtr("ignored")},
+		DisplayName:     utils.StringPtr("Payroll"),
+		UniqueName:      utils.StringPtr("payroll's-api"),
+	}
+
+	app, created, status, err := client.Upsert(context.Background(), application)
+	if err != nil {
+		t.Fatalf("Upsert(): %v", err)
+	}
+	if !created || status != http.StatusCreated || app == nil || *app.ID() != "app-1" {
+		t.Fatalf("expected application to be created, got created=%t status=%d app=%+v", created, status, app)
+	}
+
+	application.DisplayName = utils.StringPtr("Payroll v2")
+	app, created, _, err = client.Upsert(context.Background(), application)
+	if err != nil {
+		t.Fatalf("Upsert(): %v", err)
+	}
+	if created || app == nil || *app.DisplayName != "Payroll v2" {
+		t.Fatalf("expected application to be updated, got created=%t app=%+v", created, app)
+	}
+
+	if _, _, _, err := client.Upsert(context.Background(), Application{DisplayName: utils.StringPtr("Payroll")}); err == nil {
+		t.Fatalf("expected an error when upserting an application without a uniqueName")
+	}
+}
+
+func TestServicePrincipalsClient_UpsertByAppId(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/beta/servicePrincipals(appId='11111111-2222-3333-4444-555555555555')" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case http.MethodPatch:
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id":"sp-1","appId":"11111111-2222-3333-4444-555555555555","appRoleAssignmentRequired":true}`))
+		}
+	}))
+	defer ts.Close()
+
+	client := NewServicePrincipalsClient(WithEndpoint(ts.URL), WithRetryMax(0))
+	sp, created, _, err := client.UpsertByAppId(context.Background(), ServicePrincipal{
+		AppId:                     utils.StringPtr("11111111-2222-3333-4444-555555555555"),
+		AppRoleAssignmentRequired: utils.BoolPtr(true),
+	})
+	if err != nil {
+		t.Fatalf("UpsertByAppId(): %v", err)
+	}
+	if created || sp == nil || *sp.ID() != "sp-1" {
+		t.Fatalf("expected service principal to be updated, got created=%t sp=%+v", created, sp)
+	}
+}
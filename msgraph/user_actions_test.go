@@ -0,0 +1,177 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestUsersClient_ResetPassword(t *testing.T) {
+	var mutex sync.Mutex
+	polls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/beta/users/user-id/authentication/passwordMethods/"+PasswordAuthenticationMethodId+"/resetPassword":
+			body, _ := io.ReadAll(r.Body)
+			if string(body) != `{}` {
+				t.Errorf("unexpected request body: %s", body)
+			}
+			w.Header().Set("Location", "https://graph.microsoft.com/beta/users/user-id/authentication/operations/op-id")
+			w.Header().Set("Retry-After", "0")
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"newPassword":"Generated123!"}`))
+
+		case r.Method == http.MethodGet && r.URL.Path == "/beta/users/user-id/authentication/operations/op-id":
+			polls++
+			status := LongRunningOperationStatusRunning
+			if polls == 3 {
+				status = LongRunningOperationStatusSucceeded
+			}
+			w.Header().Set("Retry-After", "0")
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(LongRunningOperation{ID: &[]string{"op-id"}[0], Status: &status})
+
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c := NewUsersClient(WithEndpoint(ts.URL), WithRetryMax(0))
+	result, _, err := c.ResetPassword(context.Background(), "user-id", nil)
+	if err != nil {
+		t.Fatalf("ResetPassword(): %v", err)
+	}
+	if result.NewPassword == nil || *result.NewPassword != "Generated123!" {
+		t.Fatalf("unexpected new password: %v", result.NewPassword)
+	}
+	if result.Operation == nil || *result.Operation.Status != LongRunningOperationStatusSucceeded {
+		t.Fatalf("expected a succeeded operation, got %+v", result.Operation)
+	}
+	if polls != 3 {
+		t.Fatalf("got %d polls, want 3", polls)
+	}
+
+	if _, _, err := NewUsersClient(WithApiVersion(Version10)).ResetPassword(context.Background(), "user-id", nil); err == nil {
+		t.Fatal("expected an error when using the v1.0 API")
+	}
+}
+
+func TestUsersClient_Contain(t *testing.T) {
+	var mutex sync.Mutex
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		switch r.Method + " " + r.URL.Path {
+		case "PATCH /beta/users/user-id":
+			var user User
+			if err := json.NewDecoder(r.Body).Decode(&user); err != nil {
+				t.Errorf("json.Decode(): %v", err)
+			}
+			if user.AccountEnabled == nil || *user.AccountEnabled {
+				t.Errorf("expected the account to be disabled, got %v", user.AccountEnabled)
+			}
+			w.WriteHeader(http.StatusNoContent)
+
+		case "POST /beta/users/user-id/revokeSignInSessions":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"value":true}`))
+
+		case "GET /beta/users/user-id/authentication/methods":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"value":[
+				{"@odata.type":"#microsoft.graph.passwordAuthenticationMethod","id":"` + PasswordAuthenticationMethodId + `"},
+				{"@odata.type":"#microsoft.graph.phoneAuthenticationMethod","id":"phone-id","phoneNumber":"+1 5555551234"},
+				{"@odata.type":"#microsoft.graph.microsoftAuthenticatorAuthenticationMethod","id":"authenticator-id"},
+				{"@odata.type":"#microsoft.graph.softwareOathAuthenticationMethod","id":"oath-id"}
+			]}`))
+
+		case "DELETE /beta/users/user-id/authentication/phoneMethods/phone-id":
+			w.WriteHeader(http.StatusNoContent)
+
+		case "DELETE /beta/users/user-id/authentication/microsoftAuthenticatorMethods/authenticator-id":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"error":{"code":"accessDenied","message":"Request Authorization failed"}}`))
+
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c := NewUsersClient(WithEndpoint(ts.URL), WithRetryMax(0))
+	report, status, err := c.Contain(context.Background(), "user-id")
+	if err == nil {
+		t.Fatal("expected an error for the failed step")
+	}
+	if status != http.StatusForbidden {
+		t.Fatalf("got status %d, want %d", status, http.StatusForbidden)
+	}
+
+	expected := []struct {
+		action ContainmentAction
+		target string
+		status ContainmentStepStatus
+	}{
+		{ContainmentActionDisableAccount, "user-id", ContainmentStepStatusSucceeded},
+		{ContainmentActionRevokeSignInSessions, "user-id", ContainmentStepStatusSucceeded},
+		{ContainmentActionDeleteAuthenticationMethod, "phone-id", ContainmentStepStatusSucceeded},
+		{ContainmentActionDeleteAuthenticationMethod, "authenticator-id", ContainmentStepStatusFailed},
+		{ContainmentActionDeleteAuthenticationMethod, "oath-id", ContainmentStepStatusSkipped},
+	}
+	if len(report.Steps) != len(expected) {
+		t.Fatalf("got %d steps, want %d: %+v", len(report.Steps), len(expected), report.Steps)
+	}
+	for i, want := range expected {
+		step := report.Steps[i]
+		if step.Action != want.action || step.Target != want.target || step.Status != want.status {
+			t.Errorf("step %d: got %s %s %s, want %s %s %s", i, step.Action, step.Target, step.Status, want.action, want.target, want.status)
+		}
+	}
+	if failed := report.Failed(); len(failed) != 1 || failed[0].Err == nil {
+		t.Fatalf("expected a single failed step with an error, got %+v", failed)
+	}
+	if skipped := report.Skipped(); len(skipped) != 1 || skipped[0].MethodType != "#microsoft.graph.softwareOathAuthenticationMethod" {
+		t.Fatalf("expected the software OATH method to be skipped, got %+v", skipped)
+	}
+}
+
+func TestUsersClient_ContainSkipped(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "PATCH /beta/users/user-id":
+			w.WriteHeader(http.StatusNoContent)
+		case "POST /beta/users/user-id/revokeSignInSessions":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"value":true}`))
+		case "GET /beta/users/user-id/authentication/methods":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"value":[{"@odata.type":"#microsoft.graph.softwareOathAuthenticationMethod","id":"oath-id"}]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	// a factor remaining on the account must not be reported as success
+	report, _, err := NewUsersClient(WithEndpoint(ts.URL), WithRetryMax(0)).Contain(context.Background(), "user-id")
+	if err == nil {
+		t.Fatal("expected an error for the skipped authentication method")
+	}
+	if len(report.Failed()) != 0 || len(report.Skipped()) != 1 {
+		t.Fatalf("unexpected report: %+v", report.Steps)
+	}
+}
@@ -16,9 +16,9 @@ type UserFlowAttributesClient struct {
 }
 
 // NewUserFlowAttributesClient returns a new UserFlowAttributesClient.
-func NewUserFlowAttributesClient() *UserFlowAttributesClient {
+func NewUserFlowAttributesClient(opts ...ClientOption) *UserFlowAttributesClient {
 	return &UserFlowAttributesClient{
-		BaseClient: NewClient(Version10),
+		BaseClient: NewClient(Version10, opts...).withFeature(FeatureB2C),
 	}
 }
 
@@ -6,19 +6,30 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"path"
+	"strconv"
+	"time"
 
 	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+	"github.com/manicminer/hamilton/internal/utils"
 )
 
+// PasswordAuthenticationMethodId is the ID of the password authentication method, which is the same for every user.
+const PasswordAuthenticationMethodId = "28c10230-6103-485e-b985-444c60001490"
+
+// passwordResetPollInterval is the interval between polls of a password reset operation, when the service does not
+// specify one using the Retry-After header.
+const passwordResetPollInterval = 5 * time.Second
+
 // UsersClient performs operations on Users.
 type UsersClient struct {
 	BaseClient Client
 }
 
 // NewUsersClient returns a new UsersClient.
-func NewUsersClient() *UsersClient {
+func NewUsersClient(opts ...ClientOption) *UsersClient {
 	return &UsersClient{
-		BaseClient: NewClient(VersionBeta),
+		BaseClient: NewClient(VersionBeta, opts...),
 	}
 }
 
@@ -56,6 +67,10 @@ func (c *UsersClient) List(ctx context.Context, query odata.Query) (*[]User, int
 func (c *UsersClient) Create(ctx context.Context, user User) (*User, int, error) {
 	var status int
 
+	if err := c.BaseClient.validateFields("UsersClient.Create()", user); err != nil {
+		return nil, status, err
+	}
+
 	body, err := json.Marshal(user)
 	if err != nil {
 		return nil, status, fmt.Errorf("json.Marshal(): %v", err)
@@ -201,6 +216,10 @@ func (c *UsersClient) GetDeleted(ctx context.Context, id string, query odata.Que
 func (c *UsersClient) Update(ctx context.Context, user User) (int, error) {
 	var status int
 
+	if err := c.BaseClient.validateFields("UsersClient.Update()", user); err != nil {
+		return status, err
+	}
+
 	body, err := json.Marshal(user)
 	if err != nil {
 		return status, fmt.Errorf("json.Marshal(): %v", err)
@@ -441,3 +460,497 @@ func (c *UsersClient) UploadThumbnailPhoto(ctx context.Context, userId, contentT
 	}
 	return status, nil
 }
+
+// AssignLicense adds and removes license assignments for the specified user. Service plans can be disabled for each
+// added license using DisabledPlans. Removed licenses are specified by SKU ID.
+func (c *UsersClient) AssignLicense(ctx context.Context, id string, addLicenses []AssignedLicense, removeLicenses []string) (*User, int, error) {
+	var status int
+
+	if addLicenses == nil {
+		addLicenses = []AssignedLicense{}
+	}
+	if removeLicenses == nil {
+		removeLicenses = []string{}
+	}
+
+	body, err := json.Marshal(struct {
+		AddLicenses    []AssignedLicense `json:"addLicenses"`
+		RemoveLicenses []string          `json:"removeLicenses"`
+	}{
+		AddLicenses:    addLicenses,
+		RemoveLicenses: removeLicenses,
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("json.Marshal(): %v", err)
+	}
+
+	resp, status, _, err := c.BaseClient.Post(ctx, PostHttpRequestInput{
+		Body:                   body,
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusOK},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/users/%s/assignLicense", id),
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("UsersClient.BaseClient.Post(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var user User
+	if err := json.Unmarshal(respBody, &user); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &user, status, nil
+}
+
+// ListLicenseDetails returns the licenses assigned to the specified user, along with the provisioning status of their service plans.
+func (c *UsersClient) ListLicenseDetails(ctx context.Context, id string) (*[]LicenseDetails, int, error) {
+	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusOK},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/users/%s/licenseDetails", id),
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("UsersClient.BaseClient.Get(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var data struct {
+		LicenseDetails []LicenseDetails `json:"value"`
+	}
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &data.LicenseDetails, status, nil
+}
+
+// RevokeSignInSessions invalidates all refresh tokens and session cookies issued to the specified user, requiring them
+// to sign in again. There may be a delay of a few minutes before tokens are revoked.
+func (c *UsersClient) RevokeSignInSessions(ctx context.Context, id string) (int, error) {
+	return c.invalidateTokens(ctx, "revokeSignInSessions", id)
+}
+
+// InvalidateAllRefreshTokens invalidates all refresh tokens issued to applications for the specified user. New
+// integrations should use RevokeSignInSessions, which also invalidates session cookies.
+// This operation is only available in the beta API.
+func (c *UsersClient) InvalidateAllRefreshTokens(ctx context.Context, id string) (int, error) {
+	if err := c.BaseClient.requireBeta("UsersClient.InvalidateAllRefreshTokens()"); err != nil {
+		return 0, err
+	}
+	return c.invalidateTokens(ctx, "invalidateAllRefreshTokens", id)
+}
+
+func (c *UsersClient) invalidateTokens(ctx context.Context, action, id string) (int, error) {
+	resp, status, _, err := c.BaseClient.Post(ctx, PostHttpRequestInput{
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes: []int{
+			http.StatusOK,
+			http.StatusNoContent,
+		},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/users/%s/%s", id, action),
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("UsersClient.BaseClient.Post(): %v", err)
+	}
+
+	if status == http.StatusNoContent {
+		return status, nil
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var data struct {
+		Value bool `json:"value"`
+	}
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+	if !data.Value {
+		return status, fmt.Errorf("UsersClient.%s(): tokens for user %q were not invalidated", action, id)
+	}
+
+	return status, nil
+}
+
+// ChangePassword changes the password of the signed-in user. This requires delegated authentication, as the current
+// password must be provided.
+func (c *UsersClient) ChangePassword(ctx context.Context, id, currentPassword, newPassword string) (int, error) {
+	var status int
+
+	body, err := json.Marshal(struct {
+		CurrentPassword string `json:"currentPassword"`
+		NewPassword     string `json:"newPassword"`
+	}{
+		CurrentPassword: currentPassword,
+		NewPassword:     newPassword,
+	})
+	if err != nil {
+		return status, fmt.Errorf("json.Marshal(): %v", err)
+	}
+
+	_, status, _, err = c.BaseClient.Post(ctx, PostHttpRequestInput{
+		Body:             body,
+		ValidStatusCodes: []int{http.StatusNoContent},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/users/%s/changePassword", id),
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("UsersClient.BaseClient.Post(): %v", err)
+	}
+
+	return status, nil
+}
+
+// ResetPassword resets the password of the specified user, and waits for the long-running reset operation to complete.
+// When newPassword is nil, a password is generated by the service and returned in the response.
+// This operation is only available in the beta API.
+func (c *UsersClient) ResetPassword(ctx context.Context, id string, newPassword *string) (*PasswordResetResponse, int, error) {
+	var status int
+
+	if err := c.BaseClient.requireBeta("UsersClient.ResetPassword()"); err != nil {
+		return nil, status, err
+	}
+
+	body, err := json.Marshal(struct {
+		NewPassword *string `json:"newPassword,omitempty"`
+	}{
+		NewPassword: newPassword,
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("json.Marshal(): %v", err)
+	}
+
+	resp, status, _, err := c.BaseClient.Post(ctx, PostHttpRequestInput{
+		Body:                   body,
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusAccepted},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/users/%s/authentication/passwordMethods/%s/resetPassword", id, PasswordAuthenticationMethodId),
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("UsersClient.BaseClient.Post(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var result PasswordResetResponse
+	if len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, &result); err != nil {
+			return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+		}
+	}
+
+	location := resp.Header.Get("Location")
+	if location == "" {
+		return nil, status, fmt.Errorf("UsersClient.ResetPassword(): no Location header was returned for the reset operation")
+	}
+
+	result.Operation, status, err = c.waitForAuthenticationOperation(ctx, id, path.Base(location), retryAfter(resp, passwordResetPollInterval))
+	if err != nil {
+		return &result, status, err
+	}
+
+	return &result, status, nil
+}
+
+// waitForAuthenticationOperation polls a long-running authentication operation for a user until it has completed.
+func (c *UsersClient) waitForAuthenticationOperation(ctx context.Context, userId, operationId string, interval time.Duration) (*LongRunningOperation, int, error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, 0, fmt.Errorf("waiting for operation %q: %v", operationId, ctx.Err())
+		case <-time.After(interval):
+		}
+
+		resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+			ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+			ValidStatusCodes:       []int{http.StatusOK},
+			Uri: Uri{
+				Entity: fmt.Sprintf("/users/%s/authentication/operations/%s", userId, operationId),
+			},
+		})
+		if err != nil {
+			return nil, status, fmt.Errorf("UsersClient.BaseClient.Get(): %v", err)
+		}
+
+		respBody, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+		}
+
+		var operation LongRunningOperation
+		if err := json.Unmarshal(respBody, &operation); err != nil {
+			return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+		}
+
+		if operation.Status != nil {
+			switch *operation.Status {
+			case LongRunningOperationStatusSucceeded:
+				return &operation, status, nil
+			case LongRunningOperationStatusFailed:
+				detail := "no detail was provided"
+				if operation.StatusDetail != nil {
+					detail = *operation.StatusDetail
+				}
+				return &operation, status, fmt.Errorf("operation %q failed: %s", operationId, detail)
+			}
+		}
+
+		interval = retryAfter(resp, passwordResetPollInterval)
+	}
+}
+
+// retryAfter returns the interval specified in seconds by the Retry-After header of a response, or the default interval.
+func retryAfter(resp *http.Response, defaultInterval time.Duration) time.Duration {
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+	return defaultInterval
+}
+
+// Contain takes the steps needed to contain a compromised user account. The account is disabled, sign-in sessions are
+// revoked, and all authentication methods other than the password are deleted, so that the user must re-register them.
+// Each step is attempted even if a previous step failed, and the outcome of each step is described in the report.
+// Authentication methods of types which cannot be deleted, such as software OATH tokens, are reported as skipped and
+// an error is returned, since they must be removed by other means.
+func (c *UsersClient) Contain(ctx context.Context, id string) (*ContainmentReport, int, error) {
+	report := ContainmentReport{
+		UserId: id,
+		Steps:  make([]ContainmentStep, 0),
+	}
+
+	var lastStatus, failedStatus int
+	record := func(step ContainmentStep, status int, err error) {
+		lastStatus = status
+		step.Status = ContainmentStepStatusSucceeded
+		if err != nil {
+			step.Status = ContainmentStepStatusFailed
+			step.Err = err
+			if failedStatus == 0 {
+				failedStatus = status
+			}
+		}
+		report.Steps = append(report.Steps, step)
+	}
+
+	disable := User{DirectoryObject: DirectoryObject{Id: &id}, AccountEnabled: utils.BoolPtr(false)}
+	status, err := c.Update(ctx, disable)
+	record(ContainmentStep{Action: ContainmentActionDisableAccount, Target: id}, status, err)
+
+	status, err = c.RevokeSignInSessions(ctx, id)
+	record(ContainmentStep{Action: ContainmentActionRevokeSignInSessions, Target: id}, status, err)
+
+	methodsClient := AuthenticationMethodsClient{BaseClient: c.BaseClient}
+	deleteMethods := map[odata.Type]func(context.Context, string, string) (int, error){
+		odata.TypeEmailAuthenticationMethod:                   methodsClient.DeleteEmailMethod,
+		odata.TypeFido2AuthenticationMethod:                   methodsClient.DeleteFido2Method,
+		odata.TypeMicrosoftAuthenticatorAuthenticationMethod:  methodsClient.DeleteMicrosoftAuthenticatorMethod,
+		odata.TypePhoneAuthenticationMethod:                   methodsClient.DeletePhoneMethod,
+		odata.TypeTemporaryAccessPassAuthenticationMethod:     methodsClient.DeleteTemporaryAccessPassMethod,
+		odata.TypeWindowsHelloForBusinessAuthenticationMethod: methodsClient.DeleteWindowsHelloMethod,
+	}
+
+	// the methods are listed without decoding them into models, so that methods of types which cannot be deleted here
+	// are still reported
+	methods, status, err := c.listAuthenticationMethods(ctx, id)
+	if err != nil {
+		record(ContainmentStep{Action: ContainmentActionDeleteAuthenticationMethod}, status, err)
+	} else {
+		for _, method := range methods {
+			if method.Type == odata.TypePasswordAuthenticationMethod {
+				// the password method cannot be deleted
+				continue
+			}
+
+			step := ContainmentStep{Action: ContainmentActionDeleteAuthenticationMethod, Target: method.Id, MethodType: method.Type}
+			deleteMethod, ok := deleteMethods[method.Type]
+			if !ok || method.Id == "" {
+				step.Status = ContainmentStepStatusSkipped
+				step.Err = fmt.Errorf("authentication method %q of type %q cannot be deleted and must be removed manually", method.Id, method.Type)
+				report.Steps = append(report.Steps, step)
+				continue
+			}
+
+			status, err = deleteMethod(ctx, id, method.Id)
+			record(step, status, err)
+		}
+	}
+
+	if failed := report.Failed(); len(failed) > 0 {
+		return &report, failedStatus, fmt.Errorf("UsersClient.Contain(): %d of %d steps failed for user %q", len(failed), len(report.Steps), id)
+	}
+	if skipped := report.Skipped(); len(skipped) > 0 {
+		return &report, lastStatus, fmt.Errorf("UsersClient.Contain(): %d authentication method(s) could not be deleted for user %q", len(skipped), id)
+	}
+
+	return &report, lastStatus, nil
+}
+
+// listAuthenticationMethods returns the ID and OData type of each authentication method registered for a user.
+func (c *UsersClient) listAuthenticationMethods(ctx context.Context, id string) ([]authenticationMethodRef, int, error) {
+	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+		ValidStatusCodes: []int{http.StatusOK},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/users/%s/authentication/methods", id),
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("UsersClient.BaseClient.Get(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var data struct {
+		Methods []authenticationMethodRef `json:"value"`
+	}
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return data.Methods, status, nil
+}
+
+type authenticationMethodRef struct {
+	Id   string     `json:"id"`
+	Type odata.Type `json:"@odata.type"`
+}
+
+// ListDirectReports returns the users and contacts that report to the specified user, optionally queried using OData.
+func (c *UsersClient) ListDirectReports(ctx context.Context, id string, query odata.Query) (*[]User, int, error) {
+	respBody, status, err := c.listRelationship(ctx, id, "directReports", query)
+	if err != nil {
+		return nil, status, err
+	}
+
+	var data struct {
+		DirectReports []User `json:"value"`
+	}
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &data.DirectReports, status, nil
+}
+
+// ListTransitiveMemberOf returns the groups, directory roles and administrative units that the specified user is a
+// member of, either directly or through nested groups, optionally queried using OData.
+func (c *UsersClient) ListTransitiveMemberOf(ctx context.Context, id string, query odata.Query) (*[]DirectoryObject, int, error) {
+	return c.listRelatedDirectoryObjects(ctx, id, "transitiveMemberOf", query)
+}
+
+// ListOwnedObjects returns the directory objects owned by the specified user, optionally queried using OData.
+func (c *UsersClient) ListOwnedObjects(ctx context.Context, id string, query odata.Query) (*[]DirectoryObject, int, error) {
+	return c.listRelatedDirectoryObjects(ctx, id, "ownedObjects", query)
+}
+
+// ListCreatedObjects returns the directory objects created by the specified user, optionally queried using OData.
+func (c *UsersClient) ListCreatedObjects(ctx context.Context, id string, query odata.Query) (*[]DirectoryObject, int, error) {
+	return c.listRelatedDirectoryObjects(ctx, id, "createdObjects", query)
+}
+
+// ListRegisteredDevices returns the devices registered by the specified user, optionally queried using OData.
+func (c *UsersClient) ListRegisteredDevices(ctx context.Context, id string, query odata.Query) (*[]Device, int, error) {
+	respBody, status, err := c.listRelationship(ctx, id, "registeredDevices", query)
+	if err != nil {
+		return nil, status, err
+	}
+
+	var data struct {
+		Devices []Device `json:"value"`
+	}
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &data.Devices, status, nil
+}
+
+func (c *UsersClient) listRelatedDirectoryObjects(ctx context.Context, id, relationship string, query odata.Query) (*[]DirectoryObject, int, error) {
+	respBody, status, err := c.listRelationship(ctx, id, relationship, query)
+	if err != nil {
+		return nil, status, err
+	}
+
+	var data struct {
+		Objects []DirectoryObject `json:"value"`
+	}
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &data.Objects, status, nil
+}
+
+func (c *UsersClient) listRelationship(ctx context.Context, id, relationship string, query odata.Query) ([]byte, int, error) {
+	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
+		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
+		DisablePaging:          query.Top > 0,
+		OData:                  query,
+		ValidStatusCodes:       []int{http.StatusOK},
+		Uri: Uri{
+			Entity: fmt.Sprintf("/users/%s/%s", id, relationship),
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("UsersClient.BaseClient.Get(): %v", err)
+	}
+
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	return respBody, status, nil
+}
+
+// GetPhoto retrieves the profile photo of the specified user. When size is empty, the largest available photo is
+// returned. The photo is streamed from the response, and the caller must close the returned reader.
+func (c *UsersClient) GetPhoto(ctx context.Context, id string, size ProfilePhotoSize) (io.ReadCloser, int, error) {
+	return c.BaseClient.getPhoto(ctx, "UsersClient", photoEntity(fmt.Sprintf("/users/%s", id), size))
+}
+
+// GetPhotoMetadata retrieves the metadata for the profile photo of the specified user, including its dimensions and
+// content type. When size is empty, the metadata for the largest available photo is returned.
+func (c *UsersClient) GetPhotoMetadata(ctx context.Context, id string, size ProfilePhotoSize) (*ProfilePhoto, int, error) {
+	return c.BaseClient.getPhotoMetadata(ctx, "UsersClient", photoEntity(fmt.Sprintf("/users/%s", id), size))
+}
+
+// ListPhotos returns the sizes in which the profile photo of the specified user is available.
+func (c *UsersClient) ListPhotos(ctx context.Context, id string) (*[]ProfilePhoto, int, error) {
+	return c.BaseClient.listPhotos(ctx, "UsersClient", fmt.Sprintf("/users/%s", id))
+}
@@ -2,6 +2,7 @@ package msgraph_test
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"testing"
@@ -60,15 +61,24 @@ func TestUsersClient(t *testing.T) {
 	testGroupsClient_AddMembers(t, c, groupChild)
 
 	testUsersClient_ListGroupMemberships(t, c, *user.ID())
+	testUsersClient_ListLicenseDetails(t, c, *user.ID())
+	testUsersClient_RevokeSignInSessions(t, c, *user.ID())
+	testUsersClient_ListTransitiveMemberOf(t, c, *user.ID())
+	testUsersClient_ListOwnedObjects(t, c, *user.ID())
+	testUsersClient_ListCreatedObjects(t, c, *user.ID())
+	testUsersClient_ListRegisteredDevices(t, c, *user.ID())
 	testGroupsClient_Delete(t, c, *groupParent.ID())
 	testGroupsClient_Delete(t, c, *groupChild.ID())
 
 	testUsersClient_AssignManager(t, c, *user.ID(), *manager)
 	testUsersClient_GetManager(t, c, *user.ID())
+	testUsersClient_ListDirectReports(t, c, *manager.ID())
+	testUsersClient_OrgChart(t, c, *manager.ID())
 	testUsersClient_DeleteManager(t, c, *user.ID())
 	testUsersClient_Delete(t, c, *manager.ID())
 
 	testUsersClient_UploadThumbnail(t, c, *user)
+	testUsersClient_GetPhoto(t, c, *user.ID())
 
 	testUsersClient_Delete(t, c, *user.ID())
 	testUsersClient_ListDeleted(t, c, *user.ID())
@@ -181,6 +191,96 @@ func testUsersClient_ListGroupMemberships(t *testing.T, c *test.Test, id string)
 	return
 }
 
+func testUsersClient_ListLicenseDetails(t *testing.T, c *test.Test, id string) (licenseDetails *[]msgraph.LicenseDetails) {
+	licenseDetails, _, err := c.UsersClient.ListLicenseDetails(c.Context, id)
+	if err != nil {
+		t.Fatalf("UsersClient.ListLicenseDetails(): %v", err)
+	}
+	if licenseDetails == nil {
+		t.Fatal("UsersClient.ListLicenseDetails(): licenseDetails was nil")
+	}
+	return
+}
+
+func testUsersClient_RevokeSignInSessions(t *testing.T, c *test.Test, id string) {
+	status, err := c.UsersClient.RevokeSignInSessions(c.Context, id)
+	if err != nil {
+		t.Fatalf("UsersClient.RevokeSignInSessions(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("UsersClient.RevokeSignInSessions(): invalid status: %d", status)
+	}
+}
+
+func testUsersClient_ListDirectReports(t *testing.T, c *test.Test, id string) (objects *[]msgraph.User) {
+	objects, _, err := c.UsersClient.ListDirectReports(c.Context, id, odata.Query{})
+	if err != nil {
+		t.Fatalf("UsersClient.ListDirectReports(): %v", err)
+	}
+	if objects == nil || len(*objects) == 0 {
+		t.Fatal("UsersClient.ListDirectReports(): expected at least one result")
+	}
+	return
+}
+
+func testUsersClient_ListTransitiveMemberOf(t *testing.T, c *test.Test, id string) (objects *[]msgraph.DirectoryObject) {
+	objects, _, err := c.UsersClient.ListTransitiveMemberOf(c.Context, id, odata.Query{})
+	if err != nil {
+		t.Fatalf("UsersClient.ListTransitiveMemberOf(): %v", err)
+	}
+	if objects == nil {
+		t.Fatal("UsersClient.ListTransitiveMemberOf(): objects was nil")
+	}
+	return
+}
+
+func testUsersClient_ListOwnedObjects(t *testing.T, c *test.Test, id string) (objects *[]msgraph.DirectoryObject) {
+	objects, _, err := c.UsersClient.ListOwnedObjects(c.Context, id, odata.Query{})
+	if err != nil {
+		t.Fatalf("UsersClient.ListOwnedObjects(): %v", err)
+	}
+	if objects == nil {
+		t.Fatal("UsersClient.ListOwnedObjects(): objects was nil")
+	}
+	return
+}
+
+func testUsersClient_ListCreatedObjects(t *testing.T, c *test.Test, id string) (objects *[]msgraph.DirectoryObject) {
+	objects, _, err := c.UsersClient.ListCreatedObjects(c.Context, id, odata.Query{})
+	if err != nil {
+		t.Fatalf("UsersClient.ListCreatedObjects(): %v", err)
+	}
+	if objects == nil {
+		t.Fatal("UsersClient.ListCreatedObjects(): objects was nil")
+	}
+	return
+}
+
+func testUsersClient_ListRegisteredDevices(t *testing.T, c *test.Test, id string) (objects *[]msgraph.Device) {
+	objects, _, err := c.UsersClient.ListRegisteredDevices(c.Context, id, odata.Query{})
+	if err != nil {
+		t.Fatalf("UsersClient.ListRegisteredDevices(): %v", err)
+	}
+	if objects == nil {
+		t.Fatal("UsersClient.ListRegisteredDevices(): objects was nil")
+	}
+	return
+}
+
+func testUsersClient_OrgChart(t *testing.T, c *test.Test, id string) (chart *msgraph.OrgChart) {
+	chart, _, err := c.UsersClient.OrgChart(c.Context, id, msgraph.OrgChartOptions{IncludeManagers: true})
+	if err != nil {
+		t.Fatalf("UsersClient.OrgChart(): %v", err)
+	}
+	if chart == nil || chart.Root == nil {
+		t.Fatal("UsersClient.OrgChart(): chart was nil")
+	}
+	if len(chart.Root.DirectReports) == 0 {
+		t.Fatal("UsersClient.OrgChart(): expected the root to have direct reports")
+	}
+	return
+}
+
 func testUsersClient_ListDeleted(t *testing.T, c *test.Test, expectedId string) (deletedUsers *[]msgraph.User) {
 	deletedUsers, status, err := c.UsersClient.ListDeleted(c.Context, odata.Query{
 		Filter: fmt.Sprintf("id eq '%s'", expectedId),
@@ -278,3 +378,21 @@ func testUsersClient_UploadThumbnail(t *testing.T, c *test.Test, a msgraph.User)
 		t.Fatalf("UsersClient.UploadThumbnailPhoto(): invalid status: %d", status)
 	}
 }
+
+func testUsersClient_GetPhoto(t *testing.T, c *test.Test, id string) {
+	photo, status, err := c.UsersClient.GetPhoto(c.Context, id, "")
+	if err != nil {
+		t.Fatalf("UsersClient.GetPhoto(): %v", err)
+	}
+	if status < 200 || status >= 300 {
+		t.Fatalf("UsersClient.GetPhoto(): invalid status: %d", status)
+	}
+	defer photo.Close()
+	b, err := io.ReadAll(photo)
+	if err != nil {
+		t.Fatalf("UsersClient.GetPhoto(): reading photo: %v", err)
+	}
+	if len(b) == 0 {
+		t.Fatal("UsersClient.GetPhoto(): photo was empty")
+	}
+}
@@ -2,6 +2,8 @@ package msgraph
 
 import (
 	"encoding/json"
+	"reflect"
+	"strings"
 
 	"github.com/hashicorp/go-uuid"
 )
@@ -30,3 +32,52 @@ func ValidateId(id *string) bool {
 	}
 	return true
 }
+
+// betaOnlyFields returns the JSON names of any populated fields in the provided model, including embedded and nested
+// structs, that are tagged with `api:"beta"`.
+func betaOnlyFields(model interface{}) []string {
+	return betaOnlyFieldsFromValue(reflect.ValueOf(model), "")
+}
+
+func betaOnlyFieldsFromValue(v reflect.Value, prefix string) (fields []string) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		fv := v.Field(i)
+
+		if f.Anonymous {
+			fields = append(fields, betaOnlyFieldsFromValue(fv, prefix)...)
+			continue
+		}
+
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" {
+			name = f.Name
+		}
+		name = prefix + name
+
+		if f.Tag.Get("api") == string(VersionBeta) {
+			if !fv.IsZero() {
+				fields = append(fields, name)
+			}
+			continue
+		}
+
+		fields = append(fields, betaOnlyFieldsFromValue(fv, name+".")...)
+	}
+
+	return
+}
@@ -151,6 +151,17 @@ const (
 	AccessReviewRecurrenceTypeAnnual     AccessReviewRecurrenceType = "annual"
 )
 
+type AdminConsentAction = string
+
+const (
+	AdminConsentActionAssignAppRole                  AdminConsentAction = "AssignAppRole"
+	AdminConsentActionCreateDelegatedPermissionGrant AdminConsentAction = "CreateDelegatedPermissionGrant"
+	AdminConsentActionCreateServicePrincipal         AdminConsentAction = "CreateServicePrincipal"
+	AdminConsentActionDeleteDelegatedPermissionGrant AdminConsentAction = "DeleteDelegatedPermissionGrant"
+	AdminConsentActionRemoveAppRoleAssignment        AdminConsentAction = "RemoveAppRoleAssignment"
+	AdminConsentActionUpdateDelegatedPermissionGrant AdminConsentAction = "UpdateDelegatedPermissionGrant"
+)
+
 type AdministrativeUnitVisibility = string
 
 const (
@@ -311,6 +322,27 @@ const (
 	BodyTypeHtml BodyType = "html"
 )
 
+type ClaimsSchemaSource = string
+
+const (
+	ClaimsSchemaSourceApplication    ClaimsSchemaSource = "application"
+	ClaimsSchemaSourceAudience       ClaimsSchemaSource = "audience"
+	ClaimsSchemaSourceCompany        ClaimsSchemaSource = "company"
+	ClaimsSchemaSourceResource       ClaimsSchemaSource = "resource"
+	ClaimsSchemaSourceTransformation ClaimsSchemaSource = "transformation"
+	ClaimsSchemaSourceUser           ClaimsSchemaSource = "user"
+)
+
+type ClaimsTransformationMethod = string
+
+const (
+	ClaimsTransformationMethodExtractMailPrefix ClaimsTransformationMethod = "ExtractMailPrefix"
+	ClaimsTransformationMethodJoin              ClaimsTransformationMethod = "Join"
+	ClaimsTransformationMethodRegexReplace      ClaimsTransformationMethod = "RegexReplace"
+	ClaimsTransformationMethodToLowercase       ClaimsTransformationMethod = "ToLowercase"
+	ClaimsTransformationMethodToUppercase       ClaimsTransformationMethod = "ToUppercase"
+)
+
 type ConsentProvidedForMinor = StringNullWhenEmpty
 
 const (
@@ -320,6 +352,22 @@ const (
 	ConsentProvidedForMinorNotRequired ConsentProvidedForMinor = "NotRequired"
 )
 
+type ContainmentAction = string
+
+const (
+	ContainmentActionDeleteAuthenticationMethod ContainmentAction = "DeleteAuthenticationMethod"
+	ContainmentActionDisableAccount             ContainmentAction = "DisableAccount"
+	ContainmentActionRevokeSignInSessions       ContainmentAction = "RevokeSignInSessions"
+)
+
+type ContainmentStepStatus = string
+
+const (
+	ContainmentStepStatusFailed    ContainmentStepStatus = "Failed"
+	ContainmentStepStatusSkipped   ContainmentStepStatus = "Skipped"
+	ContainmentStepStatusSucceeded ContainmentStepStatus = "Succeeded"
+)
+
 type CredentialUsageSummaryPeriod = string
 
 const (
@@ -465,6 +513,30 @@ const (
 	ConnectedOrganizationStateUnknownFutureValue ConnectedOrganizationState = "unknownFutureValue"
 )
 
+type ConnectorGroupRegion = string
+
+const (
+	ConnectorGroupRegionAsia               ConnectorGroupRegion = "asia"
+	ConnectorGroupRegionAus                ConnectorGroupRegion = "aus"
+	ConnectorGroupRegionEur                ConnectorGroupRegion = "eur"
+	ConnectorGroupRegionInd                ConnectorGroupRegion = "ind"
+	ConnectorGroupRegionNam                ConnectorGroupRegion = "nam"
+	ConnectorGroupRegionUnknownFutureValue ConnectorGroupRegion = "unknownFutureValue"
+)
+
+type ConnectorGroupType = string
+
+const (
+	ConnectorGroupTypeApplicationProxy ConnectorGroupType = "applicationProxy"
+)
+
+type ConnectorStatus = string
+
+const (
+	ConnectorStatusActive   ConnectorStatus = "active"
+	ConnectorStatusInactive ConnectorStatus = "inactive"
+)
+
 type DaysOfWeekType = string
 
 const (
@@ -582,6 +654,30 @@ const (
 	FirstDayOfWeekSaturday  FirstDayOfWeek = "staturday"
 )
 
+type GitHubActionsEntityType = string
+
+const (
+	GitHubActionsEntityTypeBranch      GitHubActionsEntityType = "branch"
+	GitHubActionsEntityTypeEnvironment GitHubActionsEntityType = "environment"
+	GitHubActionsEntityTypePullRequest GitHubActionsEntityType = "pull_request"
+	GitHubActionsEntityTypeTag         GitHubActionsEntityType = "tag"
+)
+
+type GitLabRefType = string
+
+const (
+	GitLabRefTypeBranch GitLabRefType = "branch"
+	GitLabRefTypeTag    GitLabRefType = "tag"
+)
+
+type GroupLifecyclePolicyManagedGroupTypes = string
+
+const (
+	GroupLifecyclePolicyManagedGroupTypesAll      GroupLifecyclePolicyManagedGroupTypes = "All"
+	GroupLifecyclePolicyManagedGroupTypesNone     GroupLifecyclePolicyManagedGroupTypes = "None"
+	GroupLifecyclePolicyManagedGroupTypesSelected GroupLifecyclePolicyManagedGroupTypes = "Selected"
+)
+
 type GroupMembershipRuleProcessingState = string
 
 const (
@@ -667,6 +763,22 @@ const (
 	KeyCredentialUsageVerify KeyCredentialUsage = "Verify"
 )
 
+type LicenseAppliesTo = string
+
+const (
+	LicenseAppliesToCompany LicenseAppliesTo = "Company"
+	LicenseAppliesToUser    LicenseAppliesTo = "User"
+)
+
+type LongRunningOperationStatus = string
+
+const (
+	LongRunningOperationStatusFailed     LongRunningOperationStatus = "failed"
+	LongRunningOperationStatusNotStarted LongRunningOperationStatus = "notStarted"
+	LongRunningOperationStatusRunning    LongRunningOperationStatus = "running"
+	LongRunningOperationStatusSucceeded  LongRunningOperationStatus = "succeeded"
+)
+
 type OnPremisesGroupType = string
 
 const (
@@ -675,6 +787,13 @@ const (
 	UniversalSecurityGroup            OnPremisesGroupType = "UniversalSecurityGroup"
 )
 
+type OnPremisesPublishingExternalAuthenticationType = string
+
+const (
+	OnPremisesPublishingExternalAuthenticationTypeAadPreAuthentication OnPremisesPublishingExternalAuthenticationType = "aadPreAuthentication"
+	OnPremisesPublishingExternalAuthenticationTypePassthru             OnPremisesPublishingExternalAuthenticationType = "passthru"
+)
+
 type Members []DirectoryObject
 
 func (o Members) MarshalJSON() ([]byte, error) {
@@ -699,6 +818,30 @@ func (o *Members) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+type MemberResultStatus = string
+
+const (
+	MemberResultStatusAdded          MemberResultStatus = "Added"
+	MemberResultStatusAlreadyPresent MemberResultStatus = "AlreadyPresent"
+	MemberResultStatusFailed         MemberResultStatus = "Failed"
+)
+
+type MembershipChangeAction = string
+
+const (
+	MembershipChangeActionAdd    MembershipChangeAction = "Add"
+	MembershipChangeActionRemove MembershipChangeAction = "Remove"
+)
+
+type MembershipChangeStatus = string
+
+const (
+	MembershipChangeStatusApplied        MembershipChangeStatus = "Applied"
+	MembershipChangeStatusAlreadyApplied MembershipChangeStatus = "AlreadyApplied"
+	MembershipChangeStatusFailed         MembershipChangeStatus = "Failed"
+	MembershipChangeStatusPlanned        MembershipChangeStatus = "Planned"
+)
+
 type MethodUsabilityReason string
 
 const (
@@ -757,6 +900,20 @@ const (
 	PreferredSingleSignOnModeSaml         PreferredSingleSignOnMode = "saml"
 )
 
+type ProfilePhotoSize = string
+
+const (
+	ProfilePhotoSize48x48   ProfilePhotoSize = "48x48"
+	ProfilePhotoSize64x64   ProfilePhotoSize = "64x64"
+	ProfilePhotoSize96x96   ProfilePhotoSize = "96x96"
+	ProfilePhotoSize120x120 ProfilePhotoSize = "120x120"
+	ProfilePhotoSize240x240 ProfilePhotoSize = "240x240"
+	ProfilePhotoSize360x360 ProfilePhotoSize = "360x360"
+	ProfilePhotoSize432x432 ProfilePhotoSize = "432x432"
+	ProfilePhotoSize504x504 ProfilePhotoSize = "504x504"
+	ProfilePhotoSize648x648 ProfilePhotoSize = "648x648"
+)
+
 type PrivilegedAccessGroupAction = string
 
 const (
@@ -900,6 +1057,17 @@ func (m *SchemaExtensionMap) UnmarshalJSON(data []byte) error {
 	return json.Unmarshal(data, m2)
 }
 
+type ServicePlanProvisioningStatus = string
+
+const (
+	ServicePlanProvisioningStatusDisabled            ServicePlanProvisioningStatus = "Disabled"
+	ServicePlanProvisioningStatusError               ServicePlanProvisioningStatus = "Error"
+	ServicePlanProvisioningStatusPendingActivation   ServicePlanProvisioningStatus = "PendingActivation"
+	ServicePlanProvisioningStatusPendingInput        ServicePlanProvisioningStatus = "PendingInput"
+	ServicePlanProvisioningStatusPendingProvisioning ServicePlanProvisioningStatus = "PendingProvisioning"
+	ServicePlanProvisioningStatusSuccess             ServicePlanProvisioningStatus = "Success"
+)
+
 type SignInAudience = string
 
 const (
@@ -909,6 +1077,23 @@ const (
 	SignInAudiencePersonalMicrosoftAccount           SignInAudience = "PersonalMicrosoftAccount"
 )
 
+type SubscribedSkuCapabilityStatus = string
+
+const (
+	SubscribedSkuCapabilityStatusDeleted   SubscribedSkuCapabilityStatus = "Deleted"
+	SubscribedSkuCapabilityStatusEnabled   SubscribedSkuCapabilityStatus = "Enabled"
+	SubscribedSkuCapabilityStatusLockedOut SubscribedSkuCapabilityStatus = "LockedOut"
+	SubscribedSkuCapabilityStatusSuspended SubscribedSkuCapabilityStatus = "Suspended"
+	SubscribedSkuCapabilityStatusWarning   SubscribedSkuCapabilityStatus = "Warning"
+)
+
+type TerraformCloudRunPhase = string
+
+const (
+	TerraformCloudRunPhaseApply TerraformCloudRunPhase = "apply"
+	TerraformCloudRunPhasePlan  TerraformCloudRunPhase = "plan"
+)
+
 type UnifiedRoleScheduleRequestAction = string
 
 const (
@@ -11,19 +11,25 @@ import (
 	"github.com/hashicorp/go-azure-sdk/sdk/odata"
 )
 
+// WindowsAutopilotDeploymentProfilesClient performs operations on Windows Autopilot Deployment Profiles.
+// These operations are only available in the beta API.
 type WindowsAutopilotDeploymentProfilesClient struct {
 	BaseClient Client
 }
 
 // NewWindowsAutopilotDeploymentProfilesClient returns a new WindowsAutopilotDeploymentProfilesClient.
-func NewWindowsAutopilotDeploymentProfilesClient() *WindowsAutopilotDeploymentProfilesClient {
+func NewWindowsAutopilotDeploymentProfilesClient(opts ...ClientOption) *WindowsAutopilotDeploymentProfilesClient {
 	return &WindowsAutopilotDeploymentProfilesClient{
-		BaseClient: NewClient(VersionBeta),
+		BaseClient: NewClient(VersionBeta, opts...).withFeature(FeatureWindowsAutopilot),
 	}
 }
 
 // List returns a list of Windows Autopilot Deployment Profiles, optionally queried using OData.
 func (c *WindowsAutopilotDeploymentProfilesClient) List(ctx context.Context, query odata.Query) (*[]WindowsAutopilotDeploymentProfile, int, error) {
+	if err := c.BaseClient.requireBeta("WindowsAutopilotDeploymentProfilesClient.List()"); err != nil {
+		return nil, 0, err
+	}
+
 	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
 		DisablePaging:    query.Top > 0,
 		OData:            query,
@@ -54,6 +60,10 @@ func (c *WindowsAutopilotDeploymentProfilesClient) List(ctx context.Context, que
 
 // Create creates a new WindowsAutopilotDeploymentProfile.
 func (c *WindowsAutopilotDeploymentProfilesClient) Create(ctx context.Context, profile WindowsAutopilotDeploymentProfile) (*WindowsAutopilotDeploymentProfile, int, error) {
+	if err := c.BaseClient.requireBeta("WindowsAutopilotDeploymentProfilesClient.Create()"); err != nil {
+		return nil, 0, err
+	}
+
 	var status int
 	body, err := json.Marshal(profile)
 	if err != nil {
@@ -87,6 +97,10 @@ func (c *WindowsAutopilotDeploymentProfilesClient) Create(ctx context.Context, p
 
 // Get retrieves a WindowsAutopilotDeploymentProfile.
 func (c *WindowsAutopilotDeploymentProfilesClient) Get(ctx context.Context, id string, query odata.Query) (*WindowsAutopilotDeploymentProfile, int, error) {
+	if err := c.BaseClient.requireBeta("WindowsAutopilotDeploymentProfilesClient.Get()"); err != nil {
+		return nil, 0, err
+	}
+
 	resp, status, _, err := c.BaseClient.Get(ctx, GetHttpRequestInput{
 		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
 		OData:                  query,
@@ -115,6 +129,10 @@ func (c *WindowsAutopilotDeploymentProfilesClient) Get(ctx context.Context, id s
 
 // Update amends an existing WindowsAutopilotDeploymentProfile.
 func (c *WindowsAutopilotDeploymentProfilesClient) Update(ctx context.Context, profile WindowsAutopilotDeploymentProfile) (int, error) {
+	if err := c.BaseClient.requireBeta("WindowsAutopilotDeploymentProfilesClient.Update()"); err != nil {
+		return 0, err
+	}
+
 	var status int
 
 	if profile.ID == nil {
@@ -143,6 +161,10 @@ func (c *WindowsAutopilotDeploymentProfilesClient) Update(ctx context.Context, p
 
 // Delete removes a WindowsAutopilotDeploymentProfile.
 func (c *WindowsAutopilotDeploymentProfilesClient) Delete(ctx context.Context, id string) (int, error) {
+	if err := c.BaseClient.requireBeta("WindowsAutopilotDeploymentProfilesClient.Delete()"); err != nil {
+		return 0, err
+	}
+
 	_, status, _, err := c.BaseClient.Delete(ctx, DeleteHttpRequestInput{
 		ConsistencyFailureFunc: RetryOn404ConsistencyFailureFunc,
 		ValidStatusCodes:       []int{http.StatusOK, http.StatusNoContent},